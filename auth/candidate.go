@@ -26,14 +26,49 @@ func GetCandidateFromCtx(ctx context.Context) *Candidate {
 
 // Candidate represent an authenticated candidate
 type Candidate struct {
-	ID        int64 `json:"id"`
-	SessionID int64 `json:"session_id"`
+	ID               int64              `json:"id"`
+	SessionID        int64              `json:"session_id"`
+	MFAAuthenticated bool               `json:"mfa_authenticated"`
+	Roles            []model.Role       `json:"roles"`
+	ImpersonatorID   int64              `json:"impersonator_id,omitempty"`
+	APIKeyID         int64              `json:"api_key_id,omitempty"`
+	Scopes           []model.Permission `json:"scopes,omitempty"`
+}
+
+// IsImpersonated check the candidate is impersonated by an admin
+func (c *Candidate) IsImpersonated() bool {
+	return c.ImpersonatorID != 0
+}
+
+// IsAPIKey check the request is authenticated with the api key instead of the candidate's session
+func (c *Candidate) IsAPIKey() bool {
+	return c.toRequester().IsAPIKey()
+}
+
+// HasPermission check whether the candidate's roles is granted the permission,
+// the api key is only granted its scopes
+func (c *Candidate) HasPermission(permission model.Permission) bool {
+	return c.toRequester().HasPermission(permission)
+}
+
+// toRequester return the fields the permission check depends on as model.Candidate,
+// so the check is implemented once by the model
+func (c *Candidate) toRequester() *model.Candidate {
+	return &model.Candidate{
+		ID:       c.ID,
+		Roles:    c.Roles,
+		APIKeyID: c.APIKeyID,
+		Scopes:   c.Scopes,
+	}
 }
 
 // NewCandidateFromSession return new candidate from session
 func NewCandidateFromSession(sess model.Session) Candidate {
 	return Candidate{
-		ID:        sess.CandidateID,
-		SessionID: sess.ID,
+		ID:               sess.CandidateID,
+		SessionID:        sess.ID,
+		MFAAuthenticated: sess.MFAAuthenticated,
+		Roles:            sess.Roles,
+		ImpersonatorID:   sess.ImpersonatorID.Int64,
 	}
 }
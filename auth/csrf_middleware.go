@@ -0,0 +1,49 @@
+package auth
+
+import (
+	"crypto/subtle"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+// HeaderCSRFToken the header to submit the csrf token cookie's value
+const HeaderCSRFToken = "X-CSRF-Token"
+
+// VerifyCSRFToken guards the state-changing requests authenticated by the session cookies with the double-submit csrf token,
+// the csrf token cookie's value must be submitted on the `X-CSRF-Token` header.
+// The requests without the session cookies or with the `Authorization` header can't be forged by other sites, so they are skipped.
+func VerifyCSRFToken() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			switch req.Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+				return next(c)
+			}
+
+			if req.Header.Get(_headerAuthorization) != "" || !hasSessionCookie(req) {
+				return next(c)
+			}
+
+			cookie, err := req.Cookie(CSRFTokenCookieName)
+			if err != nil || cookie.Value == "" {
+				return errorResp(http.StatusForbidden, "csrf token is invalid")
+			}
+
+			if subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(req.Header.Get(HeaderCSRFToken))) != 1 {
+				return errorResp(http.StatusForbidden, "csrf token is invalid")
+			}
+
+			return next(c)
+		}
+	}
+}
+
+func hasSessionCookie(req *http.Request) bool {
+	for _, name := range []string{AccessTokenCookieName, RefreshTokenCookieName} {
+		if cookie, err := req.Cookie(name); err == nil && cookie.Value != "" {
+			return true
+		}
+	}
+	return false
+}
@@ -15,18 +15,33 @@ import (
 
 const (
 	_authScheme          = "Bearer"
+	_apiKeyAuthScheme    = "ApiKey"
 	_headerAuthorization = "Authorization"
 )
 
+// the session cookies on the cookie session mode
+const (
+	AccessTokenCookieName  = "access_token"
+	RefreshTokenCookieName = "refresh_token"
+	CSRFTokenCookieName    = "csrf_token"
+)
+
 // CandidateAuthenticator to perform candidate authentication
 type CandidateAuthenticator interface {
 	AuthenticateToken(ctx context.Context, accessToken string) (*Candidate, error)
 }
 
+// APIKeyAuthenticator to perform the machine authentication with the api key
+type APIKeyAuthenticator interface {
+	AuthenticateAPIKey(ctx context.Context, apiKey string) (*Candidate, error)
+}
+
 // AuthenticationMiddleware middleware for authentication
 type AuthenticationMiddleware struct {
-	cacheManager    cacher.CacheManager
-	candidateAuther CandidateAuthenticator
+	cacheManager        cacher.CacheManager
+	candidateAuther     CandidateAuthenticator
+	accessTokenVerifier AccessTokenVerifier
+	apiKeyAuther        APIKeyAuthenticator
 }
 
 // NewAuthenticationMiddleware AuthMiddleware constructor
@@ -40,31 +55,153 @@ func NewAuthenticationMiddleware(
 	}
 }
 
-// AuthenticateAccessToken authenticate access token from http `Authorization` header and load a Candidate to context
+// SetAccessTokenVerifier set the verifier of the signed access token,
+// the signed access token is verified offline without looking up the session
+func (a *AuthenticationMiddleware) SetAccessTokenVerifier(verifier AccessTokenVerifier) {
+	a.accessTokenVerifier = verifier
+}
+
+// SetAPIKeyAuthenticator set the authenticator of the `ApiKey` authorization scheme,
+// the api key is rejected when it's not set
+func (a *AuthenticationMiddleware) SetAPIKeyAuthenticator(apiKeyAuther APIKeyAuthenticator) {
+	a.apiKeyAuther = apiKeyAuther
+}
+
+// AuthenticateAccessToken authenticate access token from http `Authorization` header or the access token cookie,
+// then load a Candidate to context
 func (a *AuthenticationMiddleware) AuthenticateAccessToken() echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
-			token := getAccessToken(c.Request())
+			token, isAPIKey := getAccessToken(c.Request())
+			if isAPIKey {
+				return a.authenticateAPIKey(c, next, token)
+			}
+
 			return a.authenticateAccessToken(c, next, token)
 		}
 	}
 }
 
-// MustAuthenticateAccessToken must authenticate access token from http `Authorization` header and load a Candidate to context
+// MustAuthenticateAccessToken must authenticate access token from http `Authorization` header or the access token cookie,
+// then load a Candidate to context
 // Differ from AuthenticateAccessToken, if no token provided then return Unauthenticated
 func (a *AuthenticationMiddleware) MustAuthenticateAccessToken() echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
-			token := getAccessToken(c.Request())
+			token, isAPIKey := getAccessToken(c.Request())
 			if token == "" {
 				return errorResp(http.StatusUnauthorized, "user is unauthenticated")
 			}
 
+			if isAPIKey {
+				return a.authenticateAPIKey(c, next, token)
+			}
+
 			return a.authenticateAccessToken(c, next, token)
 		}
 	}
 }
 
+// RequireMFA requires the authenticated candidate's session to be MFA-authenticated,
+// must be placed after MustAuthenticateAccessToken
+func (a *AuthenticationMiddleware) RequireMFA() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			candidate := GetCandidateFromCtx(c.Request().Context())
+			if candidate == nil {
+				return errorResp(http.StatusUnauthorized, "user is unauthenticated")
+			}
+
+			if !candidate.MFAAuthenticated {
+				return errorResp(http.StatusForbidden, "mfa is required")
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// RequirePermission requires the authenticated candidate's roles to be granted the permission,
+// must be placed after MustAuthenticateAccessToken
+func (a *AuthenticationMiddleware) RequirePermission(permission model.Permission) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			candidate := GetCandidateFromCtx(c.Request().Context())
+			if candidate == nil {
+				return errorResp(http.StatusUnauthorized, "user is unauthenticated")
+			}
+
+			if !candidate.HasPermission(permission) {
+				return errorResp(http.StatusForbidden, "permission denied")
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// RejectImpersonation rejects the sensitive actions on the impersonation session,
+// must be placed after MustAuthenticateAccessToken
+func (a *AuthenticationMiddleware) RejectImpersonation() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			candidate := GetCandidateFromCtx(c.Request().Context())
+			if candidate == nil {
+				return errorResp(http.StatusUnauthorized, "user is unauthenticated")
+			}
+
+			if candidate.IsImpersonated() {
+				return errorResp(http.StatusForbidden, "not allowed on impersonation session")
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// RejectAPIKey rejects the candidate's own actions on the request authenticated with the api key,
+// must be placed after MustAuthenticateAccessToken
+func (a *AuthenticationMiddleware) RejectAPIKey() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			candidate := GetCandidateFromCtx(c.Request().Context())
+			if candidate == nil {
+				return errorResp(http.StatusUnauthorized, "user is unauthenticated")
+			}
+
+			if candidate.IsAPIKey() {
+				return errorResp(http.StatusForbidden, "not allowed with api key")
+			}
+
+			return next(c)
+		}
+	}
+}
+
+func (a *AuthenticationMiddleware) authenticateAPIKey(c echo.Context, next echo.HandlerFunc, apiKey string) error {
+	if a.apiKeyAuther == nil || apiKey == "" {
+		return errorResp(http.StatusUnauthorized, "api key is invalid")
+	}
+
+	candidate, err := a.apiKeyAuther.AuthenticateAPIKey(c.Request().Context(), apiKey)
+	switch status.Code(err) {
+	case codes.OK:
+		if candidate == nil { // safety check
+			return errorResp(http.StatusUnauthorized, "api key is invalid")
+		}
+
+		setCandidateToRequest(c, *candidate)
+		return next(c)
+	case codes.NotFound:
+		return errorResp(http.StatusUnauthorized, "api key is invalid")
+	case codes.Unauthenticated:
+		return errorResp(http.StatusUnauthorized, "api key expired")
+	default:
+		logrus.Error(err)
+		return errorResp(http.StatusInternalServerError, "system error")
+	}
+}
+
 func (a *AuthenticationMiddleware) authenticateAccessToken(c echo.Context, next echo.HandlerFunc, token string) error {
 	// only load user to context when token presented
 	if token == "" {
@@ -72,6 +209,20 @@ func (a *AuthenticationMiddleware) authenticateAccessToken(c echo.Context, next
 	}
 	ctx := c.Request().Context()
 
+	if a.accessTokenVerifier != nil && IsJWT(token) {
+		candidate, err := a.accessTokenVerifier.VerifyAccessToken(token)
+		switch err {
+		case nil:
+		case ErrAccessTokenExpired:
+			return errorResp(http.StatusUnauthorized, "token expired")
+		default:
+			return errorResp(http.StatusUnauthorized, "token is invalid")
+		}
+
+		setCandidateToRequest(c, *candidate)
+		return next(c)
+	}
+
 	session, err := a.findSessionFromCache(token)
 	switch err {
 	default:
@@ -85,8 +236,7 @@ func (a *AuthenticationMiddleware) authenticateAccessToken(c echo.Context, next
 			return errorResp(http.StatusUnauthorized, "token expired")
 		}
 
-		ctx := SetUserToCtx(c.Request().Context(), NewCandidateFromSession(*session))
-		c.SetRequest(c.Request().WithContext(ctx))
+		setCandidateToRequest(c, NewCandidateFromSession(*session))
 		return next(c)
 	}
 
@@ -98,8 +248,7 @@ func (a *AuthenticationMiddleware) authenticateAccessToken(c echo.Context, next
 			return next(c)
 		}
 
-		ctx := SetUserToCtx(c.Request().Context(), *userSession)
-		c.SetRequest(c.Request().WithContext(ctx))
+		setCandidateToRequest(c, *userSession)
 		return next(c)
 	case codes.NotFound:
 		return errorResp(http.StatusBadRequest, "token is invalid")
@@ -137,14 +286,48 @@ func (a *AuthenticationMiddleware) findSessionFromCache(token string) (*model.Se
 	return sess, nil
 }
 
-func getAccessToken(req *http.Request) (accessToken string) {
-	authHeaders := strings.Split(req.Header.Get(_headerAuthorization), " ")
+// setCandidateToRequest load the candidate to the request context,
+// every request on the impersonation session is logged for the audit
+func setCandidateToRequest(c echo.Context, candidate Candidate) {
+	if candidate.IsImpersonated() {
+		logrus.WithFields(logrus.Fields{
+			"securityEvent":  "impersonated_request",
+			"impersonatorID": candidate.ImpersonatorID,
+			"candidateID":    candidate.ID,
+			"sessionID":      candidate.SessionID,
+			"method":         c.Request().Method,
+			"path":           c.Request().URL.Path,
+		}).Info("request on impersonation session")
+	}
+
+	ctx := SetUserToCtx(c.Request().Context(), candidate)
+	c.SetRequest(c.Request().WithContext(ctx))
+}
 
-	if (len(authHeaders) != 2) || (authHeaders[0] != _authScheme) {
-		return ""
+// getAccessToken get the access token from the `Authorization` header with either the `Bearer` or the `ApiKey` scheme,
+// the access token cookie is only read when the header is not set
+func getAccessToken(req *http.Request) (accessToken string, isAPIKey bool) {
+	authorization := req.Header.Get(_headerAuthorization)
+	if authorization == "" {
+		if cookie, err := req.Cookie(AccessTokenCookieName); err == nil {
+			return strings.TrimSpace(cookie.Value), false
+		}
+		return "", false
+	}
+
+	authHeaders := strings.Split(authorization, " ")
+	if len(authHeaders) != 2 {
+		return "", false
 	}
 
-	return strings.TrimSpace(authHeaders[1])
+	switch authHeaders[0] {
+	case _authScheme:
+		return strings.TrimSpace(authHeaders[1]), false
+	case _apiKeyAuthScheme:
+		return strings.TrimSpace(authHeaders[1]), true
+	default:
+		return "", false
+	}
 }
 
 func errorResp(code int, message string) error {
@@ -0,0 +1,69 @@
+package auth
+
+import (
+	"context"
+	"github.com/irvankadhafi/talent-hub-service/internal/model"
+	pb "github.com/irvankadhafi/talent-hub-service/pb/auth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// GRPCCandidateAuthenticator authenticate the access token against the AuthService,
+// so the other services can use AuthenticationMiddleware without accessing the sessions
+type GRPCCandidateAuthenticator struct {
+	client pb.AuthServiceClient
+}
+
+// NewGRPCCandidateAuthenticator GRPCCandidateAuthenticator constructor
+func NewGRPCCandidateAuthenticator(conn grpc.ClientConnInterface) *GRPCCandidateAuthenticator {
+	return &GRPCCandidateAuthenticator{
+		client: pb.NewAuthServiceClient(conn),
+	}
+}
+
+// AuthenticateToken authenticate access token, the grpc status error is returned as is
+func (g *GRPCCandidateAuthenticator) AuthenticateToken(ctx context.Context, accessToken string) (*Candidate, error) {
+	res, err := g.client.AuthenticateToken(ctx, &pb.AuthenticateTokenRequest{AccessToken: accessToken})
+	if err != nil {
+		return nil, err
+	}
+
+	roles := make([]model.Role, 0, len(res.GetRoles()))
+	for _, role := range res.GetRoles() {
+		roles = append(roles, model.Role(role))
+	}
+
+	return &Candidate{
+		ID:               res.GetId(),
+		SessionID:        res.GetSessionId(),
+		MFAAuthenticated: res.GetMfaAuthenticated(),
+		Roles:            roles,
+		ImpersonatorID:   res.GetImpersonatorId(),
+	}, nil
+}
+
+// ServiceTokenCredentials send the shared service token on every call to the AuthService,
+// use it with grpc.WithPerRPCCredentials
+type ServiceTokenCredentials struct {
+	token      string
+	requireTLS bool
+}
+
+// NewServiceTokenCredentials ServiceTokenCredentials constructor, requireTLS should only be false
+// when the AuthService is reached over the loopback
+func NewServiceTokenCredentials(token string, requireTLS bool) credentials.PerRPCCredentials {
+	return &ServiceTokenCredentials{
+		token:      token,
+		requireTLS: requireTLS,
+	}
+}
+
+// GetRequestMetadata :nodoc:
+func (s *ServiceTokenCredentials) GetRequestMetadata(_ context.Context, _ ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + s.token}, nil
+}
+
+// RequireTransportSecurity :nodoc:
+func (s *ServiceTokenCredentials) RequireTransportSecurity() bool {
+	return s.requireTLS
+}
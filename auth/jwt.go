@@ -0,0 +1,163 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt"
+	"github.com/irvankadhafi/talent-hub-service/internal/model"
+	"github.com/irvankadhafi/talent-hub-service/utils"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const jwtIDLength = 16
+
+// jwt errors
+var (
+	ErrAccessTokenInvalid = errors.New("access token is invalid")
+	ErrAccessTokenExpired = errors.New("access token is expired")
+)
+
+// AccessTokenVerifier to verify the signed access token without looking up the session
+type AccessTokenVerifier interface {
+	VerifyAccessToken(accessToken string) (*Candidate, error)
+}
+
+type accessTokenClaims struct {
+	jwt.StandardClaims
+	SessionID        int64        `json:"sid"`
+	MFAAuthenticated bool         `json:"mfa"`
+	Roles            []model.Role `json:"roles"`
+	ImpersonatorID   int64        `json:"imp,omitempty"`
+}
+
+// JWTKeySet the RSA keys to sign and verify the access tokens.
+// Only the active key signs the new tokens, the other keys are kept to verify the tokens signed before the rotation.
+type JWTKeySet struct {
+	issuer      string
+	activeKeyID string
+	privateKeys map[string]*rsa.PrivateKey
+}
+
+// NewJWTKeySet JWTKeySet constructor
+func NewJWTKeySet(issuer, activeKeyID string, privateKeys map[string]*rsa.PrivateKey) (*JWTKeySet, error) {
+	if _, ok := privateKeys[activeKeyID]; !ok {
+		return nil, fmt.Errorf("active key %q is not found", activeKeyID)
+	}
+
+	return &JWTKeySet{
+		issuer:      issuer,
+		activeKeyID: activeKeyID,
+		privateKeys: privateKeys,
+	}, nil
+}
+
+// SignAccessToken sign the session's access token with the active key
+func (k *JWTKeySet) SignAccessToken(session *model.Session) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, accessTokenClaims{
+		StandardClaims: jwt.StandardClaims{
+			Id:        utils.GenerateRandomAlphanumeric(jwtIDLength),
+			Issuer:    k.issuer,
+			Subject:   strconv.FormatInt(session.CandidateID, 10),
+			IssuedAt:  time.Now().Unix(),
+			ExpiresAt: session.AccessTokenExpiredAt.Unix(),
+		},
+		SessionID:        session.ID,
+		MFAAuthenticated: session.MFAAuthenticated,
+		Roles:            session.Roles,
+		ImpersonatorID:   session.ImpersonatorID.Int64,
+	})
+	token.Header["kid"] = k.activeKeyID
+
+	return token.SignedString(k.privateKeys[k.activeKeyID])
+}
+
+// VerifyAccessToken verify the access token signature and expiry, then return the authenticated candidate
+func (k *JWTKeySet) VerifyAccessToken(accessToken string) (*Candidate, error) {
+	claims := &accessTokenClaims{}
+	_, err := jwt.ParseWithClaims(accessToken, claims, func(token *jwt.Token) (interface{}, error) {
+		if token.Method != jwt.SigningMethodRS256 {
+			return nil, ErrAccessTokenInvalid
+		}
+
+		kid, _ := token.Header["kid"].(string)
+		key, ok := k.privateKeys[kid]
+		if !ok {
+			return nil, ErrAccessTokenInvalid
+		}
+
+		return &key.PublicKey, nil
+	})
+
+	var validationErr *jwt.ValidationError
+	switch {
+	case err == nil:
+	case errors.As(err, &validationErr) && validationErr.Errors == jwt.ValidationErrorExpired:
+		return nil, ErrAccessTokenExpired
+	default:
+		return nil, ErrAccessTokenInvalid
+	}
+
+	if !claims.VerifyIssuer(k.issuer, true) {
+		return nil, ErrAccessTokenInvalid
+	}
+
+	candidateID, err := strconv.ParseInt(claims.Subject, 10, 64)
+	if err != nil {
+		return nil, ErrAccessTokenInvalid
+	}
+
+	return &Candidate{
+		ID:               candidateID,
+		SessionID:        claims.SessionID,
+		MFAAuthenticated: claims.MFAAuthenticated,
+		Roles:            claims.Roles,
+		ImpersonatorID:   claims.ImpersonatorID,
+	}, nil
+}
+
+// JSONWebKey the RSA public key in JWK format
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+	N         string `json:"n"`
+	E         string `json:"e"`
+}
+
+// JSONWebKeySet the public keys in JWKS format
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// JWKS return the public keys, so the other services can verify the access token offline
+func (k *JWTKeySet) JWKS() JSONWebKeySet {
+	jwks := JSONWebKeySet{Keys: make([]JSONWebKey, 0, len(k.privateKeys))}
+	for kid, key := range k.privateKeys {
+		jwks.Keys = append(jwks.Keys, JSONWebKey{
+			KeyType:   "RSA",
+			Use:       "sig",
+			Algorithm: jwt.SigningMethodRS256.Alg(),
+			KeyID:     kid,
+			N:         base64.RawURLEncoding.EncodeToString(key.PublicKey.N.Bytes()),
+			E:         base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.PublicKey.E)).Bytes()),
+		})
+	}
+
+	sort.Slice(jwks.Keys, func(i, j int) bool {
+		return jwks.Keys[i].KeyID < jwks.Keys[j].KeyID
+	})
+
+	return jwks
+}
+
+// IsJWT check whether the token is a JWT rather than an opaque token
+func IsJWT(token string) bool {
+	return strings.Count(token, ".") == 2
+}
@@ -0,0 +1,13 @@
+-- +migrate Up notransaction
+CREATE TABLE IF NOT EXISTS "superseded_refresh_tokens" (
+    "token_hash" text PRIMARY KEY,
+    "session_id" bigint NOT NULL,
+    "candidate_id" bigint NOT NULL,
+    "expired_at" timestamp NOT NULL,
+    "created_at" timestamp NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS "superseded_refresh_tokens_session_id_idx" ON "superseded_refresh_tokens" ("session_id");
+
+-- +migrate Down
+DROP TABLE IF EXISTS "superseded_refresh_tokens";
@@ -0,0 +1,16 @@
+-- +migrate Up notransaction
+CREATE TABLE IF NOT EXISTS "password_reset_tokens" (
+    "id" bigint PRIMARY KEY,
+    "candidate_id" bigint NOT NULL,
+    "token_hash" text NOT NULL,
+    "expired_at" timestamp NOT NULL,
+    "used_at" timestamp,
+    "created_at" timestamp NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "password_reset_tokens_token_hash_idx" ON "password_reset_tokens" ("token_hash");
+
+ALTER TABLE "password_reset_tokens" ADD FOREIGN KEY ("candidate_id") REFERENCES "candidates" ("id");
+
+-- +migrate Down
+DROP TABLE IF EXISTS "password_reset_tokens";
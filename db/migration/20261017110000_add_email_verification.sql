@@ -0,0 +1,21 @@
+-- +migrate Up notransaction
+ALTER TABLE "candidates" ADD COLUMN IF NOT EXISTS "email_verified_at" TIMESTAMP;
+
+CREATE TABLE IF NOT EXISTS "email_verification_tokens" (
+    "id" bigint PRIMARY KEY,
+    "candidate_id" bigint NOT NULL,
+    "email" VARCHAR(255) NOT NULL,
+    "token_hash" text NOT NULL,
+    "expired_at" timestamp NOT NULL,
+    "used_at" timestamp,
+    "created_at" timestamp NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "email_verification_tokens_token_hash_idx" ON "email_verification_tokens" ("token_hash");
+
+ALTER TABLE "email_verification_tokens" ADD FOREIGN KEY ("candidate_id") REFERENCES "candidates" ("id");
+
+-- +migrate Down
+DROP TABLE IF EXISTS "email_verification_tokens";
+
+ALTER TABLE "candidates" DROP COLUMN IF EXISTS "email_verified_at";
@@ -0,0 +1,25 @@
+-- +migrate Up notransaction
+ALTER TABLE "candidates" ADD COLUMN IF NOT EXISTS "totp_secret" TEXT;
+ALTER TABLE "candidates" ADD COLUMN IF NOT EXISTS "totp_enabled_at" TIMESTAMP;
+
+ALTER TABLE "sessions" ADD COLUMN IF NOT EXISTS "mfa_authenticated" BOOLEAN NOT NULL DEFAULT false;
+
+CREATE TABLE IF NOT EXISTS "mfa_recovery_codes" (
+    "id" bigint PRIMARY KEY,
+    "candidate_id" bigint NOT NULL,
+    "code_hash" text NOT NULL,
+    "used_at" timestamp,
+    "created_at" timestamp NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS "mfa_recovery_codes_candidate_id_idx" ON "mfa_recovery_codes" ("candidate_id");
+
+ALTER TABLE "mfa_recovery_codes" ADD FOREIGN KEY ("candidate_id") REFERENCES "candidates" ("id");
+
+-- +migrate Down
+DROP TABLE IF EXISTS "mfa_recovery_codes";
+
+ALTER TABLE "sessions" DROP COLUMN IF EXISTS "mfa_authenticated";
+
+ALTER TABLE "candidates" DROP COLUMN IF EXISTS "totp_enabled_at";
+ALTER TABLE "candidates" DROP COLUMN IF EXISTS "totp_secret";
@@ -0,0 +1,16 @@
+-- +migrate Up notransaction
+CREATE TABLE IF NOT EXISTS "candidate_roles" (
+    "candidate_id" bigint NOT NULL,
+    "role" text NOT NULL,
+    "created_at" timestamp NOT NULL DEFAULT now(),
+    PRIMARY KEY ("candidate_id", "role")
+);
+
+ALTER TABLE "candidate_roles" ADD FOREIGN KEY ("candidate_id") REFERENCES "candidates" ("id");
+
+ALTER TABLE "sessions" ADD COLUMN IF NOT EXISTS "roles" jsonb NOT NULL DEFAULT '[]';
+
+-- +migrate Down
+ALTER TABLE "sessions" DROP COLUMN IF EXISTS "roles";
+
+DROP TABLE IF EXISTS "candidate_roles";
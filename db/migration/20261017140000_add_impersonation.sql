@@ -0,0 +1,25 @@
+-- +migrate Up notransaction
+ALTER TABLE "sessions" ADD COLUMN IF NOT EXISTS "impersonator_id" bigint;
+
+CREATE TABLE IF NOT EXISTS "impersonation_logs" (
+    "id" bigint PRIMARY KEY,
+    "impersonator_id" bigint NOT NULL,
+    "candidate_id" bigint NOT NULL,
+    "session_id" bigint NOT NULL,
+    "reason" text NOT NULL,
+    "ip_address" text NOT NULL DEFAULT '',
+    "user_agent" text NOT NULL DEFAULT '',
+    "expired_at" timestamp NOT NULL,
+    "created_at" timestamp NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS "impersonation_logs_impersonator_id_idx" ON "impersonation_logs" ("impersonator_id");
+CREATE INDEX IF NOT EXISTS "impersonation_logs_candidate_id_idx" ON "impersonation_logs" ("candidate_id");
+
+ALTER TABLE "impersonation_logs" ADD FOREIGN KEY ("impersonator_id") REFERENCES "candidates" ("id");
+ALTER TABLE "impersonation_logs" ADD FOREIGN KEY ("candidate_id") REFERENCES "candidates" ("id");
+
+-- +migrate Down
+DROP TABLE IF EXISTS "impersonation_logs";
+
+ALTER TABLE "sessions" DROP COLUMN IF EXISTS "impersonator_id";
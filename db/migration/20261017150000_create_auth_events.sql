@@ -0,0 +1,21 @@
+-- +migrate Up notransaction
+CREATE TABLE IF NOT EXISTS "auth_events" (
+    "id" bigint PRIMARY KEY,
+    "event_type" text NOT NULL,
+    "candidate_id" bigint,
+    "session_id" bigint,
+    "identifier" text NOT NULL DEFAULT '',
+    "ip_address" text NOT NULL DEFAULT '',
+    "user_agent" text NOT NULL DEFAULT '',
+    "latitude" text NOT NULL DEFAULT '',
+    "longitude" text NOT NULL DEFAULT '',
+    "outcome" text NOT NULL,
+    "reason" text NOT NULL DEFAULT '',
+    "created_at" timestamp NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS "auth_events_candidate_id_created_at_idx" ON "auth_events" ("candidate_id", "created_at");
+CREATE INDEX IF NOT EXISTS "auth_events_created_at_idx" ON "auth_events" ("created_at");
+
+-- +migrate Down
+DROP TABLE IF EXISTS "auth_events";
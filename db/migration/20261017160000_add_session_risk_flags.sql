@@ -0,0 +1,5 @@
+-- +migrate Up notransaction
+ALTER TABLE "sessions" ADD COLUMN IF NOT EXISTS "risk_flags" jsonb NOT NULL DEFAULT '[]';
+
+-- +migrate Down
+ALTER TABLE "sessions" DROP COLUMN IF EXISTS "risk_flags";
@@ -0,0 +1,25 @@
+-- +migrate Up notransaction
+ALTER TABLE "sessions" ALTER COLUMN "latitude" DROP NOT NULL;
+ALTER TABLE "sessions" ALTER COLUMN "longitude" DROP NOT NULL;
+ALTER TABLE "sessions" ALTER COLUMN "latitude" TYPE double precision USING NULLIF("latitude", '')::double precision;
+ALTER TABLE "sessions" ALTER COLUMN "longitude" TYPE double precision USING NULLIF("longitude", '')::double precision;
+
+ALTER TABLE "auth_events" ALTER COLUMN "latitude" DROP NOT NULL;
+ALTER TABLE "auth_events" ALTER COLUMN "longitude" DROP NOT NULL;
+ALTER TABLE "auth_events" ALTER COLUMN "latitude" DROP DEFAULT;
+ALTER TABLE "auth_events" ALTER COLUMN "longitude" DROP DEFAULT;
+ALTER TABLE "auth_events" ALTER COLUMN "latitude" TYPE double precision USING NULLIF("latitude", '')::double precision;
+ALTER TABLE "auth_events" ALTER COLUMN "longitude" TYPE double precision USING NULLIF("longitude", '')::double precision;
+
+-- +migrate Down
+ALTER TABLE "auth_events" ALTER COLUMN "latitude" TYPE text USING COALESCE("latitude"::text, '');
+ALTER TABLE "auth_events" ALTER COLUMN "longitude" TYPE text USING COALESCE("longitude"::text, '');
+ALTER TABLE "auth_events" ALTER COLUMN "latitude" SET DEFAULT '';
+ALTER TABLE "auth_events" ALTER COLUMN "longitude" SET DEFAULT '';
+ALTER TABLE "auth_events" ALTER COLUMN "latitude" SET NOT NULL;
+ALTER TABLE "auth_events" ALTER COLUMN "longitude" SET NOT NULL;
+
+ALTER TABLE "sessions" ALTER COLUMN "latitude" TYPE text USING COALESCE("latitude"::text, '');
+ALTER TABLE "sessions" ALTER COLUMN "longitude" TYPE text USING COALESCE("longitude"::text, '');
+ALTER TABLE "sessions" ALTER COLUMN "latitude" SET NOT NULL;
+ALTER TABLE "sessions" ALTER COLUMN "longitude" SET NOT NULL;
@@ -0,0 +1,18 @@
+-- +migrate Up notransaction
+CREATE TABLE IF NOT EXISTS "candidate_identities" (
+    "id" bigint PRIMARY KEY,
+    "candidate_id" bigint NOT NULL,
+    "provider" text NOT NULL,
+    "subject" text NOT NULL,
+    "email" text,
+    "created_at" timestamp NOT NULL DEFAULT now(),
+    "updated_at" timestamp NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "candidate_identities_provider_subject_idx" ON "candidate_identities" ("provider", "subject");
+CREATE INDEX IF NOT EXISTS "candidate_identities_candidate_id_idx" ON "candidate_identities" ("candidate_id");
+
+ALTER TABLE "candidate_identities" ADD FOREIGN KEY ("candidate_id") REFERENCES "candidates" ("id");
+
+-- +migrate Down
+DROP TABLE IF EXISTS "candidate_identities";
@@ -0,0 +1,22 @@
+-- +migrate Up notransaction
+CREATE TABLE IF NOT EXISTS "api_keys" (
+    "id" bigint PRIMARY KEY,
+    "name" text NOT NULL,
+    "prefix" text NOT NULL,
+    "key_hash" text NOT NULL,
+    "scopes" jsonb NOT NULL DEFAULT '[]',
+    "expired_at" timestamp,
+    "last_used_at" timestamp,
+    "revoked_at" timestamp,
+    "rotated_from_id" bigint,
+    "created_by" bigint,
+    "created_at" timestamp NOT NULL DEFAULT now(),
+    "updated_at" timestamp NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "api_keys_prefix_idx" ON "api_keys" ("prefix");
+
+ALTER TABLE "api_keys" ADD FOREIGN KEY ("created_by") REFERENCES "candidates" ("id");
+
+-- +migrate Down
+DROP TABLE IF EXISTS "api_keys";
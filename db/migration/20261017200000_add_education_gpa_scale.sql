@@ -0,0 +1,9 @@
+-- +migrate Up notransaction
+ALTER TABLE "educations" ADD COLUMN IF NOT EXISTS "gpa_scale" double precision NOT NULL DEFAULT 4;
+
+CREATE INDEX IF NOT EXISTS "educations_candidate_id_idx" ON "educations" ("candidate_id") WHERE "deleted_at" IS NULL;
+
+-- +migrate Down
+DROP INDEX IF EXISTS "educations_candidate_id_idx";
+
+ALTER TABLE "educations" DROP COLUMN IF EXISTS "gpa_scale";
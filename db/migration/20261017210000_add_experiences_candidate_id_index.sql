@@ -0,0 +1,5 @@
+-- +migrate Up notransaction
+CREATE INDEX IF NOT EXISTS "experiences_candidate_id_idx" ON "experiences" ("candidate_id") WHERE "deleted_at" IS NULL;
+
+-- +migrate Down
+DROP INDEX IF EXISTS "experiences_candidate_id_idx";
@@ -0,0 +1,11 @@
+-- +migrate Up notransaction
+-- the candidates registered before the email verification never got a verification token,
+-- their emails are considered verified so requiring the verification for login doesn't lock them out
+UPDATE "candidates" SET "email_verified_at" = "created_at"
+WHERE "email_verified_at" IS NULL
+  AND "email" IS NOT NULL
+  AND "email" <> ''
+  AND NOT EXISTS (SELECT 1 FROM "email_verification_tokens" WHERE "email_verification_tokens"."candidate_id" = "candidates"."id");
+
+-- +migrate Down
+-- the grandfathered emails can't be told apart from the verified ones, nothing to revert
@@ -0,0 +1,5 @@
+-- +migrate Up notransaction
+CREATE INDEX IF NOT EXISTS "superseded_refresh_tokens_expired_at_idx" ON "superseded_refresh_tokens" ("expired_at");
+
+-- +migrate Down
+DROP INDEX IF EXISTS "superseded_refresh_tokens_expired_at_idx";
@@ -0,0 +1,164 @@
+package breach
+
+import (
+	"bytes"
+	"crypto/sha1" // nolint:gosec // the breached password hashes are published in SHA-1
+	"encoding/hex"
+	"errors"
+	"io"
+	"os"
+	"strings"
+)
+
+const (
+	sha1HexLength    = 40
+	hashPrefixLength = 5
+	maxLineLength    = 128
+)
+
+// ErrLineTooLong the file is not a breached password hashes file
+var ErrLineTooLong = errors.New("breached password hashes file line is too long")
+
+// FileChecker check the password against the offline breached password hashes file,
+// e.g. the Pwned Passwords SHA-1 file ordered by hash, each line is `<SHA-1 hash>[:<count>]`.
+// The hashes are looked up by the k-anonymity prefix, the same as the range API,
+// so the file is searched without loading it to the memory.
+type FileChecker struct {
+	file *os.File
+	size int64
+}
+
+// NewFileChecker open the breached password hashes file, the file must be sorted by the hash
+func NewFileChecker(filePath string) (*FileChecker, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+
+	return &FileChecker{
+		file: file,
+		size: info.Size(),
+	}, nil
+}
+
+// IsBreached check whether the password's hash is listed on the file
+func (f *FileChecker) IsBreached(password string) (bool, error) {
+	sum := sha1.Sum([]byte(password)) // nolint:gosec
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+
+	suffixes, err := f.findSuffixesByPrefix(hash[:hashPrefixLength])
+	if err != nil {
+		return false, err
+	}
+
+	for _, suffix := range suffixes {
+		if suffix == hash[hashPrefixLength:] {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// Close close the file
+func (f *FileChecker) Close() error {
+	return f.file.Close()
+}
+
+// findSuffixesByPrefix find the suffixes of the hashes which start with the prefix
+func (f *FileChecker) findSuffixesByPrefix(prefix string) ([]string, error) {
+	// binary search the offset which first line's prefix is not less than the prefix
+	lo, hi := int64(0), f.size
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		line, _, err := f.readLineFrom(mid)
+		if err != nil {
+			return nil, err
+		}
+
+		if line != "" && hashPrefix(line) < prefix {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+
+	var suffixes []string
+	for offset := lo; ; {
+		line, next, err := f.readLineFrom(offset)
+		if err != nil {
+			return nil, err
+		}
+
+		if line == "" || hashPrefix(line) != prefix {
+			return suffixes, nil
+		}
+
+		suffixes = append(suffixes, hashSuffix(line))
+		offset = next
+	}
+}
+
+// readLineFrom read the first line which starts at or after the offset, then return the offset of the next line.
+// The empty line is returned on the end of the file.
+func (f *FileChecker) readLineFrom(offset int64) (string, int64, error) {
+	// read from the previous byte, to find out whether the offset is the start of a line
+	from := offset
+	if offset > 0 {
+		from = offset - 1
+	}
+
+	buf := make([]byte, 2*maxLineLength)
+	n, err := f.file.ReadAt(buf, from)
+	if err != nil && err != io.EOF {
+		return "", 0, err
+	}
+	data := buf[:n]
+
+	start := from
+	if offset > 0 {
+		idx := bytes.IndexByte(data, '\n')
+		if idx < 0 {
+			if from+int64(n) < f.size {
+				return "", 0, ErrLineTooLong
+			}
+			return "", f.size, nil
+		}
+
+		start = from + int64(idx) + 1
+		data = data[idx+1:]
+	}
+
+	end := bytes.IndexByte(data, '\n')
+	switch {
+	case end >= 0:
+		data = data[:end]
+	case start+int64(len(data)) < f.size:
+		return "", 0, ErrLineTooLong
+	}
+
+	return strings.TrimSpace(string(data)), start + int64(len(data)) + 1, nil
+}
+
+// hashPrefix return the upper case k-anonymity prefix of the line's hash
+func hashPrefix(line string) string {
+	if len(line) < hashPrefixLength {
+		return strings.ToUpper(line)
+	}
+	return strings.ToUpper(line[:hashPrefixLength])
+}
+
+// hashSuffix return the upper case hash without the prefix, the count is trimmed
+func hashSuffix(line string) string {
+	hash, _, _ := strings.Cut(line, ":")
+	if len(hash) != sha1HexLength {
+		return ""
+	}
+	return strings.ToUpper(hash[hashPrefixLength:])
+}
@@ -0,0 +1,264 @@
+package breach
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// hashLine return the breached password hashes file line of the hash with the prefix, the rest is filled with the char
+func hashLine(prefix, fill string) string {
+	return prefix + strings.Repeat(fill, sha1HexLength-hashPrefixLength) + ":1"
+}
+
+// hashSuffixOf return the suffix of the hashLine
+func hashSuffixOf(fill string) string {
+	return strings.Repeat(fill, sha1HexLength-hashPrefixLength)
+}
+
+func newTestFileChecker(t *testing.T, content string) *FileChecker {
+	t.Helper()
+
+	filePath := filepath.Join(t.TempDir(), "hashes.txt")
+	require.NoError(t, os.WriteFile(filePath, []byte(content), 0o600))
+
+	checker, err := NewFileChecker(filePath)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = checker.Close()
+	})
+
+	return checker
+}
+
+func TestFileChecker_findSuffixesByPrefix(t *testing.T) {
+	lines := []string{
+		hashLine("00000", "1"),
+		hashLine("0AAAA", "2"),
+		hashLine("AAAA9", "3"),
+		hashLine("AAAAA", "4"),
+		hashLine("AAAAA", "5"),
+		hashLine("AAAAB", "6"),
+		hashLine("FFFFF", "7"),
+	}
+
+	tests := []struct {
+		name     string
+		content  string
+		prefix   string
+		expected []string
+	}{
+		{
+			name:     "first line",
+			content:  strings.Join(lines, "\n") + "\n",
+			prefix:   "00000",
+			expected: []string{hashSuffixOf("1")},
+		},
+		{
+			name:     "last line",
+			content:  strings.Join(lines, "\n") + "\n",
+			prefix:   "FFFFF",
+			expected: []string{hashSuffixOf("7")},
+		},
+		{
+			name:     "last line without trailing new line",
+			content:  strings.Join(lines, "\n"),
+			prefix:   "FFFFF",
+			expected: []string{hashSuffixOf("7")},
+		},
+		{
+			name:     "missing prefix",
+			content:  strings.Join(lines, "\n") + "\n",
+			prefix:   "55555",
+			expected: nil,
+		},
+		{
+			name:     "missing prefix after the last line",
+			content:  strings.Join(lines, "\n") + "\n",
+			prefix:   "FFFFE",
+			expected: nil,
+		},
+		{
+			name:     "prefix next to the line boundaries",
+			content:  strings.Join(lines, "\n") + "\n",
+			prefix:   "AAAAA",
+			expected: []string{hashSuffixOf("4"), hashSuffixOf("5")},
+		},
+		{
+			name:     "lower case prefix on the file",
+			content:  strings.ToLower(strings.Join(lines, "\n")) + "\n",
+			prefix:   "AAAAA",
+			expected: []string{hashSuffixOf("4"), hashSuffixOf("5")},
+		},
+		{
+			name:     "crlf line endings",
+			content:  strings.Join(lines, "\r\n") + "\r\n",
+			prefix:   "AAAAA",
+			expected: []string{hashSuffixOf("4"), hashSuffixOf("5")},
+		},
+		{
+			name:     "crlf line endings on the last line",
+			content:  strings.Join(lines, "\r\n") + "\r\n",
+			prefix:   "FFFFF",
+			expected: []string{hashSuffixOf("7")},
+		},
+		{
+			name:     "empty file",
+			content:  "",
+			prefix:   "AAAAA",
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := newTestFileChecker(t, tt.content)
+
+			suffixes, err := checker.findSuffixesByPrefix(tt.prefix)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, suffixes)
+		})
+	}
+}
+
+func TestFileChecker_readLineFrom(t *testing.T) {
+	first := hashLine("00000", "1")
+	second := hashLine("AAAAA", "2")
+	lfContent := first + "\n" + second + "\n"
+	crlfContent := first + "\r\n" + second + "\r\n"
+
+	tests := []struct {
+		name         string
+		content      string
+		offset       int64
+		expectedLine string
+		expectedNext int64
+		expectedErr  error
+	}{
+		{
+			name:         "first line",
+			content:      lfContent,
+			offset:       0,
+			expectedLine: first,
+			expectedNext: int64(len(first) + 1),
+		},
+		{
+			name:         "offset on the start of the line",
+			content:      lfContent,
+			offset:       int64(len(first) + 1),
+			expectedLine: second,
+			expectedNext: int64(len(lfContent)),
+		},
+		{
+			name:         "offset on the new line",
+			content:      lfContent,
+			offset:       int64(len(first)),
+			expectedLine: second,
+			expectedNext: int64(len(lfContent)),
+		},
+		{
+			name:         "offset in the middle of the line",
+			content:      lfContent,
+			offset:       1,
+			expectedLine: second,
+			expectedNext: int64(len(lfContent)),
+		},
+		{
+			name:         "last line without trailing new line",
+			content:      first + "\n" + second,
+			offset:       int64(len(first) + 1),
+			expectedLine: second,
+			expectedNext: int64(len(first+"\n"+second) + 1),
+		},
+		{
+			name:         "crlf first line",
+			content:      crlfContent,
+			offset:       0,
+			expectedLine: first,
+			expectedNext: int64(len(first) + 2),
+		},
+		{
+			name:         "crlf offset on the carriage return",
+			content:      crlfContent,
+			offset:       int64(len(first)),
+			expectedLine: second,
+			expectedNext: int64(len(crlfContent)),
+		},
+		{
+			name:        "line too long",
+			content:     strings.Repeat("A", 3*maxLineLength) + "\n" + second + "\n",
+			offset:      1,
+			expectedErr: ErrLineTooLong,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := newTestFileChecker(t, tt.content)
+
+			line, next, err := checker.readLineFrom(tt.offset)
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedLine, line)
+			require.Equal(t, tt.expectedNext, next)
+		})
+	}
+
+	// the empty line marks the end of the file, the next offset is not used
+	endOfFileTests := []struct {
+		name    string
+		content string
+		offset  int64
+	}{
+		{
+			name:    "offset in the middle of the last line",
+			content: lfContent,
+			offset:  int64(len(first) + 2),
+		},
+		{
+			name:    "end of file",
+			content: lfContent,
+			offset:  int64(len(lfContent)),
+		},
+		{
+			name:    "empty file",
+			content: "",
+			offset:  0,
+		},
+	}
+
+	for _, tt := range endOfFileTests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := newTestFileChecker(t, tt.content)
+
+			line, _, err := checker.readLineFrom(tt.offset)
+			require.NoError(t, err)
+			require.Equal(t, "", line)
+		})
+	}
+}
+
+func TestFileChecker_IsBreached(t *testing.T) {
+	// the SHA-1 of "password" is 5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8
+	content := strings.Join([]string{
+		hashLine("00000", "1"),
+		"5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8:3861493",
+		hashLine("FFFFF", "2"),
+	}, "\n") + "\n"
+	checker := newTestFileChecker(t, content)
+
+	breached, err := checker.IsBreached("password")
+	require.NoError(t, err)
+	require.True(t, breached)
+
+	breached, err = checker.IsBreached("correct horse battery staple")
+	require.NoError(t, err)
+	require.False(t, breached)
+}
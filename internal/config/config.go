@@ -1,6 +1,7 @@
 package config
 
 import (
+	"encoding/base64"
 	"fmt"
 	"github.com/irvankadhafi/talent-hub-service/utils"
 	"github.com/sirupsen/logrus"
@@ -37,6 +38,42 @@ func HTTPPort() string {
 	return viper.GetString("ports.http")
 }
 
+// GRPCPort :nodoc:
+func GRPCPort() string {
+	if !viper.IsSet("ports.grpc") {
+		return DefaultGRPCPort
+	}
+	return viper.GetString("ports.grpc")
+}
+
+// GRPCHost the bind address of the grpc server, only listen on the loopback by default
+func GRPCHost() string {
+	if !viper.IsSet("grpc.host") {
+		return DefaultGRPCHost
+	}
+	return viper.GetString("grpc.host")
+}
+
+// GRPCServiceToken the shared token of the internal services calling the grpc server
+func GRPCServiceToken() string {
+	return viper.GetString("grpc.service_token")
+}
+
+// GRPCTLSCertFile :nodoc:
+func GRPCTLSCertFile() string {
+	return viper.GetString("grpc.tls.cert_file")
+}
+
+// GRPCTLSKeyFile :nodoc:
+func GRPCTLSKeyFile() string {
+	return viper.GetString("grpc.tls.key_file")
+}
+
+// GRPCTLSClientCAFile the ca of the client certificates, the client certificate is required when it's set
+func GRPCTLSClientCAFile() string {
+	return viper.GetString("grpc.tls.client_ca_file")
+}
+
 // DatabaseDSN :nodoc:
 func DatabaseDSN() string {
 	return fmt.Sprintf("postgres://%s:%s@%s/%s?sslmode=%s",
@@ -159,14 +196,686 @@ func SessionDeleteBatchSize() int {
 	return cfg
 }
 
-// AccessTokenDuration get access token increment duration in hour
+// MaxActiveSession get max active session of a candidate, the oldest sessions exceeding it will be deleted
+func MaxActiveSession() int {
+	cfg := viper.GetInt("session.max_active")
+
+	if cfg <= 0 {
+		return DefaultMaxActiveSession
+	}
+
+	return cfg
+}
+
+// SessionCleanupWorkerCount get number of worker deleting the sessions exceeding max active session
+func SessionCleanupWorkerCount() int {
+	cfg := viper.GetInt("session.cleanup_worker_count")
+
+	if cfg <= 0 {
+		return DefaultSessionCleanupWorkerCount
+	}
+
+	return cfg
+}
+
+// SessionCleanupQueueSize get max queued session cleanup jobs
+func SessionCleanupQueueSize() int {
+	cfg := viper.GetInt("session.cleanup_queue_size")
+
+	if cfg <= 0 {
+		return DefaultSessionCleanupQueueSize
+	}
+
+	return cfg
+}
+
+// SessionCleanupTimeout get timeout of each session cleanup job
+func SessionCleanupTimeout() time.Duration {
+	cfg := viper.GetString("session.cleanup_timeout")
+	return utils.ParseDurationWithDefault(cfg, DefaultSessionCleanupTimeout)
+}
+
+// SupersededRefreshTokenCleanupInterval get interval of deleting the expired superseded refresh tokens
+func SupersededRefreshTokenCleanupInterval() time.Duration {
+	cfg := viper.GetString("session.superseded_cleanup_interval")
+	return utils.ParseDurationWithDefault(cfg, DefaultSupersededRefreshTokenCleanupInterval)
+}
+
+// SupersededRefreshTokenCleanupBatchSize get max superseded refresh tokens deleted on each batch
+func SupersededRefreshTokenCleanupBatchSize() int {
+	cfg := viper.GetInt("session.superseded_cleanup_batch_size")
+
+	if cfg <= 0 {
+		return DefaultSupersededRefreshTokenCleanupBatchSize
+	}
+
+	return cfg
+}
+
+// AccessTokenDuration get access token increment duration in hour,
+// the signed access token has its own shorter duration since it can't be revoked
 func AccessTokenDuration() time.Duration {
+	if TokenMode() == TokenModeJWT {
+		return JWTAccessTokenDuration()
+	}
+
 	cfg := viper.GetString("session.access_token_duration")
 	return utils.ParseDurationWithDefault(cfg, DefaultAccessTokenDuration)
 }
 
+// TokenMode get the access token mode, either opaque or jwt
+func TokenMode() string {
+	if viper.GetString("token.mode") == TokenModeJWT {
+		return TokenModeJWT
+	}
+	return TokenModeOpaque
+}
+
+// JWTIssuer get the issuer of the signed access token
+func JWTIssuer() string {
+	if !viper.IsSet("token.jwt.issuer") {
+		return DefaultJWTIssuer
+	}
+	return viper.GetString("token.jwt.issuer")
+}
+
+// JWTAccessTokenDuration get the signed access token lifetime
+func JWTAccessTokenDuration() time.Duration {
+	cfg := viper.GetString("token.jwt.access_token_duration")
+	return utils.ParseDurationWithDefault(cfg, DefaultJWTAccessTokenDuration)
+}
+
+// JWTActiveKeyID get the id of the key used to sign the new access tokens
+func JWTActiveKeyID() string {
+	return viper.GetString("token.jwt.active_key_id")
+}
+
+// JWTKey the RSA key to sign and verify the access token
+type JWTKey struct {
+	ID             string `mapstructure:"id"`
+	PrivateKeyFile string `mapstructure:"private_key_file"`
+}
+
+// JWTKeys get the signing keys, the retired keys are kept to verify the unexpired access tokens
+func JWTKeys() []JWTKey {
+	var keys []JWTKey
+	if err := viper.UnmarshalKey("token.jwt.keys", &keys); err != nil {
+		return nil
+	}
+	return keys
+}
+
 // RefreshTokenDuration get refresh token increment duration in hour
 func RefreshTokenDuration() time.Duration {
 	cfg := viper.GetString("session.refresh_token_duration")
 	return utils.ParseDurationWithDefault(cfg, DefaultRefreshTokenDuration)
 }
+
+// LoginMaxAttemptsPerIdentifier get max failed login attempts of an identifier before it is locked
+func LoginMaxAttemptsPerIdentifier() int64 {
+	cfg := viper.GetInt64("login_lockout.max_attempts_per_identifier")
+
+	if cfg <= 0 {
+		return DefaultLoginMaxAttemptsPerIdentifier
+	}
+
+	return cfg
+}
+
+// LoginMaxAttemptsPerIP get max failed login attempts of an ip address before it is locked
+func LoginMaxAttemptsPerIP() int64 {
+	cfg := viper.GetInt64("login_lockout.max_attempts_per_ip")
+
+	if cfg <= 0 {
+		return DefaultLoginMaxAttemptsPerIP
+	}
+
+	return cfg
+}
+
+// LoginAttemptWindow get the window of failed login attempts counting
+func LoginAttemptWindow() time.Duration {
+	cfg := viper.GetString("login_lockout.attempt_window")
+	return utils.ParseDurationWithDefault(cfg, DefaultLoginAttemptWindow)
+}
+
+// LoginLockoutBaseDuration get the first lockout duration, it is doubled on each consecutive lockout
+func LoginLockoutBaseDuration() time.Duration {
+	cfg := viper.GetString("login_lockout.base_duration")
+	return utils.ParseDurationWithDefault(cfg, DefaultLoginLockoutBaseDuration)
+}
+
+// LoginLockoutMaxDuration get the max lockout duration
+func LoginLockoutMaxDuration() time.Duration {
+	cfg := viper.GetString("login_lockout.max_duration")
+	return utils.ParseDurationWithDefault(cfg, DefaultLoginLockoutMaxDuration)
+}
+
+// PasswordResetTokenDuration get password reset token lifetime
+func PasswordResetTokenDuration() time.Duration {
+	cfg := viper.GetString("password_reset.token_duration")
+	return utils.ParseDurationWithDefault(cfg, DefaultPasswordResetTokenDuration)
+}
+
+// PasswordResetResendCooldown get min duration between sending the password reset email to the same email
+func PasswordResetResendCooldown() time.Duration {
+	cfg := viper.GetString("password_reset.resend_cooldown")
+	return utils.ParseDurationWithDefault(cfg, DefaultPasswordResetResendCooldown)
+}
+
+// PasswordResetURL get the url format of the reset password page, the token is placed on the `%s` verb
+func PasswordResetURL() string {
+	return viper.GetString("password_reset.url")
+}
+
+// PasswordPolicyMinLength get the min length of the password
+func PasswordPolicyMinLength() int {
+	cfg := viper.GetInt("password_policy.min_length")
+
+	if cfg <= 0 {
+		return DefaultPasswordPolicyMinLength
+	}
+
+	return cfg
+}
+
+// PasswordPolicyMaxLength get the max length of the password, to limit the hashing cost
+func PasswordPolicyMaxLength() int {
+	cfg := viper.GetInt("password_policy.max_length")
+
+	if cfg <= 0 {
+		return DefaultPasswordPolicyMaxLength
+	}
+
+	return cfg
+}
+
+// PasswordPolicyRequireUppercase check whether the password must contain an upper case letter
+func PasswordPolicyRequireUppercase() bool {
+	return viper.GetBool("password_policy.require_uppercase")
+}
+
+// PasswordPolicyRequireLowercase check whether the password must contain a lower case letter
+func PasswordPolicyRequireLowercase() bool {
+	return viper.GetBool("password_policy.require_lowercase")
+}
+
+// PasswordPolicyRequireDigit check whether the password must contain a digit
+func PasswordPolicyRequireDigit() bool {
+	return viper.GetBool("password_policy.require_digit")
+}
+
+// PasswordPolicyRequireSymbol check whether the password must contain a symbol
+func PasswordPolicyRequireSymbol() bool {
+	return viper.GetBool("password_policy.require_symbol")
+}
+
+// PasswordPolicyDisallowPersonalInfo check whether the password must not contain the candidate's name, email or phone
+func PasswordPolicyDisallowPersonalInfo() bool {
+	if !viper.IsSet("password_policy.disallow_personal_info") {
+		return true
+	}
+	return viper.GetBool("password_policy.disallow_personal_info")
+}
+
+// PasswordPolicyMinStrengthScore get the min estimated strength score from 0 to 4, the estimate is disabled on 0
+func PasswordPolicyMinStrengthScore() int {
+	if !viper.IsSet("password_policy.min_strength_score") {
+		return DefaultPasswordPolicyMinStrengthScore
+	}
+	return viper.GetInt("password_policy.min_strength_score")
+}
+
+// PasswordPolicyBreachedPasswordFile get the offline breached password SHA-1 hashes file ordered by hash,
+// the breached password is not checked when it's empty
+func PasswordPolicyBreachedPasswordFile() string {
+	return viper.GetString("password_policy.breached_password_file")
+}
+
+// PasswordHashAlgorithm get the algorithm to hash the new passwords, either argon2id or bcrypt
+func PasswordHashAlgorithm() string {
+	if !viper.IsSet("password_hash.algorithm") {
+		return DefaultPasswordHashAlgorithm
+	}
+	return viper.GetString("password_hash.algorithm")
+}
+
+// PasswordHashArgon2idMemory get the argon2id memory in KiB
+func PasswordHashArgon2idMemory() uint32 {
+	cfg := viper.GetUint32("password_hash.argon2id.memory")
+
+	if cfg == 0 {
+		return DefaultPasswordHashArgon2idMemory
+	}
+
+	return cfg
+}
+
+// PasswordHashArgon2idIterations get the argon2id number of passes over the memory
+func PasswordHashArgon2idIterations() uint32 {
+	cfg := viper.GetUint32("password_hash.argon2id.iterations")
+
+	if cfg == 0 {
+		return DefaultPasswordHashArgon2idIterations
+	}
+
+	return cfg
+}
+
+// PasswordHashArgon2idParallelism get the argon2id number of threads
+func PasswordHashArgon2idParallelism() uint8 {
+	cfg := viper.GetUint("password_hash.argon2id.parallelism")
+
+	if cfg == 0 || cfg > 255 {
+		return DefaultPasswordHashArgon2idParallelism
+	}
+
+	return uint8(cfg)
+}
+
+// PasswordHashArgon2idSaltLength get the argon2id salt length in bytes
+func PasswordHashArgon2idSaltLength() uint32 {
+	cfg := viper.GetUint32("password_hash.argon2id.salt_length")
+
+	if cfg == 0 {
+		return DefaultPasswordHashArgon2idSaltLength
+	}
+
+	return cfg
+}
+
+// PasswordHashArgon2idKeyLength get the argon2id hash length in bytes
+func PasswordHashArgon2idKeyLength() uint32 {
+	cfg := viper.GetUint32("password_hash.argon2id.key_length")
+
+	if cfg == 0 {
+		return DefaultPasswordHashArgon2idKeyLength
+	}
+
+	return cfg
+}
+
+// PasswordHashArgon2idMaxConcurrency get max argon2id hashes running at once,
+// the memory used by the hashing is bounded by the concurrency times the memory parameter
+func PasswordHashArgon2idMaxConcurrency() int {
+	cfg := viper.GetInt("password_hash.argon2id.max_concurrency")
+
+	if cfg <= 0 {
+		return DefaultPasswordHashArgon2idMaxConcurrency
+	}
+
+	return cfg
+}
+
+// PasswordHashBcryptCost get the bcrypt cost, only used when the algorithm is bcrypt
+func PasswordHashBcryptCost() int {
+	cfg := viper.GetInt("password_hash.bcrypt.cost")
+
+	if cfg <= 0 {
+		return DefaultPasswordHashBcryptCost
+	}
+
+	return cfg
+}
+
+// EmailVerificationTokenDuration get email verification token lifetime
+func EmailVerificationTokenDuration() time.Duration {
+	cfg := viper.GetString("email_verification.token_duration")
+	return utils.ParseDurationWithDefault(cfg, DefaultEmailVerificationTokenDuration)
+}
+
+// EmailVerificationResendCooldown get min duration between sending the verification email
+func EmailVerificationResendCooldown() time.Duration {
+	cfg := viper.GetString("email_verification.resend_cooldown")
+	return utils.ParseDurationWithDefault(cfg, DefaultEmailVerificationResendCooldown)
+}
+
+// EmailVerificationURL get the url format of the verify email page, the token is placed on the `%s` verb
+func EmailVerificationURL() string {
+	return viper.GetString("email_verification.url")
+}
+
+// EmailVerificationRequiredForLogin when true, login by email is blocked until the email is verified
+func EmailVerificationRequiredForLogin() bool {
+	return viper.GetBool("email_verification.required_for_login")
+}
+
+// LoginOTPCodeLength get number of digits of the login otp code
+func LoginOTPCodeLength() int {
+	cfg := viper.GetInt("login_otp.code_length")
+
+	if cfg <= 0 {
+		return DefaultLoginOTPCodeLength
+	}
+
+	return cfg
+}
+
+// LoginOTPDuration get login otp code lifetime
+func LoginOTPDuration() time.Duration {
+	cfg := viper.GetString("login_otp.duration")
+	return utils.ParseDurationWithDefault(cfg, DefaultLoginOTPDuration)
+}
+
+// LoginOTPResendCooldown get min duration between sending the login otp code to the same phone
+func LoginOTPResendCooldown() time.Duration {
+	cfg := viper.GetString("login_otp.resend_cooldown")
+	return utils.ParseDurationWithDefault(cfg, DefaultLoginOTPResendCooldown)
+}
+
+// LoginOTPMaxAttempts get max verification attempts of a login otp code before it's invalidated
+func LoginOTPMaxAttempts() int64 {
+	cfg := viper.GetInt64("login_otp.max_attempts")
+
+	if cfg <= 0 {
+		return DefaultLoginOTPMaxAttempts
+	}
+
+	return cfg
+}
+
+// MFAIssuer get the issuer shown in the authenticator app
+func MFAIssuer() string {
+	if !viper.IsSet("mfa.issuer") {
+		return DefaultMFAIssuer
+	}
+	return viper.GetString("mfa.issuer")
+}
+
+// MFATOTPEncryptionKey get the base64 encoded AES key encrypting the TOTP secrets at rest,
+// nil when it's not set or invalid
+func MFATOTPEncryptionKey() []byte {
+	cfg := viper.GetString("mfa.totp_encryption_key")
+	if cfg == "" {
+		return nil
+	}
+
+	key, err := base64.StdEncoding.DecodeString(cfg)
+	if err != nil {
+		logrus.Error(fmt.Errorf("invalid mfa.totp_encryption_key: %w", err))
+		return nil
+	}
+
+	return key
+}
+
+// MFAChallengeDuration get the mfa challenge lifetime
+func MFAChallengeDuration() time.Duration {
+	cfg := viper.GetString("mfa.challenge_duration")
+	return utils.ParseDurationWithDefault(cfg, DefaultMFAChallengeDuration)
+}
+
+// MFAMaxAttempts get max verification attempts of a mfa challenge before it's invalidated
+func MFAMaxAttempts() int64 {
+	cfg := viper.GetInt64("mfa.max_attempts")
+
+	if cfg <= 0 {
+		return DefaultMFAMaxAttempts
+	}
+
+	return cfg
+}
+
+// MFARecoveryCodeCount get number of the generated recovery codes
+func MFARecoveryCodeCount() int {
+	cfg := viper.GetInt("mfa.recovery_code_count")
+
+	if cfg <= 0 {
+		return DefaultMFARecoveryCodeCount
+	}
+
+	return cfg
+}
+
+// MFATOTPSkew get number of the time steps before and after the current one to accept the TOTP code
+func MFATOTPSkew() uint64 {
+	if !viper.IsSet("mfa.totp_skew") {
+		return DefaultMFATOTPSkew
+	}
+	return viper.GetUint64("mfa.totp_skew")
+}
+
+// ImpersonationDuration get the impersonation session lifetime
+func ImpersonationDuration() time.Duration {
+	cfg := viper.GetString("impersonation.duration")
+	return utils.ParseDurationWithDefault(cfg, DefaultImpersonationDuration)
+}
+
+// LoginRiskEnabled check whether the login is compared against the candidate's recent sessions
+func LoginRiskEnabled() bool {
+	if !viper.IsSet("login_risk.enabled") {
+		return true
+	}
+	return viper.GetBool("login_risk.enabled")
+}
+
+// LoginRiskMaxTravelSpeed get the max plausible travel speed in km/h between the sessions' coordinates
+func LoginRiskMaxTravelSpeed() float64 {
+	cfg := viper.GetFloat64("login_risk.max_travel_speed")
+
+	if cfg <= 0 {
+		return DefaultLoginRiskMaxTravelSpeed
+	}
+
+	return cfg
+}
+
+// LoginRiskMinTravelDistance get the min distance in km to be checked for the impossible travel
+func LoginRiskMinTravelDistance() float64 {
+	cfg := viper.GetFloat64("login_risk.min_travel_distance")
+
+	if cfg <= 0 {
+		return DefaultLoginRiskMinTravelDistance
+	}
+
+	return cfg
+}
+
+// LoginRiskRequireStepUp check whether the suspicious login must be verified with the second factor
+func LoginRiskRequireStepUp() bool {
+	return viper.GetBool("login_risk.require_step_up")
+}
+
+// LoginRiskNotify check whether the candidate is notified about the suspicious login
+func LoginRiskNotify() bool {
+	if !viper.IsSet("login_risk.notify") {
+		return true
+	}
+	return viper.GetBool("login_risk.notify")
+}
+
+// OIDCStateDuration get the lifetime of the pending oidc authorization, the candidate must complete the sign in within it
+func OIDCStateDuration() time.Duration {
+	cfg := viper.GetString("oidc.state_duration")
+	return utils.ParseDurationWithDefault(cfg, DefaultOIDCStateDuration)
+}
+
+// OIDCHTTPTimeout get the timeout of the requests to the oidc providers
+func OIDCHTTPTimeout() time.Duration {
+	cfg := viper.GetString("oidc.http_timeout")
+	return utils.ParseDurationWithDefault(cfg, DefaultOIDCHTTPTimeout)
+}
+
+// OIDCProvider the relying party config of the OpenID Connect provider
+type OIDCProvider struct {
+	Name                  string   `mapstructure:"name"`
+	Issuer                string   `mapstructure:"issuer"`
+	ClientID              string   `mapstructure:"client_id"`
+	ClientSecret          string   `mapstructure:"client_secret"`
+	AuthorizationEndpoint string   `mapstructure:"authorization_endpoint"`
+	TokenEndpoint         string   `mapstructure:"token_endpoint"`
+	JWKSURI               string   `mapstructure:"jwks_uri"`
+	RedirectURL           string   `mapstructure:"redirect_url"`
+	Scopes                []string `mapstructure:"scopes"`
+}
+
+// OIDCProviders get the providers the candidates can sign in with, e.g. google and linkedin
+func OIDCProviders() []OIDCProvider {
+	var providers []OIDCProvider
+	if err := viper.UnmarshalKey("oidc.providers", &providers); err != nil {
+		return nil
+	}
+	return providers
+}
+
+// SessionCookieEnabled check whether the browser clients may ask the session to be set on the HttpOnly cookies
+func SessionCookieEnabled() bool {
+	return viper.GetBool("session_cookie.enabled")
+}
+
+// SessionCookieSecure check whether the session cookies are only sent over https
+func SessionCookieSecure() bool {
+	if !viper.IsSet("session_cookie.secure") {
+		return true
+	}
+	return viper.GetBool("session_cookie.secure")
+}
+
+// SessionCookieSameSite get the SameSite attribute of the session cookies, either strict, lax or none
+func SessionCookieSameSite() string {
+	if !viper.IsSet("session_cookie.same_site") {
+		return DefaultSessionCookieSameSite
+	}
+	return viper.GetString("session_cookie.same_site")
+}
+
+// SessionCookieDomain get the domain of the session cookies, the cookies are host-only when it's empty
+func SessionCookieDomain() string {
+	return viper.GetString("session_cookie.domain")
+}
+
+// SessionCookieRefreshTokenPath get the path of the refresh token cookie, so it's only sent to the token endpoints
+func SessionCookieRefreshTokenPath() string {
+	if !viper.IsSet("session_cookie.refresh_token_path") {
+		return DefaultSessionCookieRefreshTokenPath
+	}
+	return viper.GetString("session_cookie.refresh_token_path")
+}
+
+// APIKeyLastUsedInterval get the minimum interval between the updates of the api key's last used time,
+// so the api key is not updated on every request
+func APIKeyLastUsedInterval() time.Duration {
+	cfg := viper.GetString("api_key.last_used_interval")
+	return utils.ParseDurationWithDefault(cfg, DefaultAPIKeyLastUsedInterval)
+}
+
+// APIKeyRotationGracePeriod get the duration the rotated api key is still valid, so the partner can roll out the new key
+func APIKeyRotationGracePeriod() time.Duration {
+	cfg := viper.GetString("api_key.rotation_grace_period")
+	return utils.ParseDurationWithDefault(cfg, DefaultAPIKeyRotationGracePeriod)
+}
+
+// GeoIPDatabaseFile get the offline MaxMind city database file to resolve the location from the ip address,
+// the location is not resolved when it's empty
+func GeoIPDatabaseFile() string {
+	return viper.GetString("geoip.database_file")
+}
+
+// AuthEventRetention get how long the auth events are kept before deleted
+func AuthEventRetention() time.Duration {
+	cfg := viper.GetString("auth_event.retention")
+	return utils.ParseDurationWithDefault(cfg, DefaultAuthEventRetention)
+}
+
+// AuthEventCleanupInterval get interval of deleting the auth events exceeding the retention
+func AuthEventCleanupInterval() time.Duration {
+	cfg := viper.GetString("auth_event.cleanup_interval")
+	return utils.ParseDurationWithDefault(cfg, DefaultAuthEventCleanupInterval)
+}
+
+// AuthEventCleanupBatchSize get max auth events deleted on each batch
+func AuthEventCleanupBatchSize() int {
+	cfg := viper.GetInt("auth_event.cleanup_batch_size")
+
+	if cfg <= 0 {
+		return DefaultAuthEventCleanupBatchSize
+	}
+
+	return cfg
+}
+
+// AuthEventCleanupTimeout get timeout of each auth event cleanup batch
+func AuthEventCleanupTimeout() time.Duration {
+	cfg := viper.GetString("auth_event.cleanup_timeout")
+	return utils.ParseDurationWithDefault(cfg, DefaultAuthEventCleanupTimeout)
+}
+
+// AuthEventWriterCount get number of worker writing the auth events
+func AuthEventWriterCount() int {
+	cfg := viper.GetInt("auth_event.writer_count")
+
+	if cfg <= 0 {
+		return DefaultAuthEventWriterCount
+	}
+
+	return cfg
+}
+
+// AuthEventWriterQueueSize get max queued auth events waiting to be written
+func AuthEventWriterQueueSize() int {
+	cfg := viper.GetInt("auth_event.writer_queue_size")
+
+	if cfg <= 0 {
+		return DefaultAuthEventWriterQueueSize
+	}
+
+	return cfg
+}
+
+// AuthEventWriteTimeout get timeout of writing each auth event
+func AuthEventWriteTimeout() time.Duration {
+	cfg := viper.GetString("auth_event.write_timeout")
+	return utils.ParseDurationWithDefault(cfg, DefaultAuthEventWriteTimeout)
+}
+
+// SMSSenderDriver get the sms sender driver
+func SMSSenderDriver() string {
+	if !viper.IsSet("notifier.sms.driver") {
+		return SMSSenderDriverLog
+	}
+	return viper.GetString("notifier.sms.driver")
+}
+
+// EmailSenderDriver get the email sender driver, either smtp or log
+func EmailSenderDriver() string {
+	if !viper.IsSet("notifier.email.driver") {
+		return EmailSenderDriverLog
+	}
+	return viper.GetString("notifier.email.driver")
+}
+
+// EmailLogFile get the file path to write the email by the log email sender
+func EmailLogFile() string {
+	return viper.GetString("notifier.email.log_file")
+}
+
+// SMTPHost :nodoc:
+func SMTPHost() string {
+	return viper.GetString("notifier.smtp.host")
+}
+
+// SMTPPort :nodoc:
+func SMTPPort() string {
+	return viper.GetString("notifier.smtp.port")
+}
+
+// SMTPUsername :nodoc:
+func SMTPUsername() string {
+	return viper.GetString("notifier.smtp.username")
+}
+
+// SMTPPassword :nodoc:
+func SMTPPassword() string {
+	return viper.GetString("notifier.smtp.password")
+}
+
+// SMTPFrom :nodoc:
+func SMTPFrom() string {
+	return viper.GetString("notifier.smtp.from")
+}
+
+// SMTPTimeout get the timeout to dial and send the email through the smtp server
+func SMTPTimeout() time.Duration {
+	cfg := viper.GetString("notifier.smtp.timeout")
+	return utils.ParseDurationWithDefault(cfg, DefaultSMTPTimeout)
+}
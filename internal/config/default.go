@@ -5,6 +5,9 @@ import "time"
 const (
 	EnvProduction = "production"
 
+	DefaultGRPCPort = "3001"
+	DefaultGRPCHost = "127.0.0.1"
+
 	DefaultDatabaseMaxIdleConns    = 3
 	DefaultDatabaseMaxOpenConns    = 5
 	DefaultDatabaseConnMaxLifetime = 1 * time.Hour
@@ -16,4 +19,88 @@ const (
 	DefaultRefreshTokenDuration   = 24 * time.Hour * 365 // 1 year
 	DefaultMaxActiveSession       = 20
 	DefaultSessionDeleteBatchSize = 25
+
+	TokenModeOpaque               = "opaque"
+	TokenModeJWT                  = "jwt"
+	DefaultJWTIssuer              = "talent-hub-service"
+	DefaultJWTAccessTokenDuration = 5 * time.Minute
+
+	DefaultLoginMaxAttemptsPerIdentifier = 5
+	DefaultLoginMaxAttemptsPerIP         = 20
+	DefaultLoginAttemptWindow            = 15 * time.Minute
+	DefaultLoginLockoutBaseDuration      = 1 * time.Minute
+	DefaultLoginLockoutMaxDuration       = 24 * time.Hour
+
+	DefaultPasswordResetTokenDuration  = 30 * time.Minute
+	DefaultPasswordResetTokenLength    = 32
+	DefaultPasswordResetResendCooldown = 1 * time.Minute
+
+	DefaultPasswordHashAlgorithm           = "argon2id"
+	DefaultPasswordHashArgon2idMemory      = 64 * 1024 // KiB
+	DefaultPasswordHashArgon2idIterations  = 3
+	DefaultPasswordHashArgon2idParallelism = 2
+	DefaultPasswordHashArgon2idSaltLength  = 16
+	DefaultPasswordHashArgon2idKeyLength   = 32
+	// each concurrent argon2id hash allocates the memory parameter
+	DefaultPasswordHashArgon2idMaxConcurrency = 4
+	DefaultPasswordHashBcryptCost             = 10
+
+	DefaultPasswordPolicyMinLength        = 8
+	DefaultPasswordPolicyMaxLength        = 128
+	DefaultPasswordPolicyMinStrengthScore = 2 // 0 to 4, similar to zxcvbn
+
+	DefaultEmailVerificationTokenDuration  = 24 * time.Hour
+	DefaultEmailVerificationTokenLength    = 32
+	DefaultEmailVerificationResendCooldown = 1 * time.Minute
+
+	DefaultLoginOTPCodeLength     = 6
+	DefaultLoginOTPDuration       = 5 * time.Minute
+	DefaultLoginOTPResendCooldown = 1 * time.Minute
+	DefaultLoginOTPMaxAttempts    = 5
+
+	DefaultMFAIssuer               = "Talent Hub"
+	DefaultMFAChallengeDuration    = 5 * time.Minute
+	DefaultMFAChallengeTokenLength = 32
+	DefaultMFAMaxAttempts          = 5
+	DefaultMFARecoveryCodeCount    = 10
+	DefaultMFATOTPSkew             = 1
+
+	DefaultImpersonationDuration = 30 * time.Minute
+
+	DefaultLoginRiskMaxTravelSpeed    = 1000 // km/h, a bit faster than the commercial flight
+	DefaultLoginRiskMinTravelDistance = 100  // km, the ip geolocation is not precise
+
+	DefaultAuthEventRetention        = 90 * 24 * time.Hour
+	DefaultAuthEventCleanupInterval  = 1 * time.Hour
+	DefaultAuthEventCleanupBatchSize = 1000
+	DefaultAuthEventCleanupTimeout   = 30 * time.Second
+	DefaultAuthEventWriterCount      = 2
+	DefaultAuthEventWriterQueueSize  = 1000
+	DefaultAuthEventWriteTimeout     = 5 * time.Second
+
+	DefaultOIDCStateDuration = 10 * time.Minute
+	DefaultOIDCHTTPTimeout   = 10 * time.Second
+
+	DefaultAPIKeyLastUsedInterval    = 1 * time.Minute
+	DefaultAPIKeyRotationGracePeriod = 24 * time.Hour
+
+	DefaultSessionCookieSameSite         = "strict"
+	DefaultSessionCookieRefreshTokenPath = "/api/auth/tokens/"
+
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+
+	SMSSenderDriverLog = "log"
+
+	EmailSenderDriverSMTP = "smtp"
+	EmailSenderDriverLog  = "log"
+
+	DefaultSMTPTimeout = 10 * time.Second
+
+	DefaultSessionCleanupWorkerCount = 2
+	DefaultSessionCleanupQueueSize   = 1000
+	DefaultSessionCleanupTimeout     = 30 * time.Second
+
+	DefaultSupersededRefreshTokenCleanupInterval  = 1 * time.Hour
+	DefaultSupersededRefreshTokenCleanupBatchSize = 1000
 )
@@ -0,0 +1,149 @@
+package console
+
+import (
+	"context"
+	"fmt"
+	"github.com/irvankadhafi/talent-hub-service/internal/config"
+	"github.com/irvankadhafi/talent-hub-service/internal/db"
+	"github.com/irvankadhafi/talent-hub-service/internal/model"
+	"github.com/irvankadhafi/talent-hub-service/internal/repository"
+	"github.com/irvankadhafi/talent-hub-service/internal/usecase"
+	"github.com/irvankadhafi/talent-hub-service/pkg/cacher"
+	"github.com/irvankadhafi/talent-hub-service/utils"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"gopkg.in/guregu/null.v4"
+	"time"
+)
+
+var apiKeyCmd = &cobra.Command{
+	Use:   "api-key",
+	Short: "manage the api keys",
+	Long:  `This subcommand manage the api keys of the partner integrations, the admin must be granted the api key management`,
+}
+
+var createAPIKeyCmd = &cobra.Command{
+	Use:   "create [admin_id] [name]",
+	Short: "create an api key",
+	Long:  `This subcommand create an api key, the key is only printed once`,
+	Args:  cobra.ExactArgs(2),
+	Run:   createAPIKey,
+}
+
+var rotateAPIKeyCmd = &cobra.Command{
+	Use:   "rotate [admin_id] [api_key_id]",
+	Short: "rotate an api key",
+	Long:  `This subcommand create a new key replacing the api key, the rotated key is still valid for the grace period`,
+	Args:  cobra.ExactArgs(2),
+	Run:   rotateAPIKey,
+}
+
+var revokeAPIKeyCmd = &cobra.Command{
+	Use:   "revoke [admin_id] [api_key_id]",
+	Short: "revoke an api key",
+	Long:  `This subcommand revoke an api key immediately`,
+	Args:  cobra.ExactArgs(2),
+	Run:   revokeAPIKey,
+}
+
+func init() {
+	createAPIKeyCmd.Flags().StringSlice("scopes", nil, "comma separated scopes, e.g. candidate:read")
+	createAPIKeyCmd.Flags().Duration("expires-in", 0, "the key lifetime, the key never expires when it's not set")
+	apiKeyCmd.AddCommand(createAPIKeyCmd, rotateAPIKeyCmd, revokeAPIKeyCmd)
+	RootCmd.AddCommand(apiKeyCmd)
+}
+
+func createAPIKey(cmd *cobra.Command, args []string) {
+	scopes, err := cmd.Flags().GetStringSlice("scopes")
+	continueOrFatal(err)
+	expiresIn, err := cmd.Flags().GetDuration("expires-in")
+	continueOrFatal(err)
+
+	input := model.CreateAPIKeyInput{
+		Name: args[1],
+	}
+	for _, scope := range scopes {
+		input.Scopes = append(input.Scopes, model.Permission(scope))
+	}
+	if expiresIn > 0 {
+		input.ExpiredAt = null.TimeFrom(time.Now().Add(expiresIn))
+	}
+
+	apiKeyUsecase, requester := newAPIKeyUsecaseAndAdmin(args[0])
+	apiKey, err := apiKeyUsecase.Create(context.Background(), requester, input)
+	continueOrFatal(err)
+
+	printAPIKey(apiKey)
+}
+
+func rotateAPIKey(cmd *cobra.Command, args []string) {
+	apiKeyID := utils.StringToInt[int64](args[1])
+	if apiKeyID <= 0 {
+		logrus.Fatalf("invalid api key id %q", args[1])
+	}
+
+	apiKeyUsecase, requester := newAPIKeyUsecaseAndAdmin(args[0])
+	apiKey, err := apiKeyUsecase.Rotate(context.Background(), requester, apiKeyID)
+	continueOrFatal(err)
+
+	printAPIKey(apiKey)
+	logrus.Infof("api key %d is valid until the grace period ends", apiKeyID)
+}
+
+func revokeAPIKey(cmd *cobra.Command, args []string) {
+	apiKeyID := utils.StringToInt[int64](args[1])
+	if apiKeyID <= 0 {
+		logrus.Fatalf("invalid api key id %q", args[1])
+	}
+
+	apiKeyUsecase, requester := newAPIKeyUsecaseAndAdmin(args[0])
+	err := apiKeyUsecase.Revoke(context.Background(), requester, apiKeyID)
+	continueOrFatal(err)
+
+	logrus.Infof("api key %d is revoked", apiKeyID)
+}
+
+// newAPIKeyUsecaseAndAdmin initiate the connections, then load the admin with the roles as the requester
+func newAPIKeyUsecaseAndAdmin(adminIDArg string) (model.APIKeyUsecase, *model.Candidate) {
+	adminID := utils.StringToInt[int64](adminIDArg)
+	if adminID <= 0 {
+		logrus.Fatalf("invalid admin id %q", adminIDArg)
+	}
+
+	// Initiate all connection like db, redis, etc
+	db.InitializePostgresConn()
+
+	cacheManager := cacher.ConstructCacheManager()
+
+	if !config.DisableCaching() {
+		redisDB, err := db.InitializeRedigoRedisConnectionPool(config.RedisCacheHost(), redisOptions)
+		continueOrFatal(err)
+
+		cacheManager.SetConnectionPool(redisDB)
+	}
+
+	cacheManager.SetDisableCaching(config.DisableCaching())
+
+	candidateRepo := repository.NewCandidateRepository(db.PostgreSQL, cacheManager)
+	roleRepo := repository.NewRoleRepository(db.PostgreSQL, cacheManager)
+
+	ctx := context.Background()
+	admin, err := candidateRepo.FindByID(ctx, adminID)
+	continueOrFatal(err)
+	if admin == nil {
+		logrus.Fatalf("admin %d is not found", adminID)
+	}
+
+	admin.Roles, err = roleRepo.FindAllByCandidateID(ctx, adminID)
+	continueOrFatal(err)
+
+	return usecase.NewAPIKeyUsecase(repository.NewAPIKeyRepository(db.PostgreSQL, cacheManager)), admin
+}
+
+func printAPIKey(apiKey *model.APIKey) {
+	fmt.Printf("id: %d\nname: %s\nscopes: %v\nkey: %s\n", apiKey.ID, apiKey.Name, apiKey.Scopes, apiKey.Key)
+	if apiKey.ExpiredAt.Valid {
+		fmt.Printf("expires at: %s\n", apiKey.ExpiredAt.Time.Format(time.RFC3339))
+	}
+	logrus.Warn("store the key securely, it can't be shown again")
+}
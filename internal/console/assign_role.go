@@ -0,0 +1,68 @@
+package console
+
+import (
+	"context"
+	"github.com/irvankadhafi/talent-hub-service/internal/config"
+	"github.com/irvankadhafi/talent-hub-service/internal/db"
+	"github.com/irvankadhafi/talent-hub-service/internal/model"
+	"github.com/irvankadhafi/talent-hub-service/internal/repository"
+	"github.com/irvankadhafi/talent-hub-service/pkg/cacher"
+	"github.com/irvankadhafi/talent-hub-service/utils"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"time"
+)
+
+var assignRoleCmd = &cobra.Command{
+	Use:   "assign-role [candidate_id] [role]",
+	Short: "assign a role to a candidate",
+	Long:  `This subcommand assign a role to a candidate, used to bootstrap the first platform admin`,
+	Args:  cobra.ExactArgs(2),
+	Run:   assignRole,
+}
+
+func init() {
+	RootCmd.AddCommand(assignRoleCmd)
+}
+
+func assignRole(cmd *cobra.Command, args []string) {
+	candidateID := utils.StringToInt[int64](args[0])
+	role := model.Role(args[1])
+	if candidateID <= 0 || !role.IsValid() || role == model.RoleCandidate {
+		logrus.Fatalf("invalid candidate id %q or role %q", args[0], args[1])
+	}
+
+	// Initiate all connection like db, redis, etc
+	db.InitializePostgresConn()
+
+	cacheManager := cacher.ConstructCacheManager()
+
+	if !config.DisableCaching() {
+		redisDB, err := db.InitializeRedigoRedisConnectionPool(config.RedisCacheHost(), redisOptions)
+		continueOrFatal(err)
+		defer utils.WrapCloser(redisDB.Close)
+
+		cacheManager.SetConnectionPool(redisDB)
+	}
+
+	cacheManager.SetDisableCaching(config.DisableCaching())
+
+	candidateRepo := repository.NewCandidateRepository(db.PostgreSQL, cacheManager)
+	roleRepo := repository.NewRoleRepository(db.PostgreSQL, cacheManager)
+
+	ctx := context.Background()
+	candidate, err := candidateRepo.FindByID(ctx, candidateID)
+	continueOrFatal(err)
+	if candidate == nil {
+		logrus.Fatalf("candidate %d is not found", candidateID)
+	}
+
+	err = roleRepo.Create(ctx, &model.CandidateRole{
+		CandidateID: candidateID,
+		Role:        role,
+		CreatedAt:   time.Now(),
+	})
+	continueOrFatal(err)
+
+	logrus.Infof("role %s is assigned to candidate %d", role, candidateID)
+}
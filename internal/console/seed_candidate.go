@@ -42,16 +42,16 @@ func seedUser(cmd *cobra.Command, args []string) {
 	cacheManager.SetDisableCaching(config.DisableCaching())
 
 	candidateRepo := repository.NewCandidateRepository(db.PostgreSQL, cacheManager)
-	candidateUsecase := usecase.NewCandidateUsecase(candidateRepo)
+	candidateUsecase := usecase.NewCandidateUsecase(candidateRepo, nil, nil, newPasswordHasher(), nil)
 
 	for i := 0; i < 10; i++ { // Number of candidates to seed
 		var candidate model.CreateCandidateInput
 
 		// Generate random data for each field
-		candidate.FullName = faker.Name()              // Random full name
-		candidate.Email = faker.Email()                // Random email
-		candidate.Password = "Password123"             // Use a fixed password
-		candidate.PasswordConfirmation = "Password123" // Same as password
+		candidate.FullName = faker.Name()                      // Random full name
+		candidate.Email = faker.Email()                        // Random email
+		candidate.Password = "Seed#Candidate-2026"             // Use a fixed password
+		candidate.PasswordConfirmation = "Seed#Candidate-2026" // Same as password
 
 		// Set Gender manually as faker does not provide Gender
 		candidate.Gender = model.GenderMale // or model.GenderFemale
@@ -59,7 +59,7 @@ func seedUser(cmd *cobra.Command, args []string) {
 		// Create the candidate
 		_, err := candidateUsecase.Create(context.Background(), candidate)
 		if err != nil {
-			logrus.Error("Error creating candidate: %v", err)
+			logrus.Errorf("Error creating candidate: %v", err)
 			continue
 		}
 	}
@@ -2,14 +2,25 @@ package console
 
 import (
 	"context"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"github.com/golang-jwt/jwt"
 	"github.com/irvankadhafi/talent-hub-service/auth"
+	"github.com/irvankadhafi/talent-hub-service/internal/breach"
 	"github.com/irvankadhafi/talent-hub-service/internal/config"
 	"github.com/irvankadhafi/talent-hub-service/internal/db"
+	"github.com/irvankadhafi/talent-hub-service/internal/delivery/grpcsvc"
 	"github.com/irvankadhafi/talent-hub-service/internal/delivery/httpsvc"
+	"github.com/irvankadhafi/talent-hub-service/internal/geoip"
 	"github.com/irvankadhafi/talent-hub-service/internal/helper"
+	"github.com/irvankadhafi/talent-hub-service/internal/model"
+	"github.com/irvankadhafi/talent-hub-service/internal/notifier"
+	"github.com/irvankadhafi/talent-hub-service/internal/oidc"
 	"github.com/irvankadhafi/talent-hub-service/internal/repository"
 	"github.com/irvankadhafi/talent-hub-service/internal/usecase"
+	"github.com/irvankadhafi/talent-hub-service/internal/worker"
 	"github.com/irvankadhafi/talent-hub-service/pkg/cacher"
 	"github.com/irvankadhafi/talent-hub-service/utils"
 	"github.com/labstack/echo/v4"
@@ -17,6 +28,9 @@ import (
 	"github.com/labstack/gommon/log"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -56,19 +70,127 @@ func runServer(cmd *cobra.Command, args []string) {
 
 	candidateRepo := repository.NewCandidateRepository(db.PostgreSQL, cacheManager)
 	sessionRepo := repository.NewSessionRepository(db.PostgreSQL, cacheManager)
-	authUsecase := usecase.NewAuthUsecase(candidateRepo, sessionRepo)
+	sessionCleaner := worker.NewSessionCleaner(sessionRepo, config.SessionCleanupWorkerCount(), config.SessionCleanupQueueSize())
+	sessionCleaner.Start()
+	defer sessionCleaner.Stop()
+
+	// the signed access token is only used on jwt token mode
+	var accessTokenSigner model.AccessTokenSigner
+	jwtKeySet := newJWTKeySet()
+	if jwtKeySet != nil {
+		accessTokenSigner = jwtKeySet
+	}
+
+	mfaRecoveryCodeRepo := repository.NewMFARecoveryCodeRepository(db.PostgreSQL)
+	roleRepo := repository.NewRoleRepository(db.PostgreSQL, cacheManager)
+	impersonationLogRepo := repository.NewImpersonationLogRepository(db.PostgreSQL)
+	authEventRepo := repository.NewAuthEventRepository(db.PostgreSQL)
+	authEventCleaner := worker.NewAuthEventCleaner(authEventRepo, config.AuthEventCleanupInterval())
+	authEventCleaner.Start()
+	defer authEventCleaner.Stop()
+
+	authEventWriter := worker.NewAuthEventWriter(authEventRepo, config.AuthEventWriterCount(), config.AuthEventWriterQueueSize())
+	authEventWriter.Start()
+	defer authEventWriter.Stop()
+
+	supersededRefreshTokenCleaner := worker.NewSupersededRefreshTokenCleaner(sessionRepo, config.SupersededRefreshTokenCleanupInterval())
+	supersededRefreshTokenCleaner.Start()
+	defer supersededRefreshTokenCleaner.Stop()
+
+	emailSender := newEmailSender()
+	smsSender := newSMSSender()
+	loginAlertNotifier := notifier.NewLoginAlertNotifier(emailSender, smsSender)
+
+	// the location is only resolved from the ip address when the database file is set
+	var ipLocator model.IPLocator
+	if config.GeoIPDatabaseFile() != "" {
+		maxMindLocator, err := geoip.NewMaxMindLocator(config.GeoIPDatabaseFile())
+		continueOrFatal(err)
+		defer helper.WrapCloser(maxMindLocator.Close)
+
+		ipLocator = maxMindLocator
+	}
+
+	// the breached password is only checked when the hashes file is set
+	var breachedPasswordChecker model.BreachedPasswordChecker
+	if config.PasswordPolicyBreachedPasswordFile() != "" {
+		fileChecker, err := breach.NewFileChecker(config.PasswordPolicyBreachedPasswordFile())
+		continueOrFatal(err)
+		defer helper.WrapCloser(fileChecker.Close)
+
+		breachedPasswordChecker = fileChecker
+	}
+
+	if config.MFATOTPEncryptionKey() == nil {
+		logrus.Warn("mfa.totp_encryption_key is not set, the TOTP can't be enrolled or verified")
+	}
+
+	passwordHasher := newPasswordHasher()
+	candidateIdentityRepo := repository.NewCandidateIdentityRepository(db.PostgreSQL)
+	authUsecase := usecase.NewAuthUsecase(usecase.AuthUsecaseDeps{
+		CandidateRepo:         candidateRepo,
+		SessionRepo:           sessionRepo,
+		RoleRepo:              roleRepo,
+		SessionCleaner:        sessionCleaner,
+		CacheManager:          cacheManager,
+		SMSSender:             smsSender,
+		MFARecoveryCodeRepo:   mfaRecoveryCodeRepo,
+		AccessTokenSigner:     accessTokenSigner,
+		LoginAlertNotifier:    loginAlertNotifier,
+		ImpersonationLogRepo:  impersonationLogRepo,
+		AuthEventRecorder:     authEventWriter,
+		IPLocator:             ipLocator,
+		PasswordHasher:        passwordHasher,
+		CandidateIdentityRepo: candidateIdentityRepo,
+		OIDCProviders:         newOIDCProviders(),
+	})
+	cityRepo := repository.NewCityRepository(db.PostgreSQL, cacheManager)
+	provinceRepo := repository.NewProvinceRepository(db.PostgreSQL, cacheManager)
+	candidateUsecase := usecase.NewCandidateUsecase(candidateRepo, cityRepo, provinceRepo, passwordHasher, breachedPasswordChecker)
+	passwordResetTokenRepo := repository.NewPasswordResetTokenRepository(db.PostgreSQL, cacheManager)
+	passwordUsecase := usecase.NewPasswordUsecase(candidateRepo, sessionRepo, passwordResetTokenRepo, emailSender, passwordHasher, cacheManager, breachedPasswordChecker)
+	emailVerificationTokenRepo := repository.NewEmailVerificationTokenRepository(db.PostgreSQL)
+	emailVerificationUsecase := usecase.NewEmailVerificationUsecase(candidateRepo, emailVerificationTokenRepo, emailSender, passwordHasher, cacheManager)
+	mfaUsecase := usecase.NewMFAUsecase(candidateRepo, mfaRecoveryCodeRepo, cacheManager)
+	roleUsecase := usecase.NewRoleUsecase(candidateRepo, sessionRepo, roleRepo)
+	authEventUsecase := usecase.NewAuthEventUsecase(authEventRepo)
+	apiKeyUsecase := usecase.NewAPIKeyUsecase(repository.NewAPIKeyRepository(db.PostgreSQL, cacheManager))
+	educationUsecase := usecase.NewEducationUsecase(repository.NewEducationRepository(db.PostgreSQL, cacheManager))
+	experienceUsecase := usecase.NewExperienceUsecase(repository.NewExperienceRepository(db.PostgreSQL, cacheManager))
 	userAuther := usecase.NewCandidateAutherAdapter(authUsecase)
 
 	httpServer := echo.New()
 	authMiddleware := auth.NewAuthenticationMiddleware(userAuther, cacheManager)
+	if jwtKeySet != nil {
+		authMiddleware.SetAccessTokenVerifier(jwtKeySet)
+	}
+	authMiddleware.SetAPIKeyAuthenticator(usecase.NewAPIKeyAutherAdapter(apiKeyUsecase))
 
 	httpServer.Pre(middleware.AddTrailingSlash())
 	httpServer.Use(middleware.Logger())
 	httpServer.Use(middleware.Recover())
 	httpServer.Use(middleware.CORS())
+	httpServer.Use(auth.VerifyCSRFToken())
 
 	apiGroup := httpServer.Group("/api")
-	httpsvc.RouteService(apiGroup, authUsecase, authMiddleware)
+	httpsvc.RouteService(
+		apiGroup,
+		authUsecase,
+		candidateUsecase,
+		passwordUsecase,
+		emailVerificationUsecase,
+		mfaUsecase,
+		roleUsecase,
+		authEventUsecase,
+		apiKeyUsecase,
+		educationUsecase,
+		experienceUsecase,
+		jwtKeySet,
+		authMiddleware,
+	)
+
+	grpcServer := newGRPCServer()
+	grpcsvc.RegisterService(grpcServer, authUsecase, candidateUsecase)
 
 	sigCh := make(chan os.Signal, 1)
 	errCh := make(chan error, 1)
@@ -79,11 +201,11 @@ func runServer(cmd *cobra.Command, args []string) {
 		for {
 			select {
 			case <-sigCh:
-				gracefulShutdown(httpServer)
+				gracefulShutdown(httpServer, grpcServer)
 				quitCh <- true
 			case e := <-errCh:
 				logrus.Error(e)
-				gracefulShutdown(httpServer)
+				gracefulShutdown(httpServer, grpcServer)
 				quitCh <- true
 			}
 		}
@@ -96,19 +218,160 @@ func runServer(cmd *cobra.Command, args []string) {
 		}
 	}()
 
+	go func() {
+		// Start gRPC server
+		lis, err := net.Listen("tcp", net.JoinHostPort(config.GRPCHost(), config.GRPCPort()))
+		if err != nil {
+			errCh <- err
+			return
+		}
+
+		logrus.Infof("gRPC server started on %s", lis.Addr())
+		if err := grpcServer.Serve(lis); err != nil {
+			errCh <- err
+		}
+	}()
+
 	<-quitCh
 	log.Info("exiting")
 }
 
+func newEmailSender() model.EmailSender {
+	switch config.EmailSenderDriver() {
+	case config.EmailSenderDriverSMTP:
+		return notifier.NewSMTPEmailSender(config.SMTPHost(), config.SMTPPort(), config.SMTPUsername(), config.SMTPPassword(), config.SMTPFrom(), config.SMTPTimeout())
+	case config.EmailSenderDriverLog:
+		return notifier.NewLogEmailSender(config.EmailLogFile())
+	default:
+		logrus.Fatalf("unknown email sender driver: %s", config.EmailSenderDriver())
+		return nil
+	}
+}
+
+func newSMSSender() model.SMSSender {
+	switch config.SMSSenderDriver() {
+	case config.SMSSenderDriverLog:
+		return notifier.NewLogSMSSender()
+	default:
+		logrus.Fatalf("unknown sms sender driver: %s", config.SMSSenderDriver())
+		return nil
+	}
+}
+
+// newJWTKeySet load the signing keys on jwt token mode, return nil on opaque token mode
+func newJWTKeySet() *auth.JWTKeySet {
+	if config.TokenMode() != config.TokenModeJWT {
+		return nil
+	}
+
+	privateKeys := map[string]*rsa.PrivateKey{}
+	for _, key := range config.JWTKeys() {
+		bt, err := os.ReadFile(key.PrivateKeyFile)
+		continueOrFatal(err)
+
+		privateKey, err := jwt.ParseRSAPrivateKeyFromPEM(bt)
+		continueOrFatal(err)
+
+		privateKeys[key.ID] = privateKey
+	}
+
+	keySet, err := auth.NewJWTKeySet(config.JWTIssuer(), config.JWTActiveKeyID(), privateKeys)
+	continueOrFatal(err)
+
+	return keySet
+}
+
+func newPasswordHasher() model.PasswordHasher {
+	passwordHasher, err := helper.NewPasswordHasher(config.PasswordHashAlgorithm(), helper.Argon2idParams{
+		Memory:      config.PasswordHashArgon2idMemory(),
+		Iterations:  config.PasswordHashArgon2idIterations(),
+		Parallelism: config.PasswordHashArgon2idParallelism(),
+		SaltLength:  config.PasswordHashArgon2idSaltLength(),
+		KeyLength:   config.PasswordHashArgon2idKeyLength(),
+	}, config.PasswordHashBcryptCost(), config.PasswordHashArgon2idMaxConcurrency())
+	continueOrFatal(err)
+
+	return passwordHasher
+}
+
+// newOIDCProviders creates the providers keyed by the name, the provider without the client id is skipped
+func newOIDCProviders() map[string]model.OIDCProvider {
+	httpClient := &http.Client{Timeout: config.OIDCHTTPTimeout()}
+	providers := map[string]model.OIDCProvider{}
+	for _, provider := range config.OIDCProviders() {
+		if provider.Name == "" || provider.ClientID == "" {
+			continue
+		}
+
+		providers[provider.Name] = oidc.NewProvider(oidc.ProviderConfig{
+			Name:                  provider.Name,
+			Issuer:                provider.Issuer,
+			ClientID:              provider.ClientID,
+			ClientSecret:          provider.ClientSecret,
+			AuthorizationEndpoint: provider.AuthorizationEndpoint,
+			TokenEndpoint:         provider.TokenEndpoint,
+			JWKSURI:               provider.JWKSURI,
+			RedirectURL:           provider.RedirectURL,
+			Scopes:                provider.Scopes,
+		}, httpClient)
+	}
+
+	return providers
+}
+
+// newGRPCServer every call must be authenticated by the service token or the client certificate,
+// the server is not started when neither is configured
+func newGRPCServer() *grpc.Server {
+	var opts []grpc.ServerOption
+	if config.GRPCTLSCertFile() != "" {
+		cert, err := tls.LoadX509KeyPair(config.GRPCTLSCertFile(), config.GRPCTLSKeyFile())
+		continueOrFatal(err)
+
+		tlsConfig := &tls.Config{
+			Certificates: []tls.Certificate{cert},
+			MinVersion:   tls.VersionTLS12,
+		}
+
+		if config.GRPCTLSClientCAFile() != "" {
+			caPEM, err := os.ReadFile(config.GRPCTLSClientCAFile())
+			continueOrFatal(err)
+
+			clientCAs := x509.NewCertPool()
+			if !clientCAs.AppendCertsFromPEM(caPEM) {
+				logrus.Fatalf("invalid grpc client ca file %q", config.GRPCTLSClientCAFile())
+			}
+
+			tlsConfig.ClientCAs = clientCAs
+			tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+		}
+
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+
+	mutualTLS := config.GRPCTLSCertFile() != "" && config.GRPCTLSClientCAFile() != ""
+	switch {
+	case config.GRPCServiceToken() != "":
+		opts = append(opts, grpc.UnaryInterceptor(grpcsvc.NewServiceTokenInterceptor(config.GRPCServiceToken())))
+	case !mutualTLS:
+		logrus.Fatal("grpc.service_token or grpc.tls.client_ca_file must be set to authenticate the grpc clients")
+	}
+
+	return grpc.NewServer(opts...)
+}
+
 func continueOrFatal(err error) {
 	if err != nil {
 		logrus.Fatal(err)
 	}
 }
 
-func gracefulShutdown(httpSvr *echo.Echo) {
+func gracefulShutdown(httpSvr *echo.Echo, grpcSvr *grpc.Server) {
 	db.StopTickerCh <- true
 
+	if grpcSvr != nil {
+		grpcSvr.GracefulStop()
+	}
+
 	if httpSvr != nil {
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
@@ -14,8 +14,13 @@ func GetAuthCandidateFromCtx(ctx context.Context) *model.Candidate {
 	}
 
 	user := &model.Candidate{
-		ID:        authCandidate.ID,
-		SessionID: authCandidate.SessionID,
+		ID:               authCandidate.ID,
+		SessionID:        authCandidate.SessionID,
+		MFAAuthenticated: authCandidate.MFAAuthenticated,
+		Roles:            authCandidate.Roles,
+		ImpersonatorID:   authCandidate.ImpersonatorID,
+		APIKeyID:         authCandidate.APIKeyID,
+		Scopes:           authCandidate.Scopes,
 	}
 
 	return user
@@ -0,0 +1,82 @@
+package grpcsvc
+
+import (
+	"context"
+	"github.com/irvankadhafi/talent-hub-service/internal/usecase"
+	pb "github.com/irvankadhafi/talent-hub-service/pb/auth"
+	"github.com/irvankadhafi/talent-hub-service/utils"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AuthenticateToken authenticate the access token, return NotFound when the token is invalid
+// and Unauthenticated when the token is expired
+func (s *Service) AuthenticateToken(ctx context.Context, req *pb.AuthenticateTokenRequest) (*pb.AuthenticatedCandidate, error) {
+	if req.GetAccessToken() == "" {
+		return nil, status.Error(codes.InvalidArgument, "access token is required")
+	}
+
+	candidate, err := s.authUsecase.AuthenticateToken(ctx, req.GetAccessToken())
+	switch err {
+	case nil:
+	case usecase.ErrNotFound:
+		return nil, status.Error(codes.NotFound, err.Error())
+	case usecase.ErrAccessTokenExpired:
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	default:
+		logrus.Error(err)
+		return nil, status.Error(codes.Internal, "internal system error")
+	}
+
+	roles := make([]string, 0, len(candidate.Roles))
+	for _, role := range candidate.Roles {
+		roles = append(roles, string(role))
+	}
+
+	return &pb.AuthenticatedCandidate{
+		Id:               candidate.ID,
+		SessionId:        candidate.SessionID,
+		MfaAuthenticated: candidate.MFAAuthenticated,
+		Roles:            roles,
+		ImpersonatorId:   candidate.ImpersonatorID,
+	}, nil
+}
+
+// GetCandidate find the candidate by id
+func (s *Service) GetCandidate(ctx context.Context, req *pb.GetCandidateRequest) (*pb.Candidate, error) {
+	candidate, err := s.candidateUsecase.FindByID(ctx, req.GetId())
+	switch err {
+	case nil:
+	case usecase.ErrNotFound:
+		return nil, status.Error(codes.NotFound, err.Error())
+	default:
+		logrus.WithField("id", req.GetId()).Error(err)
+		return nil, status.Error(codes.Internal, "internal system error")
+	}
+
+	return &pb.Candidate{
+		Id:            candidate.ID,
+		FullName:      candidate.FullName,
+		Email:         candidate.Email.String,
+		Phone:         candidate.Phone.String,
+		Gender:        string(candidate.Gender),
+		EmailVerified: candidate.EmailVerifiedAt.Valid,
+		CreatedAt:     utils.FormatTimeRFC3339(&candidate.CreatedAt),
+	}, nil
+}
+
+// RevokeSession delete the session by id
+func (s *Service) RevokeSession(ctx context.Context, req *pb.RevokeSessionRequest) (*pb.RevokeSessionResponse, error) {
+	err := s.authUsecase.DeleteSessionByID(ctx, req.GetSessionId())
+	switch err {
+	case nil:
+	case usecase.ErrNotFound:
+		return nil, status.Error(codes.NotFound, err.Error())
+	default:
+		logrus.WithField("sessionID", req.GetSessionId()).Error(err)
+		return nil, status.Error(codes.Internal, "internal system error")
+	}
+
+	return &pb.RevokeSessionResponse{}, nil
+}
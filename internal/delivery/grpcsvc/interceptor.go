@@ -0,0 +1,48 @@
+package grpcsvc
+
+import (
+	"context"
+	"crypto/subtle"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"strings"
+)
+
+const _serviceTokenScheme = "Bearer"
+
+// NewServiceTokenInterceptor authenticate every call with the shared service token,
+// the token is sent as the `authorization: Bearer <token>` metadata
+func NewServiceTokenInterceptor(serviceToken string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if !isValidServiceToken(ctx, serviceToken) {
+			return nil, status.Error(codes.Unauthenticated, "invalid service token")
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func isValidServiceToken(ctx context.Context, serviceToken string) bool {
+	if serviceToken == "" {
+		return false
+	}
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return false
+	}
+
+	values := md.Get("authorization")
+	if len(values) != 1 {
+		return false
+	}
+
+	scheme, token, ok := strings.Cut(values[0], " ")
+	if !ok || !strings.EqualFold(scheme, _serviceTokenScheme) {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(token), []byte(serviceToken)) == 1
+}
@@ -0,0 +1,27 @@
+package grpcsvc
+
+import (
+	"github.com/irvankadhafi/talent-hub-service/internal/model"
+	pb "github.com/irvankadhafi/talent-hub-service/pb/auth"
+	"google.golang.org/grpc"
+)
+
+// Service grpc service
+type Service struct {
+	pb.UnimplementedAuthServiceServer
+	authUsecase      model.AuthUsecase
+	candidateUsecase model.CandidateUsecase
+}
+
+// RegisterService add dependencies and register the AuthService to the grpc server
+func RegisterService(
+	server *grpc.Server,
+	authUsecase model.AuthUsecase,
+	candidateUsecase model.CandidateUsecase,
+) {
+	srv := &Service{
+		authUsecase:      authUsecase,
+		candidateUsecase: candidateUsecase,
+	}
+	pb.RegisterAuthServiceServer(server, srv)
+}
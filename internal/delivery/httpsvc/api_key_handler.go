@@ -0,0 +1,123 @@
+package httpsvc
+
+import (
+	"github.com/irvankadhafi/talent-hub-service/internal/delivery"
+	"github.com/irvankadhafi/talent-hub-service/internal/delivery/httpsvc/dto"
+	"github.com/irvankadhafi/talent-hub-service/internal/model"
+	"github.com/irvankadhafi/talent-hub-service/internal/usecase"
+	"github.com/irvankadhafi/talent-hub-service/utils"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"net/http"
+)
+
+func (s *Service) handleGetAPIKeys() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		requester := delivery.GetAuthCandidateFromCtx(ctx)
+
+		apiKeys, err := s.apiKeyUsecase.FindAll(ctx, requester)
+		switch err {
+		case nil:
+			break
+		case usecase.ErrPermissionDenied:
+			return ErrPermissionDenied
+		default:
+			logrus.Error(err)
+			return ErrInternal
+		}
+
+		res := make([]dto.APIKeyResponse, 0, len(apiKeys))
+		for _, apiKey := range apiKeys {
+			res = append(res, dto.NewAPIKeyResponse(apiKey))
+		}
+
+		return c.JSON(http.StatusOK, dto.NewSuccessResponse(res, "Success Get API Keys"))
+	}
+}
+
+func (s *Service) handleCreateAPIKey() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := model.CreateAPIKeyInput{}
+		if err := c.Bind(&req); err != nil {
+			logrus.Error(err)
+			return ErrInvalidArgument
+		}
+
+		ctx := c.Request().Context()
+		requester := delivery.GetAuthCandidateFromCtx(ctx)
+
+		apiKey, err := s.apiKeyUsecase.Create(ctx, requester, req)
+		switch err {
+		case nil:
+			break
+		case usecase.ErrPermissionDenied:
+			return ErrPermissionDenied
+		case usecase.ErrAPIKeyScopeInvalid:
+			return httpFieldErr(http.StatusBadRequest, "scopes", "invalid")
+		case usecase.ErrAPIKeyExpiryInvalid:
+			return httpFieldErr(http.StatusBadRequest, "expires_at", "must be in the future")
+		default:
+			logrus.Error(err)
+			return httpValidationOrInternalErr(err)
+		}
+
+		return c.JSON(http.StatusCreated, dto.NewSuccessResponse(dto.NewAPIKeyResponse(apiKey), "Success Create API Key"))
+	}
+}
+
+func (s *Service) handleRotateAPIKey() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		requester := delivery.GetAuthCandidateFromCtx(ctx)
+
+		apiKeyID := utils.StringToInt[int64](c.Param("id"))
+		if apiKeyID <= 0 {
+			return ErrInvalidArgument
+		}
+
+		apiKey, err := s.apiKeyUsecase.Rotate(ctx, requester, apiKeyID)
+		switch err {
+		case nil:
+			break
+		case usecase.ErrPermissionDenied:
+			return ErrPermissionDenied
+		case usecase.ErrNotFound:
+			return ErrNotFound
+		case usecase.ErrAPIKeyRevoked:
+			return ErrAPIKeyRevoked
+		default:
+			logrus.Error(err)
+			return ErrInternal
+		}
+
+		return c.JSON(http.StatusCreated, dto.NewSuccessResponse(dto.NewAPIKeyResponse(apiKey), "Success Rotate API Key"))
+	}
+}
+
+func (s *Service) handleRevokeAPIKey() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		requester := delivery.GetAuthCandidateFromCtx(ctx)
+
+		apiKeyID := utils.StringToInt[int64](c.Param("id"))
+		if apiKeyID <= 0 {
+			return ErrInvalidArgument
+		}
+
+		err := s.apiKeyUsecase.Revoke(ctx, requester, apiKeyID)
+		switch err {
+		case nil:
+			break
+		case usecase.ErrPermissionDenied:
+			return ErrPermissionDenied
+		case usecase.ErrNotFound:
+			return ErrNotFound
+		default:
+			logrus.Error(err)
+			return ErrInternal
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
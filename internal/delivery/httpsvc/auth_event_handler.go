@@ -0,0 +1,79 @@
+package httpsvc
+
+import (
+	"github.com/irvankadhafi/talent-hub-service/internal/delivery"
+	"github.com/irvankadhafi/talent-hub-service/internal/delivery/httpsvc/dto"
+	"github.com/irvankadhafi/talent-hub-service/internal/model"
+	"github.com/irvankadhafi/talent-hub-service/internal/usecase"
+	"github.com/irvankadhafi/talent-hub-service/utils"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"net/http"
+)
+
+func (s *Service) handleGetMyAuthEvents() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		requester := delivery.GetAuthCandidateFromCtx(ctx)
+
+		criteria := newAuthEventCriteria(c)
+		events, count, err := s.authEventUsecase.FindAllByCandidate(ctx, requester, criteria)
+		if err != nil {
+			logrus.Error(err)
+			return ErrInternal
+		}
+
+		return c.JSON(http.StatusOK, dto.NewSuccessResponse(newAuthEventsResponse(events, criteria, count), "Success Get Auth Events"))
+	}
+}
+
+func (s *Service) handleGetAuthEvents() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		requester := delivery.GetAuthCandidateFromCtx(ctx)
+
+		criteria := newAuthEventCriteria(c)
+		criteria.CandidateID = utils.StringToInt[int64](c.QueryParam("candidate_id"))
+
+		events, count, err := s.authEventUsecase.FindAll(ctx, requester, criteria)
+		switch err {
+		case nil:
+			break
+		case usecase.ErrPermissionDenied:
+			return ErrPermissionDenied
+		default:
+			logrus.Error(err)
+			return ErrInternal
+		}
+
+		return c.JSON(http.StatusOK, dto.NewSuccessResponse(newAuthEventsResponse(events, criteria, count), "Success Get Auth Events"))
+	}
+}
+
+func newAuthEventCriteria(c echo.Context) model.AuthEventCriteria {
+	return model.AuthEventCriteria{
+		Pagination: newPagination(c),
+		EventType:  model.AuthEventType(c.QueryParam("event_type")),
+		Outcome:    model.AuthEventOutcome(c.QueryParam("outcome")),
+	}
+}
+
+func newAuthEventsResponse(events []*model.AuthEvent, criteria model.AuthEventCriteria, count int64) dto.PaginationResponse[dto.AuthEventResponse] {
+	res := make([]dto.AuthEventResponse, 0, len(events))
+	for _, event := range events {
+		res = append(res, dto.NewAuthEventResponse(event))
+	}
+
+	return dto.NewPaginationResponse(res, criteria.Page, criteria.Size, count)
+}
+
+// newPagination get the normalized pagination from the `page` and `size` query params
+func newPagination(c echo.Context) model.Pagination {
+	pagination := model.Pagination{
+		Page: utils.StringToInt[int64](c.QueryParam("page")),
+		Size: utils.StringToInt[int64](c.QueryParam("size")),
+	}
+	pagination.Normalize()
+
+	return pagination
+}
@@ -1,11 +1,13 @@
 package httpsvc
 
 import (
+	"errors"
+	"github.com/irvankadhafi/talent-hub-service/auth"
+	"github.com/irvankadhafi/talent-hub-service/internal/config"
 	"github.com/irvankadhafi/talent-hub-service/internal/delivery"
 	"github.com/irvankadhafi/talent-hub-service/internal/delivery/httpsvc/dto"
 	"github.com/irvankadhafi/talent-hub-service/internal/model"
 	"github.com/irvankadhafi/talent-hub-service/internal/usecase"
-	"github.com/irvankadhafi/talent-hub-service/utils"
 	"github.com/labstack/echo/v4"
 	"github.com/sirupsen/logrus"
 	"net/http"
@@ -15,6 +17,7 @@ func (s *Service) handleLoginByIdentifierPassword() echo.HandlerFunc {
 	type loginRequest struct {
 		Identifier string `json:"identifier"` // Can be either email or phone
 		Password   string `json:"password"`
+		geoLocationRequest
 	}
 
 	return func(c echo.Context) error {
@@ -24,15 +27,20 @@ func (s *Service) handleLoginByIdentifierPassword() echo.HandlerFunc {
 			return ErrInvalidArgument
 		}
 
+		geoLocation, err := req.geoLocation(c)
+		if err != nil {
+			return err
+		}
+
 		loginReq := model.LoginRequest{
 			Identifier:    req.Identifier,
 			PlainPassword: req.Password,
 			IPAddress:     c.RealIP(),
 			UserAgent:     c.Request().UserAgent(),
-			// TODO: implement longitude and latitude
+			GeoLocation:   geoLocation,
 		}
 
-		session, err := s.authUsecase.LoginByIdentifierPassword(c.Request().Context(), loginReq)
+		session, challenge, err := s.authUsecase.LoginByIdentifierPassword(c.Request().Context(), loginReq)
 		switch err {
 		case nil:
 			break
@@ -40,6 +48,8 @@ func (s *Service) handleLoginByIdentifierPassword() echo.HandlerFunc {
 			return ErrEmailPasswordNotMatch
 		case usecase.ErrLoginByEmailPasswordLocked:
 			return ErrLoginByEmailPasswordLocked
+		case usecase.ErrEmailNotVerified:
+			return ErrEmailNotVerified
 		case usecase.ErrPermissionDenied:
 			return ErrPermissionDenied
 		default:
@@ -47,12 +57,89 @@ func (s *Service) handleLoginByIdentifierPassword() echo.HandlerFunc {
 			return ErrInternal
 		}
 
-		res := dto.LoginResponse{
-			AccessToken:           session.AccessToken,
-			AccessTokenExpiresAt:  utils.FormatTimeRFC3339(&session.AccessTokenExpiredAt),
-			RefreshToken:          session.RefreshToken,
-			RefreshTokenExpiresAt: utils.FormatTimeRFC3339(&session.RefreshTokenExpiredAt),
-			TokenType:             "Bearer",
+		if challenge != nil {
+			return c.JSON(http.StatusOK, dto.NewSuccessResponse(dto.NewMFAChallengeResponse(challenge), "MFA Required"))
+		}
+
+		res, err := newLoginResponse(c, session)
+		if err != nil {
+			logrus.Error(err)
+			return ErrInternal
+		}
+
+		return c.JSON(http.StatusOK, dto.NewSuccessResponse(res, "Success Login"))
+	}
+}
+
+func (s *Service) handleRequestLoginOTP() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := model.RequestLoginOTPRequest{}
+		if err := c.Bind(&req); err != nil {
+			logrus.Error(err)
+			return ErrInvalidArgument
+		}
+
+		err := s.authUsecase.RequestLoginOTP(c.Request().Context(), req)
+		switch err {
+		case nil:
+			break
+		case usecase.ErrTooManyRequests:
+			return ErrTooManyRequests
+		default:
+			logrus.Error(err)
+			return httpValidationOrInternalErr(err)
+		}
+
+		return c.JSON(http.StatusOK, dto.NewSuccessResponse[any](nil, "If the phone is registered, a login code has been sent"))
+	}
+}
+
+func (s *Service) handleLoginByOTP() echo.HandlerFunc {
+	type request struct {
+		Phone string `json:"phone"`
+		Code  string `json:"code"`
+		geoLocationRequest
+	}
+
+	return func(c echo.Context) error {
+		req := request{}
+		if err := c.Bind(&req); err != nil {
+			logrus.Error(err)
+			return ErrInvalidArgument
+		}
+
+		geoLocation, err := req.geoLocation(c)
+		if err != nil {
+			return err
+		}
+
+		session, challenge, err := s.authUsecase.LoginByOTP(c.Request().Context(), model.LoginByOTPRequest{
+			Phone:       req.Phone,
+			Code:        req.Code,
+			IPAddress:   c.RealIP(),
+			UserAgent:   c.Request().UserAgent(),
+			GeoLocation: geoLocation,
+		})
+		switch err {
+		case nil:
+			break
+		case usecase.ErrOTPInvalid, usecase.ErrNotFound:
+			return ErrOTPInvalid
+		case usecase.ErrLoginByEmailPasswordLocked:
+			return ErrLoginByEmailPasswordLocked
+		default:
+			logrus.Error(err)
+			return httpValidationOrInternalErr(err)
+		}
+
+		if challenge != nil {
+			return c.JSON(http.StatusOK, dto.NewSuccessResponse(dto.NewMFAChallengeResponse(challenge), "MFA Required"))
+		}
+
+		res, err := newLoginResponse(c, session)
+		if err != nil {
+			logrus.Error(err)
+			return ErrInternal
 		}
 
 		return c.JSON(http.StatusOK, dto.NewSuccessResponse(res, "Success Login"))
@@ -62,6 +149,7 @@ func (s *Service) handleLoginByIdentifierPassword() echo.HandlerFunc {
 func (s *Service) handleRefreshToken() echo.HandlerFunc {
 	type request struct {
 		RefreshToken string `json:"refresh_token"`
+		geoLocationRequest
 	}
 
 	return func(c echo.Context) error {
@@ -71,28 +159,37 @@ func (s *Service) handleRefreshToken() echo.HandlerFunc {
 			return ErrInvalidArgument
 		}
 
+		geoLocation, err := req.geoLocation(c)
+		if err != nil {
+			return err
+		}
+
+		// on the cookie session mode, the refresh token is taken from the cookie
+		if req.RefreshToken == "" {
+			req.RefreshToken = getRefreshTokenCookie(c)
+		}
+
 		session, err := s.authUsecase.RefreshToken(c.Request().Context(), model.RefreshTokenRequest{
 			RefreshToken: req.RefreshToken,
 			IPAddress:    c.RealIP(),
 			UserAgent:    c.Request().UserAgent(),
-			// TODO: implement longitude and latitude
-
+			GeoLocation:  geoLocation,
 		})
 		switch err {
 		case nil:
-		case usecase.ErrRefreshTokenExpired, usecase.ErrNotFound:
+		case usecase.ErrRefreshTokenExpired, usecase.ErrRefreshTokenReused, usecase.ErrNotFound:
 			return ErrUnauthenticated
+		case usecase.ErrPermissionDenied:
+			return ErrPermissionDenied
 		default:
 			logrus.Error(err)
 			return ErrInternal
 		}
 
-		res := dto.LoginResponse{
-			AccessToken:           session.AccessToken,
-			AccessTokenExpiresAt:  utils.FormatTimeRFC3339(&session.AccessTokenExpiredAt),
-			RefreshToken:          session.RefreshToken,
-			RefreshTokenExpiresAt: utils.FormatTimeRFC3339(&session.RefreshTokenExpiredAt),
-			TokenType:             "Bearer",
+		res, err := newLoginResponse(c, session)
+		if err != nil {
+			logrus.Error(err)
+			return ErrInternal
 		}
 
 		return c.JSON(http.StatusOK, dto.NewSuccessResponse(res, "Success Refresh Token"))
@@ -104,7 +201,7 @@ func (s *Service) handleLogout() echo.HandlerFunc {
 		ctx := c.Request().Context()
 		requester := delivery.GetAuthCandidateFromCtx(ctx)
 
-		err := s.authUsecase.DeleteSessionByID(c.Request().Context(), requester.SessionID)
+		err := s.authUsecase.Logout(ctx, requester, newClientInfo(c))
 		switch err {
 		case nil:
 			break
@@ -115,6 +212,100 @@ func (s *Service) handleLogout() echo.HandlerFunc {
 			return httpValidationOrInternalErr(err)
 		}
 
+		if config.SessionCookieEnabled() {
+			clearSessionCookies(c)
+		}
+
 		return c.NoContent(http.StatusNoContent)
 	}
 }
+
+func (s *Service) handleRegister() echo.HandlerFunc {
+	type request struct {
+		model.CreateCandidateInput
+		Login bool `json:"login"` // when true, login the candidate straight away
+		geoLocationRequest
+	}
+
+	return func(c echo.Context) error {
+		req := request{}
+		if err := c.Bind(&req); err != nil {
+			logrus.Error(err)
+			return ErrInvalidArgument
+		}
+
+		geoLocation, err := req.geoLocation(c)
+		if err != nil {
+			return err
+		}
+
+		ctx := c.Request().Context()
+		candidate, err := s.candidateUsecase.Create(ctx, req.CreateCandidateInput)
+		switch {
+		case err == nil:
+			break
+		case errors.Is(err, usecase.ErrDuplicateEmail):
+			return httpFieldErr(http.StatusConflict, "email", "already registered")
+		case errors.Is(err, usecase.ErrDuplicatePhone):
+			return httpFieldErr(http.StatusConflict, "phone", "already registered")
+		default:
+			logrus.Error(err)
+			return httpValidationOrInternalErr(err)
+		}
+
+		if candidate.Email.String != "" {
+			if err := s.emailVerificationUsecase.SendVerification(ctx, candidate.ID); err != nil {
+				logrus.WithField("candidateID", candidate.ID).Error(err)
+			}
+		}
+
+		res := dto.RegisterResponse{
+			Candidate: dto.NewCandidateResponse(candidate),
+		}
+		if !req.Login {
+			return c.JSON(http.StatusCreated, dto.NewSuccessResponse(res, "Success Register"))
+		}
+
+		identifier := candidate.Email.String
+		if identifier == "" {
+			identifier = candidate.Phone.String
+		}
+
+		session, _, err := s.authUsecase.LoginByIdentifierPassword(ctx, model.LoginRequest{
+			Identifier:    identifier,
+			PlainPassword: req.Password,
+			IPAddress:     c.RealIP(),
+			UserAgent:     c.Request().UserAgent(),
+			GeoLocation:   geoLocation,
+		})
+		if err != nil || session == nil {
+			// the candidate is already registered, the client can still login by itself
+			logrus.WithField("candidateID", candidate.ID).Error(err)
+			return c.JSON(http.StatusCreated, dto.NewSuccessResponse(res, "Success Register"))
+		}
+
+		loginRes, err := newLoginResponse(c, session)
+		if err != nil {
+			// the candidate is already registered, the client can still login by itself
+			logrus.WithField("candidateID", candidate.ID).Error(err)
+			return c.JSON(http.StatusCreated, dto.NewSuccessResponse(res, "Success Register"))
+		}
+		res.Session = &loginRes
+
+		return c.JSON(http.StatusCreated, dto.NewSuccessResponse(res, "Success Register"))
+	}
+}
+
+// handleGetJWKS returns the public keys to verify the signed access token,
+// the keys are empty on opaque token mode
+func (s *Service) handleGetJWKS() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		jwks := auth.JSONWebKeySet{Keys: []auth.JSONWebKey{}}
+		if s.jwtKeySet != nil {
+			jwks = s.jwtKeySet.JWKS()
+		}
+
+		c.Response().Header().Set(echo.HeaderCacheControl, "public, max-age=300")
+		return c.JSON(http.StatusOK, jwks)
+	}
+}
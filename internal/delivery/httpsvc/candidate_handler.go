@@ -0,0 +1,63 @@
+package httpsvc
+
+import (
+	"github.com/irvankadhafi/talent-hub-service/internal/delivery"
+	"github.com/irvankadhafi/talent-hub-service/internal/delivery/httpsvc/dto"
+	"github.com/irvankadhafi/talent-hub-service/internal/model"
+	"github.com/irvankadhafi/talent-hub-service/internal/usecase"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"net/http"
+)
+
+func (s *Service) handleGetMe() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		requester := delivery.GetAuthCandidateFromCtx(ctx)
+
+		candidate, err := s.candidateUsecase.FindByID(ctx, requester.ID)
+		switch err {
+		case nil:
+			break
+		case usecase.ErrNotFound:
+			return ErrNotFound
+		default:
+			logrus.Error(err)
+			return ErrInternal
+		}
+
+		return c.JSON(http.StatusOK, dto.NewSuccessResponse(dto.NewCandidateResponse(candidate), "Success Get Profile"))
+	}
+}
+
+func (s *Service) handleUpdateMe() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := model.UpdateProfileInput{}
+		if err := c.Bind(&req); err != nil {
+			logrus.Error(err)
+			return ErrInvalidArgument
+		}
+
+		ctx := c.Request().Context()
+		requester := delivery.GetAuthCandidateFromCtx(ctx)
+
+		candidate, err := s.candidateUsecase.UpdateProfile(ctx, requester, req)
+		switch err {
+		case nil:
+			break
+		case usecase.ErrNotFound:
+			return ErrNotFound
+		case usecase.ErrDateOfBirthInvalid:
+			return httpFieldErr(http.StatusBadRequest, "date_of_birth", "must be in the past")
+		case usecase.ErrCityInvalid:
+			return httpFieldErr(http.StatusBadRequest, "city_id", "not found or not in the province")
+		case usecase.ErrProvinceInvalid:
+			return httpFieldErr(http.StatusBadRequest, "province_id", "not found")
+		default:
+			logrus.Error(err)
+			return httpValidationOrInternalErr(err)
+		}
+
+		return c.JSON(http.StatusOK, dto.NewSuccessResponse(dto.NewCandidateResponse(candidate), "Success Update Profile"))
+	}
+}
@@ -1,12 +1,374 @@
 package dto
 
+import (
+	"github.com/irvankadhafi/talent-hub-service/internal/model"
+	"github.com/irvankadhafi/talent-hub-service/utils"
+	"gopkg.in/guregu/null.v4"
+)
+
 // LoginResponse for login response data.
+// On the cookie session mode, the tokens are set on the cookies instead, only the csrf token is returned.
 type LoginResponse struct {
-	AccessToken           string `json:"access_token"`
+	AccessToken           string `json:"access_token,omitempty"`
 	AccessTokenExpiresAt  string `json:"access_token_expires_at"`
 	TokenType             string `json:"token_type"`
-	RefreshToken          string `json:"refresh_token"`
+	RefreshToken          string `json:"refresh_token,omitempty"`
 	RefreshTokenExpiresAt string `json:"refresh_token_expires_at"`
+	CSRFToken             string `json:"csrf_token,omitempty"`
+}
+
+// NewLoginResponse creates a login response from the session.
+func NewLoginResponse(session *model.Session) LoginResponse {
+	return LoginResponse{
+		AccessToken:           session.AccessToken,
+		AccessTokenExpiresAt:  utils.FormatTimeRFC3339(&session.AccessTokenExpiredAt),
+		RefreshToken:          session.RefreshToken,
+		RefreshTokenExpiresAt: utils.FormatTimeRFC3339(&session.RefreshTokenExpiredAt),
+		TokenType:             "Bearer",
+	}
+}
+
+// MFAChallengeResponse for login response data when the second factor is required.
+type MFAChallengeResponse struct {
+	MFARequired        bool                     `json:"mfa_required"`
+	ChallengeToken     string                   `json:"challenge_token"`
+	ChallengeMethod    model.MFAChallengeMethod `json:"challenge_method"`
+	ChallengeExpiresAt string                   `json:"challenge_expires_at"`
+}
+
+// NewMFAChallengeResponse creates a mfa challenge response from the challenge.
+func NewMFAChallengeResponse(challenge *model.MFAChallenge) MFAChallengeResponse {
+	return MFAChallengeResponse{
+		MFARequired:        true,
+		ChallengeToken:     challenge.Token,
+		ChallengeMethod:    challenge.Method,
+		ChallengeExpiresAt: utils.FormatTimeRFC3339(&challenge.ExpiredAt),
+	}
+}
+
+// OIDCAuthorizationResponse for the oidc authorization response data, the client redirects the candidate to the authorization url.
+type OIDCAuthorizationResponse struct {
+	Provider         string `json:"provider"`
+	AuthorizationURL string `json:"authorization_url"`
+	State            string `json:"state"`
+	ExpiresAt        string `json:"expires_at"`
+}
+
+// NewOIDCAuthorizationResponse creates an oidc authorization response from the authorization.
+func NewOIDCAuthorizationResponse(authorization *model.OIDCAuthorization) OIDCAuthorizationResponse {
+	return OIDCAuthorizationResponse{
+		Provider:         authorization.Provider,
+		AuthorizationURL: authorization.AuthorizationURL,
+		State:            authorization.State,
+		ExpiresAt:        utils.FormatTimeRFC3339(&authorization.ExpiredAt),
+	}
+}
+
+// TOTPEnrollmentResponse for totp enrollment response data.
+type TOTPEnrollmentResponse struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauth_uri"`
+}
+
+// NewTOTPEnrollmentResponse creates a totp enrollment response from the enrollment.
+func NewTOTPEnrollmentResponse(enrollment *model.TOTPEnrollment) TOTPEnrollmentResponse {
+	return TOTPEnrollmentResponse{
+		Secret:     enrollment.Secret,
+		OTPAuthURI: enrollment.URI,
+	}
+}
+
+// RecoveryCodesResponse for recovery codes response data, the codes are only shown once.
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// RolesResponse for roles response data.
+type RolesResponse struct {
+	Roles []model.Role `json:"roles"`
+}
+
+// CandidateResponse for candidate response data, never includes the password.
+type CandidateResponse struct {
+	ID            int64        `json:"id"`
+	FullName      string       `json:"full_name"`
+	Email         string       `json:"email,omitempty"`
+	EmailVerified bool         `json:"email_verified"`
+	Phone         string       `json:"phone,omitempty"`
+	DateOfBirth   string       `json:"date_of_birth,omitempty"`
+	Gender        model.Gender `json:"gender"`
+	CityID        int64        `json:"city_id,omitempty"`
+	ProvinceID    int64        `json:"province_id,omitempty"`
+	MFAEnabled    bool         `json:"mfa_enabled"`
+	CreatedAt     string       `json:"created_at"`
+	UpdatedAt     string       `json:"updated_at"`
+}
+
+// NewCandidateResponse creates a candidate response from the candidate.
+func NewCandidateResponse(candidate *model.Candidate) CandidateResponse {
+	res := CandidateResponse{
+		ID:            candidate.ID,
+		FullName:      candidate.FullName,
+		Email:         candidate.Email.String,
+		EmailVerified: candidate.EmailVerifiedAt.Valid,
+		Phone:         candidate.Phone.String,
+		Gender:        candidate.Gender,
+		CityID:        candidate.CityID,
+		ProvinceID:    candidate.ProvinceID,
+		MFAEnabled:    candidate.IsTOTPEnabled(),
+		CreatedAt:     utils.FormatTimeRFC3339(&candidate.CreatedAt),
+		UpdatedAt:     utils.FormatTimeRFC3339(&candidate.UpdatedAt),
+	}
+
+	if candidate.DateOfBirth.Valid {
+		res.DateOfBirth = candidate.DateOfBirth.Time.Format(model.DateOfBirthLayout)
+	}
+
+	return res
+}
+
+// PartnerCandidateResponse for candidate response data read by the partner api key,
+// never includes the contact and the other personal data.
+type PartnerCandidateResponse struct {
+	ID         int64        `json:"id"`
+	FullName   string       `json:"full_name"`
+	Gender     model.Gender `json:"gender"`
+	CityID     int64        `json:"city_id,omitempty"`
+	ProvinceID int64        `json:"province_id,omitempty"`
+	CreatedAt  string       `json:"created_at"`
+	UpdatedAt  string       `json:"updated_at"`
+}
+
+// NewPartnerCandidateResponse creates a partner candidate response from the candidate.
+func NewPartnerCandidateResponse(candidate *model.Candidate) PartnerCandidateResponse {
+	return PartnerCandidateResponse{
+		ID:         candidate.ID,
+		FullName:   candidate.FullName,
+		Gender:     candidate.Gender,
+		CityID:     candidate.CityID,
+		ProvinceID: candidate.ProvinceID,
+		CreatedAt:  utils.FormatTimeRFC3339(&candidate.CreatedAt),
+		UpdatedAt:  utils.FormatTimeRFC3339(&candidate.UpdatedAt),
+	}
+}
+
+// RegisterResponse for register response data.
+// Session is only present when the candidate is logged in straight away.
+type RegisterResponse struct {
+	Candidate CandidateResponse `json:"candidate"`
+	Session   *LoginResponse    `json:"session,omitempty"`
+}
+
+// SessionResponse for session response data, never includes the tokens.
+type SessionResponse struct {
+	ID               int64                 `json:"id"`
+	UserAgent        string                `json:"user_agent"`
+	IPAddress        string                `json:"ip_address"`
+	Latitude         null.Float            `json:"latitude"`
+	Longitude        null.Float            `json:"longitude"`
+	IsCurrent        bool                  `json:"is_current"`
+	MFAAuthenticated bool                  `json:"mfa_authenticated"`
+	ImpersonatorID   int64                 `json:"impersonator_id,omitempty"`
+	RiskFlags        []model.LoginRiskFlag `json:"risk_flags,omitempty"`
+	CreatedAt        string                `json:"created_at"`
+	LastActiveAt     string                `json:"last_active_at"`
+}
+
+// NewSessionResponse creates a session response from the session,
+// flagged as current when it's the requester's session.
+func NewSessionResponse(session *model.Session, currentSessionID int64) SessionResponse {
+	return SessionResponse{
+		ID:               session.ID,
+		UserAgent:        session.UserAgent,
+		IPAddress:        session.IPAddress,
+		Latitude:         session.Latitude,
+		Longitude:        session.Longitude,
+		IsCurrent:        session.ID == currentSessionID,
+		MFAAuthenticated: session.MFAAuthenticated,
+		ImpersonatorID:   session.ImpersonatorID.Int64,
+		RiskFlags:        session.RiskFlags,
+		CreatedAt:        utils.FormatTimeRFC3339(&session.CreatedAt),
+		LastActiveAt:     utils.FormatTimeRFC3339(&session.UpdatedAt),
+	}
+}
+
+// AuthEventResponse for auth event response data.
+type AuthEventResponse struct {
+	ID          int64                  `json:"id"`
+	EventType   model.AuthEventType    `json:"event_type"`
+	CandidateID int64                  `json:"candidate_id,omitempty"`
+	SessionID   int64                  `json:"session_id,omitempty"`
+	Identifier  string                 `json:"identifier,omitempty"`
+	IPAddress   string                 `json:"ip_address"`
+	UserAgent   string                 `json:"user_agent"`
+	Latitude    null.Float             `json:"latitude"`
+	Longitude   null.Float             `json:"longitude"`
+	Outcome     model.AuthEventOutcome `json:"outcome"`
+	Reason      string                 `json:"reason,omitempty"`
+	CreatedAt   string                 `json:"created_at"`
+}
+
+// NewAuthEventResponse creates an auth event response from the auth event.
+func NewAuthEventResponse(event *model.AuthEvent) AuthEventResponse {
+	return AuthEventResponse{
+		ID:          event.ID,
+		EventType:   event.EventType,
+		CandidateID: event.CandidateID.Int64,
+		SessionID:   event.SessionID.Int64,
+		Identifier:  event.Identifier,
+		IPAddress:   event.IPAddress,
+		UserAgent:   event.UserAgent,
+		Latitude:    event.Latitude,
+		Longitude:   event.Longitude,
+		Outcome:     event.Outcome,
+		Reason:      event.Reason,
+		CreatedAt:   utils.FormatTimeRFC3339(&event.CreatedAt),
+	}
+}
+
+// APIKeyResponse for api key response data, the key is only present when the key is created or rotated.
+type APIKeyResponse struct {
+	ID            int64              `json:"id"`
+	Name          string             `json:"name"`
+	Key           string             `json:"key,omitempty"`
+	Prefix        string             `json:"prefix"`
+	Scopes        []model.Permission `json:"scopes"`
+	ExpiresAt     null.Time          `json:"expires_at"`
+	LastUsedAt    null.Time          `json:"last_used_at"`
+	RevokedAt     null.Time          `json:"revoked_at"`
+	RotatedFromID int64              `json:"rotated_from_id,omitempty"`
+	CreatedBy     int64              `json:"created_by,omitempty"`
+	CreatedAt     string             `json:"created_at"`
+}
+
+// NewAPIKeyResponse creates an api key response from the api key, never includes the key hash.
+func NewAPIKeyResponse(apiKey *model.APIKey) APIKeyResponse {
+	return APIKeyResponse{
+		ID:            apiKey.ID,
+		Name:          apiKey.Name,
+		Key:           apiKey.Key,
+		Prefix:        model.APIKeyPrefix + apiKey.Prefix,
+		Scopes:        apiKey.Scopes,
+		ExpiresAt:     apiKey.ExpiredAt,
+		LastUsedAt:    apiKey.LastUsedAt,
+		RevokedAt:     apiKey.RevokedAt,
+		RotatedFromID: apiKey.RotatedFromID.Int64,
+		CreatedBy:     apiKey.CreatedBy.Int64,
+		CreatedAt:     utils.FormatTimeRFC3339(&apiKey.CreatedAt),
+	}
+}
+
+// EducationResponse for education response data.
+type EducationResponse struct {
+	ID              int64      `json:"id"`
+	InstitutionName string     `json:"institution_name"`
+	Major           string     `json:"major"`
+	StartDate       string     `json:"start_date"`
+	EndDate         string     `json:"end_date,omitempty"`
+	UntilNow        bool       `json:"until_now"`
+	GPA             null.Float `json:"gpa"`
+	GPAScale        float64    `json:"gpa_scale"`
+	CreatedAt       string     `json:"created_at"`
+	UpdatedAt       string     `json:"updated_at"`
+}
+
+// NewEducationResponse creates an education response from the education.
+func NewEducationResponse(education *model.Education) EducationResponse {
+	res := EducationResponse{
+		ID:              education.ID,
+		InstitutionName: education.InstitutionName,
+		Major:           education.Major,
+		StartDate:       education.StartYear.Format(model.DateLayout),
+		UntilNow:        education.UntilNow,
+		GPA:             education.GPA,
+		GPAScale:        education.GPAScale,
+		CreatedAt:       utils.FormatTimeRFC3339(&education.CreatedAt),
+		UpdatedAt:       utils.FormatTimeRFC3339(&education.UpdatedAt),
+	}
+
+	if education.EndYear.Valid {
+		res.EndDate = education.EndYear.Time.Format(model.DateLayout)
+	}
+
+	return res
+}
+
+// ExperienceResponse for experience response data.
+// The overlaps are only present on the created or updated experience as the warning.
+type ExperienceResponse struct {
+	ID             int64                       `json:"id"`
+	CompanyName    string                      `json:"company_name"`
+	CompanyAddress string                      `json:"company_address"`
+	Position       string                      `json:"position"`
+	JobDescription string                      `json:"job_description"`
+	StartDate      string                      `json:"start_date"`
+	EndDate        string                      `json:"end_date,omitempty"`
+	UntilNow       bool                        `json:"until_now"`
+	Overlaps       []ExperienceOverlapResponse `json:"overlaps,omitempty"`
+	CreatedAt      string                      `json:"created_at"`
+	UpdatedAt      string                      `json:"updated_at"`
+}
+
+// ExperienceOverlapResponse for the overlapping experience warning data.
+type ExperienceOverlapResponse struct {
+	ID          int64  `json:"id"`
+	CompanyName string `json:"company_name"`
+	Position    string `json:"position"`
+	Message     string `json:"message"`
+}
+
+// NewExperienceResponse creates an experience response from the experience.
+func NewExperienceResponse(experience *model.Experience) ExperienceResponse {
+	res := ExperienceResponse{
+		ID:             experience.ID,
+		CompanyName:    experience.CompanyName,
+		CompanyAddress: experience.CompanyAddress,
+		Position:       experience.Position,
+		JobDescription: experience.JobDescription,
+		StartDate:      experience.StartYear.Format(model.DateLayout),
+		UntilNow:       experience.UntilNow,
+		CreatedAt:      utils.FormatTimeRFC3339(&experience.CreatedAt),
+		UpdatedAt:      utils.FormatTimeRFC3339(&experience.UpdatedAt),
+	}
+
+	if experience.EndYear.Valid {
+		res.EndDate = experience.EndYear.Time.Format(model.DateLayout)
+	}
+
+	return res
+}
+
+// NewExperienceWithOverlapsResponse creates an experience response with the overlapping experiences as the warning.
+func NewExperienceWithOverlapsResponse(experience *model.Experience, overlaps []*model.Experience) ExperienceResponse {
+	res := NewExperienceResponse(experience)
+	for _, overlap := range overlaps {
+		res.Overlaps = append(res.Overlaps, ExperienceOverlapResponse{
+			ID:          overlap.ID,
+			CompanyName: overlap.CompanyName,
+			Position:    overlap.Position,
+			Message:     "the dates overlap with this experience",
+		})
+	}
+
+	return res
+}
+
+// PaginationResponse for paginated response data.
+type PaginationResponse[T any] struct {
+	Items      []T   `json:"items"`
+	Page       int64 `json:"page"`
+	Size       int64 `json:"size"`
+	TotalCount int64 `json:"total_count"`
+}
+
+// NewPaginationResponse creates a pagination response from the items.
+func NewPaginationResponse[T any](items []T, page, size, totalCount int64) PaginationResponse[T] {
+	return PaginationResponse[T]{
+		Items:      items,
+		Page:       page,
+		Size:       size,
+		TotalCount: totalCount,
+	}
 }
 
 // Response is a generic structure for standard API responses.
@@ -0,0 +1,159 @@
+package httpsvc
+
+import (
+	"github.com/irvankadhafi/talent-hub-service/internal/delivery"
+	"github.com/irvankadhafi/talent-hub-service/internal/delivery/httpsvc/dto"
+	"github.com/irvankadhafi/talent-hub-service/internal/model"
+	"github.com/irvankadhafi/talent-hub-service/internal/usecase"
+	"github.com/irvankadhafi/talent-hub-service/utils"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"net/http"
+)
+
+func (s *Service) handleGetMyEducations() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		requester := delivery.GetAuthCandidateFromCtx(ctx)
+
+		pagination := newPagination(c)
+		educations, count, err := s.educationUsecase.FindAllByCandidate(ctx, requester, pagination)
+		if err != nil {
+			logrus.Error(err)
+			return ErrInternal
+		}
+
+		res := make([]dto.EducationResponse, 0, len(educations))
+		for _, education := range educations {
+			res = append(res, dto.NewEducationResponse(education))
+		}
+
+		return c.JSON(http.StatusOK, dto.NewSuccessResponse(dto.NewPaginationResponse(res, pagination.Page, pagination.Size, count), "Success Get Educations"))
+	}
+}
+
+func (s *Service) handleGetMyEducation() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		requester := delivery.GetAuthCandidateFromCtx(ctx)
+
+		educationID := utils.StringToInt[int64](c.Param("id"))
+		if educationID <= 0 {
+			return ErrInvalidArgument
+		}
+
+		education, err := s.educationUsecase.FindByID(ctx, requester, educationID)
+		switch err {
+		case nil:
+			break
+		case usecase.ErrNotFound:
+			return ErrNotFound
+		default:
+			logrus.Error(err)
+			return ErrInternal
+		}
+
+		return c.JSON(http.StatusOK, dto.NewSuccessResponse(dto.NewEducationResponse(education), "Success Get Education"))
+	}
+}
+
+func (s *Service) handleCreateEducation() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := model.EducationInput{}
+		if err := c.Bind(&req); err != nil {
+			logrus.Error(err)
+			return ErrInvalidArgument
+		}
+
+		ctx := c.Request().Context()
+		requester := delivery.GetAuthCandidateFromCtx(ctx)
+
+		education, err := s.educationUsecase.Create(ctx, requester, req)
+		if err != nil {
+			return educationInputErr(err)
+		}
+
+		return c.JSON(http.StatusCreated, dto.NewSuccessResponse(dto.NewEducationResponse(education), "Success Create Education"))
+	}
+}
+
+func (s *Service) handleUpdateEducation() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := model.EducationInput{}
+		if err := c.Bind(&req); err != nil {
+			logrus.Error(err)
+			return ErrInvalidArgument
+		}
+
+		ctx := c.Request().Context()
+		requester := delivery.GetAuthCandidateFromCtx(ctx)
+
+		educationID := utils.StringToInt[int64](c.Param("id"))
+		if educationID <= 0 {
+			return ErrInvalidArgument
+		}
+
+		education, err := s.educationUsecase.Update(ctx, requester, educationID, req)
+		if err != nil {
+			return educationInputErr(err)
+		}
+
+		return c.JSON(http.StatusOK, dto.NewSuccessResponse(dto.NewEducationResponse(education), "Success Update Education"))
+	}
+}
+
+func (s *Service) handleDeleteEducation() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		requester := delivery.GetAuthCandidateFromCtx(ctx)
+
+		educationID := utils.StringToInt[int64](c.Param("id"))
+		if educationID <= 0 {
+			return ErrInvalidArgument
+		}
+
+		err := s.educationUsecase.Delete(ctx, requester, educationID)
+		switch err {
+		case nil:
+			break
+		case usecase.ErrNotFound:
+			return ErrNotFound
+		default:
+			logrus.Error(err)
+			return ErrInternal
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// educationInputErr map the error of the create and update education to the http error
+func educationInputErr(err error) error {
+	switch err {
+	case usecase.ErrNotFound:
+		return ErrNotFound
+	case usecase.ErrGPAScaleInvalid:
+		return httpFieldErr(http.StatusBadRequest, "gpa_scale", "must be one of 4, 5, 10, 100")
+	case usecase.ErrGPAExceedsScale:
+		return httpFieldErr(http.StatusBadRequest, "gpa", "must not exceed the gpa scale")
+	default:
+		return dateRangeInputErr(err)
+	}
+}
+
+// dateRangeInputErr map the error of the start and end dates to the http error
+func dateRangeInputErr(err error) error {
+	switch err {
+	case usecase.ErrStartDateInvalid:
+		return httpFieldErr(http.StatusBadRequest, "start_date", "must not be in the future")
+	case usecase.ErrEndDateRequired:
+		return httpFieldErr(http.StatusBadRequest, "end_date", "required unless until now")
+	case usecase.ErrEndDateUntilNowConflict:
+		return httpFieldErr(http.StatusBadRequest, "end_date", "must be empty when until now")
+	case usecase.ErrEndDateBeforeStartDate:
+		return httpFieldErr(http.StatusBadRequest, "end_date", "must not be before the start date")
+	default:
+		logrus.Error(err)
+		return httpValidationOrInternalErr(err)
+	}
+}
@@ -0,0 +1,98 @@
+package httpsvc
+
+import (
+	"github.com/irvankadhafi/talent-hub-service/internal/delivery"
+	"github.com/irvankadhafi/talent-hub-service/internal/delivery/httpsvc/dto"
+	"github.com/irvankadhafi/talent-hub-service/internal/model"
+	"github.com/irvankadhafi/talent-hub-service/internal/usecase"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"net/http"
+)
+
+func (s *Service) handleSendEmailVerification() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		requester := delivery.GetAuthCandidateFromCtx(ctx)
+
+		err := s.emailVerificationUsecase.SendVerification(ctx, requester.ID)
+		switch err {
+		case nil:
+			break
+		case usecase.ErrNotFound:
+			return ErrNotFound
+		case usecase.ErrEmailNotSet:
+			return ErrEmailNotSet
+		case usecase.ErrEmailAlreadyVerified:
+			return ErrEmailAlreadyVerified
+		case usecase.ErrTooManyRequests:
+			return ErrTooManyRequests
+		default:
+			logrus.Error(err)
+			return ErrInternal
+		}
+
+		return c.JSON(http.StatusOK, dto.NewSuccessResponse[any](nil, "Verification email has been sent"))
+	}
+}
+
+func (s *Service) handleVerifyEmail() echo.HandlerFunc {
+	type request struct {
+		Token string `json:"token"`
+	}
+
+	return func(c echo.Context) error {
+		req := request{}
+		if err := c.Bind(&req); err != nil {
+			logrus.Error(err)
+			return ErrInvalidArgument
+		}
+
+		err := s.emailVerificationUsecase.Verify(c.Request().Context(), req.Token)
+		switch err {
+		case nil:
+			break
+		case usecase.ErrEmailVerificationTokenInvalid:
+			return ErrEmailVerificationTokenInvalid
+		default:
+			logrus.Error(err)
+			return ErrInternal
+		}
+
+		return c.JSON(http.StatusOK, dto.NewSuccessResponse[any](nil, "Success Verify Email"))
+	}
+}
+
+func (s *Service) handleChangeEmail() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := model.ChangeEmailInput{}
+		if err := c.Bind(&req); err != nil {
+			logrus.Error(err)
+			return ErrInvalidArgument
+		}
+
+		ctx := c.Request().Context()
+		requester := delivery.GetAuthCandidateFromCtx(ctx)
+
+		err := s.emailVerificationUsecase.ChangeEmail(ctx, requester, req)
+		switch err {
+		case nil:
+			break
+		case usecase.ErrCurrentPasswordNotMatch:
+			return httpFieldErr(http.StatusBadRequest, "password", "not match")
+		case usecase.ErrPasswordNotSet:
+			return ErrPasswordNotSet
+		case usecase.ErrDuplicateEmail:
+			return httpFieldErr(http.StatusConflict, "email", "already registered")
+		case usecase.ErrPermissionDenied:
+			return ErrPermissionDenied
+		case usecase.ErrNotFound:
+			return ErrNotFound
+		default:
+			logrus.Error(err)
+			return httpValidationOrInternalErr(err)
+		}
+
+		return c.JSON(http.StatusOK, dto.NewSuccessResponse[any](nil, "Success Change Email, verification email has been sent"))
+	}
+}
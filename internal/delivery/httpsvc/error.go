@@ -3,6 +3,7 @@ package httpsvc
 import (
 	"fmt"
 	"github.com/go-playground/validator/v10"
+	"github.com/irvankadhafi/talent-hub-service/internal/usecase"
 	"github.com/irvankadhafi/talent-hub-service/utils"
 	"net/http"
 
@@ -11,19 +12,39 @@ import (
 
 // http errors
 var (
-	ErrInvalidArgument            = echo.NewHTTPError(http.StatusBadRequest, "invalid argument")
-	ErrNotFound                   = echo.NewHTTPError(http.StatusNotFound, "record not found")
-	ErrInternal                   = echo.NewHTTPError(http.StatusInternalServerError, "internal system error")
-	ErrEntityTooLarge             = echo.NewHTTPError(http.StatusRequestEntityTooLarge, "entity too large")
-	ErrUnauthenticated            = echo.NewHTTPError(http.StatusUnauthorized, "unauthenticated")
-	ErrUnauthorized               = echo.NewHTTPError(http.StatusUnauthorized, "unauthorized")
-	ErrEmailTokenNotMatch         = echo.NewHTTPError(http.StatusUnauthorized, "email or token not match")
-	ErrEmailPasswordNotMatch      = echo.NewHTTPError(http.StatusUnauthorized, "email or password not match")
-	ErrPermissionDenied           = echo.NewHTTPError(http.StatusForbidden, "permission denied")
-	ErrSpaceNotSelected           = echo.NewHTTPError(http.StatusBadRequest, "space not selected")
-	ErrLoginByEmailPasswordLocked = echo.NewHTTPError(http.StatusLocked, "user is locked from logging in using email and password")
-	ErrInvitationExpired          = echo.NewHTTPError(http.StatusBadRequest, "invitation expired")
-	ErrFailedPrecondition         = echo.NewHTTPError(http.StatusPreconditionFailed, "precondition failed")
+	ErrInvalidArgument               = echo.NewHTTPError(http.StatusBadRequest, "invalid argument")
+	ErrNotFound                      = echo.NewHTTPError(http.StatusNotFound, "record not found")
+	ErrInternal                      = echo.NewHTTPError(http.StatusInternalServerError, "internal system error")
+	ErrEntityTooLarge                = echo.NewHTTPError(http.StatusRequestEntityTooLarge, "entity too large")
+	ErrUnauthenticated               = echo.NewHTTPError(http.StatusUnauthorized, "unauthenticated")
+	ErrUnauthorized                  = echo.NewHTTPError(http.StatusUnauthorized, "unauthorized")
+	ErrEmailTokenNotMatch            = echo.NewHTTPError(http.StatusUnauthorized, "email or token not match")
+	ErrEmailPasswordNotMatch         = echo.NewHTTPError(http.StatusUnauthorized, "email or password not match")
+	ErrPermissionDenied              = echo.NewHTTPError(http.StatusForbidden, "permission denied")
+	ErrSpaceNotSelected              = echo.NewHTTPError(http.StatusBadRequest, "space not selected")
+	ErrLoginByEmailPasswordLocked    = echo.NewHTTPError(http.StatusLocked, "user is locked from logging in using email and password")
+	ErrInvitationExpired             = echo.NewHTTPError(http.StatusBadRequest, "invitation expired")
+	ErrFailedPrecondition            = echo.NewHTTPError(http.StatusPreconditionFailed, "precondition failed")
+	ErrPasswordResetTokenInvalid     = echo.NewHTTPError(http.StatusBadRequest, "password reset token is invalid or expired")
+	ErrPasswordNotSet                = echo.NewHTTPError(http.StatusBadRequest, "password is not set, reset the password to set one")
+	ErrOTPInvalid                    = echo.NewHTTPError(http.StatusUnauthorized, "otp is invalid or expired")
+	ErrTooManyRequests               = echo.NewHTTPError(http.StatusTooManyRequests, "too many requests")
+	ErrEmailNotSet                   = echo.NewHTTPError(http.StatusBadRequest, "email is not set")
+	ErrEmailNotVerified              = echo.NewHTTPError(http.StatusForbidden, "email is not verified")
+	ErrEmailAlreadyVerified          = echo.NewHTTPError(http.StatusConflict, "email is already verified")
+	ErrEmailVerificationTokenInvalid = echo.NewHTTPError(http.StatusBadRequest, "email verification token is invalid or expired")
+	ErrMFAChallengeInvalid           = echo.NewHTTPError(http.StatusUnauthorized, "mfa challenge is invalid or expired")
+	ErrMFACodeInvalid                = echo.NewHTTPError(http.StatusUnauthorized, "mfa code is invalid")
+	ErrMFAAlreadyEnabled             = echo.NewHTTPError(http.StatusConflict, "mfa is already enabled")
+	ErrMFANotEnabled                 = echo.NewHTTPError(http.StatusBadRequest, "mfa is not enabled")
+	ErrMFANotEnrolled                = echo.NewHTTPError(http.StatusBadRequest, "mfa is not enrolled")
+	ErrInvalidGeoLocation            = echo.NewHTTPError(http.StatusBadRequest, "latitude or longitude is invalid")
+	ErrOIDCProviderNotFound          = echo.NewHTTPError(http.StatusNotFound, "oidc provider not found")
+	ErrOIDCStateInvalid              = echo.NewHTTPError(http.StatusBadRequest, "oidc state is invalid or expired")
+	ErrOIDCAuthenticationFailed      = echo.NewHTTPError(http.StatusUnauthorized, "oidc authentication failed")
+	ErrOIDCLinkRequired              = echo.NewHTTPError(http.StatusConflict, "email is registered, sign in to link the oidc identity")
+	ErrOIDCIdentityAlreadyLinked     = echo.NewHTTPError(http.StatusConflict, "oidc identity is linked to another candidate")
+	ErrAPIKeyRevoked                 = echo.NewHTTPError(http.StatusBadRequest, "api key is revoked or expired")
 )
 
 // httpValidationOrInternalErr return valdiation or internal error
@@ -39,7 +60,14 @@ func httpValidationOrInternalErr(err error) error {
 		}
 
 		return echo.NewHTTPError(http.StatusBadRequest, utils.Dump(fields))
+	case *usecase.PasswordPolicyError:
+		return echo.NewHTTPError(http.StatusBadRequest, utils.Dump(map[string]interface{}{"password": t.Reasons}))
 	default:
 		return ErrInternal
 	}
 }
+
+// httpFieldErr return http error with the reason of a specific field
+func httpFieldErr(code int, field, reason string) error {
+	return echo.NewHTTPError(code, utils.Dump(map[string]interface{}{field: reason}))
+}
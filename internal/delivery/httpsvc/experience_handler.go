@@ -0,0 +1,133 @@
+package httpsvc
+
+import (
+	"github.com/irvankadhafi/talent-hub-service/internal/delivery"
+	"github.com/irvankadhafi/talent-hub-service/internal/delivery/httpsvc/dto"
+	"github.com/irvankadhafi/talent-hub-service/internal/model"
+	"github.com/irvankadhafi/talent-hub-service/internal/usecase"
+	"github.com/irvankadhafi/talent-hub-service/utils"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"net/http"
+)
+
+func (s *Service) handleGetMyExperiences() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		requester := delivery.GetAuthCandidateFromCtx(ctx)
+
+		pagination := newPagination(c)
+		experiences, count, err := s.experienceUsecase.FindAllByCandidate(ctx, requester, pagination)
+		if err != nil {
+			logrus.Error(err)
+			return ErrInternal
+		}
+
+		res := make([]dto.ExperienceResponse, 0, len(experiences))
+		for _, experience := range experiences {
+			res = append(res, dto.NewExperienceResponse(experience))
+		}
+
+		return c.JSON(http.StatusOK, dto.NewSuccessResponse(dto.NewPaginationResponse(res, pagination.Page, pagination.Size, count), "Success Get Experiences"))
+	}
+}
+
+func (s *Service) handleGetMyExperience() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		requester := delivery.GetAuthCandidateFromCtx(ctx)
+
+		experienceID := utils.StringToInt[int64](c.Param("id"))
+		if experienceID <= 0 {
+			return ErrInvalidArgument
+		}
+
+		experience, err := s.experienceUsecase.FindByID(ctx, requester, experienceID)
+		switch err {
+		case nil:
+			break
+		case usecase.ErrNotFound:
+			return ErrNotFound
+		default:
+			logrus.Error(err)
+			return ErrInternal
+		}
+
+		return c.JSON(http.StatusOK, dto.NewSuccessResponse(dto.NewExperienceResponse(experience), "Success Get Experience"))
+	}
+}
+
+func (s *Service) handleCreateExperience() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := model.ExperienceInput{}
+		if err := c.Bind(&req); err != nil {
+			logrus.Error(err)
+			return ErrInvalidArgument
+		}
+
+		ctx := c.Request().Context()
+		requester := delivery.GetAuthCandidateFromCtx(ctx)
+
+		experience, overlaps, err := s.experienceUsecase.Create(ctx, requester, req)
+		if err != nil {
+			return dateRangeInputErr(err)
+		}
+
+		return c.JSON(http.StatusCreated, dto.NewSuccessResponse(dto.NewExperienceWithOverlapsResponse(experience, overlaps), "Success Create Experience"))
+	}
+}
+
+func (s *Service) handleUpdateExperience() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := model.ExperienceInput{}
+		if err := c.Bind(&req); err != nil {
+			logrus.Error(err)
+			return ErrInvalidArgument
+		}
+
+		ctx := c.Request().Context()
+		requester := delivery.GetAuthCandidateFromCtx(ctx)
+
+		experienceID := utils.StringToInt[int64](c.Param("id"))
+		if experienceID <= 0 {
+			return ErrInvalidArgument
+		}
+
+		experience, overlaps, err := s.experienceUsecase.Update(ctx, requester, experienceID, req)
+		switch err {
+		case nil:
+			break
+		case usecase.ErrNotFound:
+			return ErrNotFound
+		default:
+			return dateRangeInputErr(err)
+		}
+
+		return c.JSON(http.StatusOK, dto.NewSuccessResponse(dto.NewExperienceWithOverlapsResponse(experience, overlaps), "Success Update Experience"))
+	}
+}
+
+func (s *Service) handleDeleteExperience() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		requester := delivery.GetAuthCandidateFromCtx(ctx)
+
+		experienceID := utils.StringToInt[int64](c.Param("id"))
+		if experienceID <= 0 {
+			return ErrInvalidArgument
+		}
+
+		err := s.experienceUsecase.Delete(ctx, requester, experienceID)
+		switch err {
+		case nil:
+			break
+		case usecase.ErrNotFound:
+			return ErrNotFound
+		default:
+			logrus.Error(err)
+			return ErrInternal
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
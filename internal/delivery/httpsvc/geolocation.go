@@ -0,0 +1,51 @@
+package httpsvc
+
+import (
+	"github.com/irvankadhafi/talent-hub-service/internal/helper"
+	"github.com/irvankadhafi/talent-hub-service/internal/model"
+	"github.com/labstack/echo/v4"
+	"gopkg.in/guregu/null.v4"
+)
+
+const (
+	_headerGeoLatitude  = "X-Geo-Latitude"
+	_headerGeoLongitude = "X-Geo-Longitude"
+)
+
+// geoLocationRequest the client's coordinates in the request body, embedded in the login and refresh token request
+type geoLocationRequest struct {
+	Latitude  *float64 `json:"latitude"`
+	Longitude *float64 `json:"longitude"`
+}
+
+// geoLocation get the client's coordinates from the body, or from the `X-Geo-Latitude` and `X-Geo-Longitude` headers
+// when the body has none. Either both or none of the coordinates must be sent.
+func (g geoLocationRequest) geoLocation(c echo.Context) (model.GeoLocation, error) {
+	if g.Latitude == nil && g.Longitude == nil {
+		latitude := c.Request().Header.Get(_headerGeoLatitude)
+		longitude := c.Request().Header.Get(_headerGeoLongitude)
+		if latitude == "" && longitude == "" {
+			return model.GeoLocation{}, nil
+		}
+
+		lat, long, ok := helper.ParseCoordinates(latitude, longitude)
+		if !ok {
+			return model.GeoLocation{}, ErrInvalidGeoLocation
+		}
+
+		return newGeoLocation(lat, long), nil
+	}
+
+	if g.Latitude == nil || g.Longitude == nil || !helper.IsValidCoordinates(*g.Latitude, *g.Longitude) {
+		return model.GeoLocation{}, ErrInvalidGeoLocation
+	}
+
+	return newGeoLocation(*g.Latitude, *g.Longitude), nil
+}
+
+func newGeoLocation(latitude, longitude float64) model.GeoLocation {
+	return model.GeoLocation{
+		Latitude:  null.FloatFrom(latitude),
+		Longitude: null.FloatFrom(longitude),
+	}
+}
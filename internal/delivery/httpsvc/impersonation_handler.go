@@ -0,0 +1,53 @@
+package httpsvc
+
+import (
+	"github.com/irvankadhafi/talent-hub-service/internal/delivery"
+	"github.com/irvankadhafi/talent-hub-service/internal/delivery/httpsvc/dto"
+	"github.com/irvankadhafi/talent-hub-service/internal/model"
+	"github.com/irvankadhafi/talent-hub-service/internal/usecase"
+	"github.com/irvankadhafi/talent-hub-service/utils"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"net/http"
+)
+
+func (s *Service) handleImpersonateCandidate() echo.HandlerFunc {
+	type request struct {
+		Reason string `json:"reason"`
+	}
+
+	return func(c echo.Context) error {
+		req := request{}
+		if err := c.Bind(&req); err != nil {
+			logrus.Error(err)
+			return ErrInvalidArgument
+		}
+
+		ctx := c.Request().Context()
+		requester := delivery.GetAuthCandidateFromCtx(ctx)
+
+		candidateID := utils.StringToInt[int64](c.Param("id"))
+		if candidateID <= 0 {
+			return ErrInvalidArgument
+		}
+
+		session, err := s.authUsecase.Impersonate(ctx, requester, candidateID, model.ImpersonateRequest{
+			Reason:    req.Reason,
+			IPAddress: c.RealIP(),
+			UserAgent: c.Request().UserAgent(),
+		})
+		switch err {
+		case nil:
+			break
+		case usecase.ErrPermissionDenied:
+			return ErrPermissionDenied
+		case usecase.ErrNotFound:
+			return ErrNotFound
+		default:
+			logrus.Error(err)
+			return httpValidationOrInternalErr(err)
+		}
+
+		return c.JSON(http.StatusCreated, dto.NewSuccessResponse(dto.NewLoginResponse(session), "Success Impersonate"))
+	}
+}
@@ -0,0 +1,171 @@
+package httpsvc
+
+import (
+	"github.com/irvankadhafi/talent-hub-service/internal/delivery"
+	"github.com/irvankadhafi/talent-hub-service/internal/delivery/httpsvc/dto"
+	"github.com/irvankadhafi/talent-hub-service/internal/model"
+	"github.com/irvankadhafi/talent-hub-service/internal/usecase"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"net/http"
+)
+
+type mfaCodeRequest struct {
+	Code string `json:"code"` // either the TOTP code or a recovery code
+}
+
+func (s *Service) handleVerifyMFAChallenge() echo.HandlerFunc {
+	type request struct {
+		ChallengeToken string `json:"challenge_token"`
+		Code           string `json:"code"`
+		geoLocationRequest
+	}
+
+	return func(c echo.Context) error {
+		req := request{}
+		if err := c.Bind(&req); err != nil {
+			logrus.Error(err)
+			return ErrInvalidArgument
+		}
+
+		geoLocation, err := req.geoLocation(c)
+		if err != nil {
+			return err
+		}
+
+		session, err := s.authUsecase.VerifyMFAChallenge(c.Request().Context(), model.VerifyMFAChallengeRequest{
+			ChallengeToken: req.ChallengeToken,
+			Code:           req.Code,
+			IPAddress:      c.RealIP(),
+			UserAgent:      c.Request().UserAgent(),
+			GeoLocation:    geoLocation,
+		})
+		switch err {
+		case nil:
+			break
+		case usecase.ErrMFAChallengeInvalid:
+			return ErrMFAChallengeInvalid
+		case usecase.ErrMFACodeInvalid:
+			return ErrMFACodeInvalid
+		case usecase.ErrLoginByEmailPasswordLocked:
+			return ErrLoginByEmailPasswordLocked
+		default:
+			logrus.Error(err)
+			return httpValidationOrInternalErr(err)
+		}
+
+		res, err := newLoginResponse(c, session)
+		if err != nil {
+			logrus.Error(err)
+			return ErrInternal
+		}
+
+		return c.JSON(http.StatusOK, dto.NewSuccessResponse(res, "Success Login"))
+	}
+}
+
+func (s *Service) handleEnrollTOTP() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		requester := delivery.GetAuthCandidateFromCtx(ctx)
+
+		enrollment, err := s.mfaUsecase.EnrollTOTP(ctx, requester)
+		switch err {
+		case nil:
+			break
+		case usecase.ErrMFAAlreadyEnabled:
+			return ErrMFAAlreadyEnabled
+		case usecase.ErrNotFound:
+			return ErrNotFound
+		default:
+			logrus.Error(err)
+			return ErrInternal
+		}
+
+		return c.JSON(http.StatusOK, dto.NewSuccessResponse(dto.NewTOTPEnrollmentResponse(enrollment), "Success Enroll TOTP"))
+	}
+}
+
+func (s *Service) handleConfirmTOTP() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := mfaCodeRequest{}
+		if err := c.Bind(&req); err != nil {
+			logrus.Error(err)
+			return ErrInvalidArgument
+		}
+
+		ctx := c.Request().Context()
+		requester := delivery.GetAuthCandidateFromCtx(ctx)
+
+		codes, err := s.mfaUsecase.ConfirmTOTP(ctx, requester, req.Code)
+		switch err {
+		case nil:
+			break
+		case usecase.ErrMFACodeInvalid:
+			return httpFieldErr(http.StatusBadRequest, "code", "invalid")
+		case usecase.ErrMFAAlreadyEnabled:
+			return ErrMFAAlreadyEnabled
+		case usecase.ErrMFANotEnrolled:
+			return ErrMFANotEnrolled
+		case usecase.ErrNotFound:
+			return ErrNotFound
+		default:
+			logrus.Error(err)
+			return ErrInternal
+		}
+
+		return c.JSON(http.StatusOK, dto.NewSuccessResponse(dto.RecoveryCodesResponse{RecoveryCodes: codes}, "Success Enable TOTP"))
+	}
+}
+
+func (s *Service) handleDisableTOTP() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := mfaCodeRequest{}
+		if err := c.Bind(&req); err != nil {
+			logrus.Error(err)
+			return ErrInvalidArgument
+		}
+
+		ctx := c.Request().Context()
+		requester := delivery.GetAuthCandidateFromCtx(ctx)
+
+		err := s.mfaUsecase.DisableTOTP(ctx, requester, req.Code)
+		switch err {
+		case nil:
+			break
+		case usecase.ErrMFACodeInvalid:
+			return httpFieldErr(http.StatusBadRequest, "code", "invalid")
+		case usecase.ErrMFANotEnabled:
+			return ErrMFANotEnabled
+		case usecase.ErrNotFound:
+			return ErrNotFound
+		default:
+			logrus.Error(err)
+			return ErrInternal
+		}
+
+		return c.JSON(http.StatusOK, dto.NewSuccessResponse[any](nil, "Success Disable TOTP"))
+	}
+}
+
+func (s *Service) handleRegenerateRecoveryCodes() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		requester := delivery.GetAuthCandidateFromCtx(ctx)
+
+		codes, err := s.mfaUsecase.RegenerateRecoveryCodes(ctx, requester)
+		switch err {
+		case nil:
+			break
+		case usecase.ErrMFANotEnabled:
+			return ErrMFANotEnabled
+		case usecase.ErrNotFound:
+			return ErrNotFound
+		default:
+			logrus.Error(err)
+			return ErrInternal
+		}
+
+		return c.JSON(http.StatusOK, dto.NewSuccessResponse(dto.RecoveryCodesResponse{RecoveryCodes: codes}, "Success Regenerate Recovery Codes"))
+	}
+}
@@ -0,0 +1,150 @@
+package httpsvc
+
+import (
+	"github.com/irvankadhafi/talent-hub-service/internal/delivery"
+	"github.com/irvankadhafi/talent-hub-service/internal/delivery/httpsvc/dto"
+	"github.com/irvankadhafi/talent-hub-service/internal/model"
+	"github.com/irvankadhafi/talent-hub-service/internal/usecase"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"net/http"
+)
+
+func (s *Service) handleStartOIDCLogin() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		authorization, err := s.authUsecase.StartOIDCLogin(c.Request().Context(), c.Param("provider"))
+		switch err {
+		case nil:
+			break
+		case usecase.ErrOIDCProviderNotFound:
+			return ErrOIDCProviderNotFound
+		default:
+			logrus.Error(err)
+			return ErrInternal
+		}
+
+		return c.JSON(http.StatusOK, dto.NewSuccessResponse(dto.NewOIDCAuthorizationResponse(authorization), "Success"))
+	}
+}
+
+func (s *Service) handleLoginByOIDC() echo.HandlerFunc {
+	type request struct {
+		Code  string `json:"code"`
+		State string `json:"state"`
+		geoLocationRequest
+	}
+
+	return func(c echo.Context) error {
+		req := request{}
+		if err := c.Bind(&req); err != nil {
+			logrus.Error(err)
+			return ErrInvalidArgument
+		}
+
+		geoLocation, err := req.geoLocation(c)
+		if err != nil {
+			return err
+		}
+
+		session, challenge, err := s.authUsecase.LoginByOIDC(c.Request().Context(), model.OIDCLoginRequest{
+			Provider:    c.Param("provider"),
+			Code:        req.Code,
+			State:       req.State,
+			IPAddress:   c.RealIP(),
+			UserAgent:   c.Request().UserAgent(),
+			GeoLocation: geoLocation,
+		})
+		switch err {
+		case nil:
+			break
+		case usecase.ErrOIDCProviderNotFound:
+			return ErrOIDCProviderNotFound
+		case usecase.ErrOIDCStateInvalid:
+			return ErrOIDCStateInvalid
+		case usecase.ErrOIDCAuthenticationFailed:
+			return ErrOIDCAuthenticationFailed
+		case usecase.ErrDuplicateEmail:
+			return httpFieldErr(http.StatusConflict, "email", "already registered")
+		case usecase.ErrOIDCLinkRequired:
+			return ErrOIDCLinkRequired
+		case usecase.ErrLoginByEmailPasswordLocked:
+			return ErrLoginByEmailPasswordLocked
+		default:
+			logrus.Error(err)
+			return httpValidationOrInternalErr(err)
+		}
+
+		if challenge != nil {
+			return c.JSON(http.StatusOK, dto.NewSuccessResponse(dto.NewMFAChallengeResponse(challenge), "MFA Required"))
+		}
+
+		res, err := newLoginResponse(c, session)
+		if err != nil {
+			logrus.Error(err)
+			return ErrInternal
+		}
+
+		return c.JSON(http.StatusOK, dto.NewSuccessResponse(res, "Success Login"))
+	}
+}
+
+func (s *Service) handleStartOIDCLink() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		requester := delivery.GetAuthCandidateFromCtx(ctx)
+
+		authorization, err := s.authUsecase.StartOIDCLink(ctx, requester, c.Param("provider"))
+		switch err {
+		case nil:
+			break
+		case usecase.ErrOIDCProviderNotFound:
+			return ErrOIDCProviderNotFound
+		default:
+			logrus.Error(err)
+			return ErrInternal
+		}
+
+		return c.JSON(http.StatusOK, dto.NewSuccessResponse(dto.NewOIDCAuthorizationResponse(authorization), "Success"))
+	}
+}
+
+func (s *Service) handleLinkOIDCIdentity() echo.HandlerFunc {
+	type request struct {
+		Code  string `json:"code"`
+		State string `json:"state"`
+	}
+
+	return func(c echo.Context) error {
+		req := request{}
+		if err := c.Bind(&req); err != nil {
+			logrus.Error(err)
+			return ErrInvalidArgument
+		}
+
+		ctx := c.Request().Context()
+		requester := delivery.GetAuthCandidateFromCtx(ctx)
+
+		err := s.authUsecase.LinkOIDCIdentity(ctx, requester, model.OIDCLinkRequest{
+			Provider: c.Param("provider"),
+			Code:     req.Code,
+			State:    req.State,
+		})
+		switch err {
+		case nil:
+			break
+		case usecase.ErrOIDCProviderNotFound:
+			return ErrOIDCProviderNotFound
+		case usecase.ErrOIDCStateInvalid:
+			return ErrOIDCStateInvalid
+		case usecase.ErrOIDCAuthenticationFailed:
+			return ErrOIDCAuthenticationFailed
+		case usecase.ErrOIDCIdentityAlreadyLinked:
+			return ErrOIDCIdentityAlreadyLinked
+		default:
+			logrus.Error(err)
+			return httpValidationOrInternalErr(err)
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,100 @@
+package httpsvc
+
+import (
+	"github.com/irvankadhafi/talent-hub-service/internal/delivery"
+	"github.com/irvankadhafi/talent-hub-service/internal/delivery/httpsvc/dto"
+	"github.com/irvankadhafi/talent-hub-service/internal/usecase"
+	"github.com/irvankadhafi/talent-hub-service/utils"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"net/http"
+)
+
+func (s *Service) handleGetPartnerCandidate() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		requester := delivery.GetAuthCandidateFromCtx(ctx)
+
+		candidateID := utils.StringToInt[int64](c.Param("id"))
+		if candidateID <= 0 {
+			return ErrInvalidArgument
+		}
+
+		candidate, err := s.candidateUsecase.FindByIDForPartner(ctx, requester, candidateID)
+		switch err {
+		case nil:
+			break
+		case usecase.ErrNotFound:
+			return ErrNotFound
+		case usecase.ErrPermissionDenied:
+			return ErrPermissionDenied
+		default:
+			logrus.Error(err)
+			return ErrInternal
+		}
+
+		return c.JSON(http.StatusOK, dto.NewSuccessResponse(dto.NewPartnerCandidateResponse(candidate), "Success Get Candidate"))
+	}
+}
+
+func (s *Service) handleGetPartnerCandidateEducations() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		requester := delivery.GetAuthCandidateFromCtx(ctx)
+
+		candidateID := utils.StringToInt[int64](c.Param("id"))
+		if candidateID <= 0 {
+			return ErrInvalidArgument
+		}
+
+		pagination := newPagination(c)
+		educations, count, err := s.educationUsecase.FindAllByCandidateIDForPartner(ctx, requester, candidateID, pagination)
+		switch err {
+		case nil:
+			break
+		case usecase.ErrPermissionDenied:
+			return ErrPermissionDenied
+		default:
+			logrus.Error(err)
+			return ErrInternal
+		}
+
+		res := make([]dto.EducationResponse, 0, len(educations))
+		for _, education := range educations {
+			res = append(res, dto.NewEducationResponse(education))
+		}
+
+		return c.JSON(http.StatusOK, dto.NewSuccessResponse(dto.NewPaginationResponse(res, pagination.Page, pagination.Size, count), "Success Get Educations"))
+	}
+}
+
+func (s *Service) handleGetPartnerCandidateExperiences() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		requester := delivery.GetAuthCandidateFromCtx(ctx)
+
+		candidateID := utils.StringToInt[int64](c.Param("id"))
+		if candidateID <= 0 {
+			return ErrInvalidArgument
+		}
+
+		pagination := newPagination(c)
+		experiences, count, err := s.experienceUsecase.FindAllByCandidateIDForPartner(ctx, requester, candidateID, pagination)
+		switch err {
+		case nil:
+			break
+		case usecase.ErrPermissionDenied:
+			return ErrPermissionDenied
+		default:
+			logrus.Error(err)
+			return ErrInternal
+		}
+
+		res := make([]dto.ExperienceResponse, 0, len(experiences))
+		for _, experience := range experiences {
+			res = append(res, dto.NewExperienceResponse(experience))
+		}
+
+		return c.JSON(http.StatusOK, dto.NewSuccessResponse(dto.NewPaginationResponse(res, pagination.Page, pagination.Size, count), "Success Get Experiences"))
+	}
+}
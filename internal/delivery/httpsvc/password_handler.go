@@ -0,0 +1,87 @@
+package httpsvc
+
+import (
+	"github.com/irvankadhafi/talent-hub-service/internal/delivery"
+	"github.com/irvankadhafi/talent-hub-service/internal/delivery/httpsvc/dto"
+	"github.com/irvankadhafi/talent-hub-service/internal/model"
+	"github.com/irvankadhafi/talent-hub-service/internal/usecase"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"net/http"
+)
+
+func (s *Service) handleForgotPassword() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := model.ForgotPasswordInput{}
+		if err := c.Bind(&req); err != nil {
+			logrus.Error(err)
+			return ErrInvalidArgument
+		}
+
+		err := s.passwordUsecase.ForgotPassword(c.Request().Context(), req)
+		switch err {
+		case nil:
+			break
+		case usecase.ErrTooManyRequests:
+			return ErrTooManyRequests
+		default:
+			logrus.Error(err)
+			return httpValidationOrInternalErr(err)
+		}
+
+		return c.JSON(http.StatusOK, dto.NewSuccessResponse[any](nil, "If the email is registered, a password reset link has been sent"))
+	}
+}
+
+func (s *Service) handleResetPassword() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := model.ResetPasswordInput{}
+		if err := c.Bind(&req); err != nil {
+			logrus.Error(err)
+			return ErrInvalidArgument
+		}
+
+		err := s.passwordUsecase.ResetPassword(c.Request().Context(), req)
+		switch err {
+		case nil:
+			break
+		case usecase.ErrPasswordResetTokenInvalid:
+			return ErrPasswordResetTokenInvalid
+		default:
+			logrus.Error(err)
+			return httpValidationOrInternalErr(err)
+		}
+
+		return c.JSON(http.StatusOK, dto.NewSuccessResponse[any](nil, "Success Reset Password"))
+	}
+}
+
+func (s *Service) handleChangePassword() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := model.ChangePasswordInput{}
+		if err := c.Bind(&req); err != nil {
+			logrus.Error(err)
+			return ErrInvalidArgument
+		}
+
+		ctx := c.Request().Context()
+		requester := delivery.GetAuthCandidateFromCtx(ctx)
+
+		err := s.passwordUsecase.ChangePassword(ctx, requester, req)
+		switch err {
+		case nil:
+			break
+		case usecase.ErrCurrentPasswordNotMatch:
+			return httpFieldErr(http.StatusBadRequest, "current_password", "not match")
+		case usecase.ErrPermissionDenied:
+			return ErrPermissionDenied
+		case usecase.ErrNotFound:
+			return ErrNotFound
+		default:
+			logrus.Error(err)
+			return httpValidationOrInternalErr(err)
+		}
+
+		return c.JSON(http.StatusOK, dto.NewSuccessResponse[any](nil, "Success Change Password"))
+	}
+}
@@ -0,0 +1,107 @@
+package httpsvc
+
+import (
+	"github.com/irvankadhafi/talent-hub-service/internal/delivery"
+	"github.com/irvankadhafi/talent-hub-service/internal/delivery/httpsvc/dto"
+	"github.com/irvankadhafi/talent-hub-service/internal/model"
+	"github.com/irvankadhafi/talent-hub-service/internal/usecase"
+	"github.com/irvankadhafi/talent-hub-service/utils"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"net/http"
+)
+
+func (s *Service) handleGetCandidateRoles() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		requester := delivery.GetAuthCandidateFromCtx(ctx)
+
+		candidateID := utils.StringToInt[int64](c.Param("id"))
+		if candidateID <= 0 {
+			return ErrInvalidArgument
+		}
+
+		roles, err := s.roleUsecase.FindAllByCandidateID(ctx, requester, candidateID)
+		switch err {
+		case nil:
+			break
+		case usecase.ErrPermissionDenied:
+			return ErrPermissionDenied
+		case usecase.ErrNotFound:
+			return ErrNotFound
+		default:
+			logrus.Error(err)
+			return ErrInternal
+		}
+
+		return c.JSON(http.StatusOK, dto.NewSuccessResponse(dto.RolesResponse{Roles: roles}, "Success Get Roles"))
+	}
+}
+
+func (s *Service) handleAssignCandidateRole() echo.HandlerFunc {
+	type request struct {
+		Role model.Role `json:"role"`
+	}
+
+	return func(c echo.Context) error {
+		req := request{}
+		if err := c.Bind(&req); err != nil {
+			logrus.Error(err)
+			return ErrInvalidArgument
+		}
+
+		ctx := c.Request().Context()
+		requester := delivery.GetAuthCandidateFromCtx(ctx)
+
+		candidateID := utils.StringToInt[int64](c.Param("id"))
+		if candidateID <= 0 {
+			return ErrInvalidArgument
+		}
+
+		err := s.roleUsecase.Assign(ctx, requester, candidateID, req.Role)
+		switch err {
+		case nil:
+			break
+		case usecase.ErrInvalidRole:
+			return httpFieldErr(http.StatusBadRequest, "role", "invalid")
+		case usecase.ErrPermissionDenied:
+			return ErrPermissionDenied
+		case usecase.ErrNotFound:
+			return ErrNotFound
+		default:
+			logrus.Error(err)
+			return ErrInternal
+		}
+
+		return c.JSON(http.StatusOK, dto.NewSuccessResponse[any](nil, "Success Assign Role"))
+	}
+}
+
+func (s *Service) handleRevokeCandidateRole() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		requester := delivery.GetAuthCandidateFromCtx(ctx)
+
+		candidateID := utils.StringToInt[int64](c.Param("id"))
+		if candidateID <= 0 {
+			return ErrInvalidArgument
+		}
+
+		err := s.roleUsecase.Revoke(ctx, requester, candidateID, model.Role(c.Param("role")))
+		switch err {
+		case nil:
+			break
+		case usecase.ErrInvalidRole:
+			return httpFieldErr(http.StatusBadRequest, "role", "invalid")
+		case usecase.ErrPermissionDenied:
+			return ErrPermissionDenied
+		case usecase.ErrNotFound:
+			return ErrNotFound
+		default:
+			logrus.Error(err)
+			return ErrInternal
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
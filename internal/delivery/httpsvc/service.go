@@ -8,27 +8,117 @@ import (
 
 // Service http service
 type Service struct {
-	group          *echo.Group
-	authUsecase    model.AuthUsecase
-	authMiddleware *auth.AuthenticationMiddleware
+	group                    *echo.Group
+	authUsecase              model.AuthUsecase
+	candidateUsecase         model.CandidateUsecase
+	passwordUsecase          model.PasswordUsecase
+	emailVerificationUsecase model.EmailVerificationUsecase
+	mfaUsecase               model.MFAUsecase
+	roleUsecase              model.RoleUsecase
+	authEventUsecase         model.AuthEventUsecase
+	apiKeyUsecase            model.APIKeyUsecase
+	educationUsecase         model.EducationUsecase
+	experienceUsecase        model.ExperienceUsecase
+	jwtKeySet                *auth.JWTKeySet
+	authMiddleware           *auth.AuthenticationMiddleware
 }
 
 // RouteService add dependencies and use group for routing
 func RouteService(
 	group *echo.Group,
 	authUSecase model.AuthUsecase,
+	candidateUsecase model.CandidateUsecase,
+	passwordUsecase model.PasswordUsecase,
+	emailVerificationUsecase model.EmailVerificationUsecase,
+	mfaUsecase model.MFAUsecase,
+	roleUsecase model.RoleUsecase,
+	authEventUsecase model.AuthEventUsecase,
+	apiKeyUsecase model.APIKeyUsecase,
+	educationUsecase model.EducationUsecase,
+	experienceUsecase model.ExperienceUsecase,
+	jwtKeySet *auth.JWTKeySet,
 	authMiddleware *auth.AuthenticationMiddleware,
 ) {
 	srv := &Service{
-		group:          group,
-		authUsecase:    authUSecase,
-		authMiddleware: authMiddleware,
+		group:                    group,
+		authUsecase:              authUSecase,
+		candidateUsecase:         candidateUsecase,
+		passwordUsecase:          passwordUsecase,
+		emailVerificationUsecase: emailVerificationUsecase,
+		mfaUsecase:               mfaUsecase,
+		roleUsecase:              roleUsecase,
+		authEventUsecase:         authEventUsecase,
+		apiKeyUsecase:            apiKeyUsecase,
+		educationUsecase:         educationUsecase,
+		experienceUsecase:        experienceUsecase,
+		jwtKeySet:                jwtKeySet,
+		authMiddleware:           authMiddleware,
 	}
 	srv.initRoutes()
 }
 
 func (s *Service) initRoutes() {
+	s.group.POST("/auth/register/", s.handleRegister())
 	s.group.POST("/auth/login/", s.handleLoginByIdentifierPassword())
+	s.group.POST("/auth/otp/request/", s.handleRequestLoginOTP())
+	s.group.POST("/auth/otp/login/", s.handleLoginByOTP())
 	s.group.POST("/auth/tokens/refresh/", s.handleRefreshToken())
-	s.group.POST("/auth/logout/", s.handleLogout(), s.authMiddleware.MustAuthenticateAccessToken())
+	s.group.GET("/auth/jwks/", s.handleGetJWKS())
+	s.group.POST("/auth/logout/", s.handleLogout(), s.authMiddleware.MustAuthenticateAccessToken(), s.authMiddleware.RejectAPIKey())
+
+	s.group.POST("/auth/oidc/:provider/authorize/", s.handleStartOIDCLogin())
+	s.group.POST("/auth/oidc/:provider/login/", s.handleLoginByOIDC())
+	s.group.POST("/me/identities/oidc/:provider/authorize/", s.handleStartOIDCLink(), s.authMiddleware.MustAuthenticateAccessToken(), s.authMiddleware.RejectAPIKey(), s.authMiddleware.RejectImpersonation())
+	s.group.POST("/me/identities/oidc/:provider/link/", s.handleLinkOIDCIdentity(), s.authMiddleware.MustAuthenticateAccessToken(), s.authMiddleware.RejectAPIKey(), s.authMiddleware.RejectImpersonation())
+
+	s.group.POST("/auth/password/forgot/", s.handleForgotPassword())
+	s.group.POST("/auth/password/reset/", s.handleResetPassword())
+
+	s.group.POST("/auth/email/verify/", s.handleVerifyEmail())
+
+	s.group.POST("/auth/mfa/verify/", s.handleVerifyMFAChallenge())
+	s.group.POST("/me/mfa/totp/", s.handleEnrollTOTP(), s.authMiddleware.MustAuthenticateAccessToken(), s.authMiddleware.RejectAPIKey(), s.authMiddleware.RejectImpersonation())
+	s.group.POST("/me/mfa/totp/confirm/", s.handleConfirmTOTP(), s.authMiddleware.MustAuthenticateAccessToken(), s.authMiddleware.RejectAPIKey(), s.authMiddleware.RejectImpersonation())
+	s.group.POST("/me/mfa/totp/disable/", s.handleDisableTOTP(), s.authMiddleware.MustAuthenticateAccessToken(), s.authMiddleware.RejectAPIKey(), s.authMiddleware.RejectImpersonation(), s.authMiddleware.RequireMFA())
+	s.group.POST("/me/mfa/recovery-codes/", s.handleRegenerateRecoveryCodes(), s.authMiddleware.MustAuthenticateAccessToken(), s.authMiddleware.RejectAPIKey(), s.authMiddleware.RejectImpersonation(), s.authMiddleware.RequireMFA())
+
+	s.group.GET("/me/", s.handleGetMe(), s.authMiddleware.MustAuthenticateAccessToken(), s.authMiddleware.RejectAPIKey())
+	s.group.PATCH("/me/", s.handleUpdateMe(), s.authMiddleware.MustAuthenticateAccessToken(), s.authMiddleware.RejectAPIKey())
+	s.group.PUT("/me/password/", s.handleChangePassword(), s.authMiddleware.MustAuthenticateAccessToken(), s.authMiddleware.RejectAPIKey(), s.authMiddleware.RejectImpersonation())
+	s.group.PUT("/me/email/", s.handleChangeEmail(), s.authMiddleware.MustAuthenticateAccessToken(), s.authMiddleware.RejectAPIKey(), s.authMiddleware.RejectImpersonation())
+	s.group.POST("/me/email/verification/", s.handleSendEmailVerification(), s.authMiddleware.MustAuthenticateAccessToken(), s.authMiddleware.RejectAPIKey())
+
+	s.group.GET("/me/educations/", s.handleGetMyEducations(), s.authMiddleware.MustAuthenticateAccessToken(), s.authMiddleware.RejectAPIKey())
+	s.group.POST("/me/educations/", s.handleCreateEducation(), s.authMiddleware.MustAuthenticateAccessToken(), s.authMiddleware.RejectAPIKey())
+	s.group.GET("/me/educations/:id/", s.handleGetMyEducation(), s.authMiddleware.MustAuthenticateAccessToken(), s.authMiddleware.RejectAPIKey())
+	s.group.PUT("/me/educations/:id/", s.handleUpdateEducation(), s.authMiddleware.MustAuthenticateAccessToken(), s.authMiddleware.RejectAPIKey())
+	s.group.DELETE("/me/educations/:id/", s.handleDeleteEducation(), s.authMiddleware.MustAuthenticateAccessToken(), s.authMiddleware.RejectAPIKey())
+
+	s.group.GET("/me/experiences/", s.handleGetMyExperiences(), s.authMiddleware.MustAuthenticateAccessToken(), s.authMiddleware.RejectAPIKey())
+	s.group.POST("/me/experiences/", s.handleCreateExperience(), s.authMiddleware.MustAuthenticateAccessToken(), s.authMiddleware.RejectAPIKey())
+	s.group.GET("/me/experiences/:id/", s.handleGetMyExperience(), s.authMiddleware.MustAuthenticateAccessToken(), s.authMiddleware.RejectAPIKey())
+	s.group.PUT("/me/experiences/:id/", s.handleUpdateExperience(), s.authMiddleware.MustAuthenticateAccessToken(), s.authMiddleware.RejectAPIKey())
+	s.group.DELETE("/me/experiences/:id/", s.handleDeleteExperience(), s.authMiddleware.MustAuthenticateAccessToken(), s.authMiddleware.RejectAPIKey())
+
+	// the partner endpoints are accessible with the api key granted the scope
+	s.group.GET("/partner/candidates/:id/", s.handleGetPartnerCandidate(), s.authMiddleware.MustAuthenticateAccessToken(), s.authMiddleware.RequirePermission(model.PermissionCandidateRead))
+	s.group.GET("/partner/candidates/:id/educations/", s.handleGetPartnerCandidateEducations(), s.authMiddleware.MustAuthenticateAccessToken(), s.authMiddleware.RequirePermission(model.PermissionCandidateRead))
+	s.group.GET("/partner/candidates/:id/experiences/", s.handleGetPartnerCandidateExperiences(), s.authMiddleware.MustAuthenticateAccessToken(), s.authMiddleware.RequirePermission(model.PermissionCandidateRead))
+
+	s.group.GET("/auth/sessions/", s.handleGetSessions(), s.authMiddleware.MustAuthenticateAccessToken(), s.authMiddleware.RejectAPIKey())
+	s.group.DELETE("/auth/sessions/", s.handleRevokeOtherSessions(), s.authMiddleware.MustAuthenticateAccessToken(), s.authMiddleware.RejectAPIKey(), s.authMiddleware.RejectImpersonation())
+	s.group.DELETE("/auth/sessions/:id/", s.handleRevokeSession(), s.authMiddleware.MustAuthenticateAccessToken(), s.authMiddleware.RejectAPIKey(), s.authMiddleware.RejectImpersonation())
+
+	s.group.GET("/me/auth-events/", s.handleGetMyAuthEvents(), s.authMiddleware.MustAuthenticateAccessToken(), s.authMiddleware.RejectAPIKey())
+	s.group.GET("/admin/auth-events/", s.handleGetAuthEvents(), s.authMiddleware.MustAuthenticateAccessToken(), s.authMiddleware.RequirePermission(model.PermissionAuditRead))
+
+	s.group.GET("/admin/candidates/:id/roles/", s.handleGetCandidateRoles(), s.authMiddleware.MustAuthenticateAccessToken(), s.authMiddleware.RejectImpersonation(), s.authMiddleware.RequirePermission(model.PermissionRoleManage))
+	s.group.POST("/admin/candidates/:id/roles/", s.handleAssignCandidateRole(), s.authMiddleware.MustAuthenticateAccessToken(), s.authMiddleware.RejectImpersonation(), s.authMiddleware.RequirePermission(model.PermissionRoleManage))
+	s.group.DELETE("/admin/candidates/:id/roles/:role/", s.handleRevokeCandidateRole(), s.authMiddleware.MustAuthenticateAccessToken(), s.authMiddleware.RejectImpersonation(), s.authMiddleware.RequirePermission(model.PermissionRoleManage))
+	s.group.POST("/admin/candidates/:id/impersonate/", s.handleImpersonateCandidate(), s.authMiddleware.MustAuthenticateAccessToken(), s.authMiddleware.RejectImpersonation(), s.authMiddleware.RequirePermission(model.PermissionCandidateImpersonate))
+
+	s.group.GET("/admin/api-keys/", s.handleGetAPIKeys(), s.authMiddleware.MustAuthenticateAccessToken(), s.authMiddleware.RequirePermission(model.PermissionAPIKeyManage))
+	s.group.POST("/admin/api-keys/", s.handleCreateAPIKey(), s.authMiddleware.MustAuthenticateAccessToken(), s.authMiddleware.RejectImpersonation(), s.authMiddleware.RequirePermission(model.PermissionAPIKeyManage))
+	s.group.POST("/admin/api-keys/:id/rotate/", s.handleRotateAPIKey(), s.authMiddleware.MustAuthenticateAccessToken(), s.authMiddleware.RejectImpersonation(), s.authMiddleware.RequirePermission(model.PermissionAPIKeyManage))
+	s.group.DELETE("/admin/api-keys/:id/", s.handleRevokeAPIKey(), s.authMiddleware.MustAuthenticateAccessToken(), s.authMiddleware.RejectImpersonation(), s.authMiddleware.RequirePermission(model.PermissionAPIKeyManage))
 }
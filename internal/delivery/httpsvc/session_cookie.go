@@ -0,0 +1,105 @@
+package httpsvc
+
+import (
+	"github.com/irvankadhafi/talent-hub-service/auth"
+	"github.com/irvankadhafi/talent-hub-service/internal/config"
+	"github.com/irvankadhafi/talent-hub-service/internal/delivery/httpsvc/dto"
+	"github.com/irvankadhafi/talent-hub-service/internal/model"
+	"github.com/irvankadhafi/talent-hub-service/utils"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	headerSessionMode = "X-Session-Mode"
+	sessionModeCookie = "cookie"
+	tokenTypeCookie   = "Cookie"
+	csrfTokenLength   = 32
+	sessionCookiePath = "/"
+)
+
+// isCookieSessionMode check whether the browser client asks the session to be set on the HttpOnly cookies,
+// so the tokens are not accessible from the javascript
+func isCookieSessionMode(c echo.Context) bool {
+	return config.SessionCookieEnabled() && strings.EqualFold(c.Request().Header.Get(headerSessionMode), sessionModeCookie)
+}
+
+// newLoginResponse creates the login response from the session.
+// On the cookie session mode, the tokens are set on the HttpOnly cookies instead of the response body,
+// along with the csrf token cookie to be submitted on the `X-CSRF-Token` header.
+func newLoginResponse(c echo.Context, session *model.Session) (dto.LoginResponse, error) {
+	res := dto.NewLoginResponse(session)
+	if !isCookieSessionMode(c) {
+		return res, nil
+	}
+
+	csrfToken, err := utils.GenerateRandomStringURLSafe(csrfTokenLength)
+	if err != nil {
+		return dto.LoginResponse{}, err
+	}
+
+	c.SetCookie(newSessionCookie(auth.AccessTokenCookieName, session.AccessToken, sessionCookiePath, session.AccessTokenExpiredAt, true))
+	c.SetCookie(newSessionCookie(auth.RefreshTokenCookieName, session.RefreshToken, config.SessionCookieRefreshTokenPath(), session.RefreshTokenExpiredAt, true))
+	// the csrf token must be readable by the javascript, so it can be submitted on the header
+	c.SetCookie(newSessionCookie(auth.CSRFTokenCookieName, csrfToken, sessionCookiePath, session.RefreshTokenExpiredAt, false))
+
+	res.AccessToken = ""
+	res.RefreshToken = ""
+	res.TokenType = tokenTypeCookie
+	res.CSRFToken = csrfToken
+
+	return res, nil
+}
+
+// clearSessionCookies expires the session cookies
+func clearSessionCookies(c echo.Context) {
+	c.SetCookie(newSessionCookie(auth.AccessTokenCookieName, "", sessionCookiePath, time.Unix(0, 0), true))
+	c.SetCookie(newSessionCookie(auth.RefreshTokenCookieName, "", config.SessionCookieRefreshTokenPath(), time.Unix(0, 0), true))
+	c.SetCookie(newSessionCookie(auth.CSRFTokenCookieName, "", sessionCookiePath, time.Unix(0, 0), false))
+}
+
+// getRefreshTokenCookie get the refresh token from the cookie on the cookie session mode
+func getRefreshTokenCookie(c echo.Context) string {
+	if !isCookieSessionMode(c) {
+		return ""
+	}
+
+	cookie, err := c.Cookie(auth.RefreshTokenCookieName)
+	if err != nil {
+		return ""
+	}
+
+	return cookie.Value
+}
+
+func newSessionCookie(name, value, path string, expiredAt time.Time, httpOnly bool) *http.Cookie {
+	cookie := &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     path,
+		Domain:   config.SessionCookieDomain(),
+		Expires:  expiredAt,
+		Secure:   config.SessionCookieSecure(),
+		HttpOnly: httpOnly,
+		SameSite: sessionCookieSameSite(),
+	}
+
+	if value == "" {
+		cookie.MaxAge = -1
+	}
+
+	return cookie
+}
+
+func sessionCookieSameSite() http.SameSite {
+	switch strings.ToLower(config.SessionCookieSameSite()) {
+	case "lax":
+		return http.SameSiteLaxMode
+	case "none":
+		return http.SameSiteNoneMode
+	default:
+		return http.SameSiteStrictMode
+	}
+}
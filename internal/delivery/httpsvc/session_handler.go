@@ -0,0 +1,79 @@
+package httpsvc
+
+import (
+	"github.com/irvankadhafi/talent-hub-service/internal/delivery"
+	"github.com/irvankadhafi/talent-hub-service/internal/delivery/httpsvc/dto"
+	"github.com/irvankadhafi/talent-hub-service/internal/model"
+	"github.com/irvankadhafi/talent-hub-service/internal/usecase"
+	"github.com/irvankadhafi/talent-hub-service/utils"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"net/http"
+)
+
+func (s *Service) handleGetSessions() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		requester := delivery.GetAuthCandidateFromCtx(ctx)
+
+		sessions, err := s.authUsecase.FindAllSessions(ctx, requester)
+		if err != nil {
+			logrus.Error(err)
+			return ErrInternal
+		}
+
+		res := make([]dto.SessionResponse, 0, len(sessions))
+		for _, session := range sessions {
+			res = append(res, dto.NewSessionResponse(session, requester.SessionID))
+		}
+
+		return c.JSON(http.StatusOK, dto.NewSuccessResponse(res, "Success Get Sessions"))
+	}
+}
+
+func (s *Service) handleRevokeSession() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		requester := delivery.GetAuthCandidateFromCtx(ctx)
+
+		sessionID := utils.StringToInt[int64](c.Param("id"))
+		if sessionID <= 0 {
+			return ErrInvalidArgument
+		}
+
+		err := s.authUsecase.RevokeSession(ctx, requester, sessionID, newClientInfo(c))
+		switch err {
+		case nil:
+			break
+		case usecase.ErrNotFound:
+			return ErrNotFound
+		default:
+			logrus.Error(err)
+			return ErrInternal
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+func (s *Service) handleRevokeOtherSessions() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		requester := delivery.GetAuthCandidateFromCtx(ctx)
+
+		if err := s.authUsecase.RevokeOtherSessions(ctx, requester, newClientInfo(c)); err != nil {
+			logrus.Error(err)
+			return ErrInternal
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// newClientInfo get the client performing the request
+func newClientInfo(c echo.Context) model.ClientInfo {
+	return model.ClientInfo{
+		UserAgent: c.Request().UserAgent(),
+		IPAddress: c.RealIP(),
+	}
+}
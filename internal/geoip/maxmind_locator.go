@@ -0,0 +1,54 @@
+package geoip
+
+import (
+	"github.com/irvankadhafi/talent-hub-service/internal/model"
+	"github.com/oschwald/geoip2-golang"
+	"gopkg.in/guregu/null.v4"
+	"net"
+)
+
+// MaxMindLocator resolves the coarse location of the ip address from the offline MaxMind city database
+type MaxMindLocator struct {
+	reader *geoip2.Reader
+}
+
+// NewMaxMindLocator open the MaxMind city database file, e.g. GeoLite2-City.mmdb
+func NewMaxMindLocator(filePath string) (*MaxMindLocator, error) {
+	reader, err := geoip2.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MaxMindLocator{
+		reader: reader,
+	}, nil
+}
+
+// Locate find the location of the ip address, the location is unknown for the private or unlisted address
+func (m *MaxMindLocator) Locate(ipAddress string) (model.GeoLocation, error) {
+	ip := net.ParseIP(ipAddress)
+	if ip == nil || ip.IsPrivate() || ip.IsLoopback() {
+		return model.GeoLocation{}, nil
+	}
+
+	city, err := m.reader.City(ip)
+	if err != nil {
+		return model.GeoLocation{}, err
+	}
+
+	// the record without the location is decoded as zero coordinates
+	location := city.Location
+	if location.AccuracyRadius == 0 && location.Latitude == 0 && location.Longitude == 0 {
+		return model.GeoLocation{}, nil
+	}
+
+	return model.GeoLocation{
+		Latitude:  null.FloatFrom(location.Latitude),
+		Longitude: null.FloatFrom(location.Longitude),
+	}, nil
+}
+
+// Close close the database file
+func (m *MaxMindLocator) Close() error {
+	return m.reader.Close()
+}
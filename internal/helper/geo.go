@@ -0,0 +1,55 @@
+package helper
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const earthRadiusKm = 6371.0
+
+var versionRegex = regexp.MustCompile(`\d+([._]\d+)*`)
+
+// DeviceFingerprint hash the user agent without the version numbers,
+// so the browser or OS update is not treated as a new device
+func DeviceFingerprint(userAgent string) string {
+	normalized := strings.ToLower(strings.TrimSpace(userAgent))
+	normalized = versionRegex.ReplaceAllString(normalized, "")
+	return HashToken(normalized)
+}
+
+// ParseCoordinates parse the latitude and longitude, return false when either is empty, malformed or out of range
+func ParseCoordinates(latitude, longitude string) (float64, float64, bool) {
+	lat, err := strconv.ParseFloat(strings.TrimSpace(latitude), 64)
+	if err != nil {
+		return 0, 0, false
+	}
+
+	long, err := strconv.ParseFloat(strings.TrimSpace(longitude), 64)
+	if err != nil {
+		return 0, 0, false
+	}
+
+	return lat, long, IsValidCoordinates(lat, long)
+}
+
+// IsValidCoordinates check the latitude and longitude are in range
+func IsValidCoordinates(latitude, longitude float64) bool {
+	return latitude >= -90 && latitude <= 90 && longitude >= -180 && longitude <= 180
+}
+
+// HaversineDistance get the great-circle distance in kilometers between two coordinates
+func HaversineDistance(lat1, long1, lat2, long2 float64) float64 {
+	dLat := toRadians(lat2 - lat1)
+	dLong := toRadians(long2 - long1)
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRadians(lat1))*math.Cos(toRadians(lat2))*math.Sin(dLong/2)*math.Sin(dLong/2)
+
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(a))
+}
+
+func toRadians(degree float64) float64 {
+	return degree * math.Pi / 180
+}
@@ -0,0 +1,69 @@
+package helper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHelper_DeviceFingerprint(t *testing.T) {
+	var (
+		chrome119 = "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/119.0.0.0 Safari/537.36"
+		chrome120 = "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.6099.71 Safari/537.36"
+		firefox   = "Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:121.0) Gecko/20100101 Firefox/121.0"
+	)
+
+	t.Run("ignore version", func(t *testing.T) {
+		require.Equal(t, DeviceFingerprint(chrome119), DeviceFingerprint(chrome120))
+	})
+
+	t.Run("different device", func(t *testing.T) {
+		require.NotEqual(t, DeviceFingerprint(chrome119), DeviceFingerprint(firefox))
+	})
+}
+
+func TestHelper_ParseCoordinates(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		lat, long, ok := ParseCoordinates("-6.2088", " 106.8456 ")
+		require.True(t, ok)
+		require.Equal(t, -6.2088, lat)
+		require.Equal(t, 106.8456, long)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, _, ok := ParseCoordinates("", "106.8456")
+		require.False(t, ok)
+
+		_, _, ok = ParseCoordinates("-6.2088", "abc")
+		require.False(t, ok)
+
+		_, _, ok = ParseCoordinates("91", "106.8456")
+		require.False(t, ok)
+
+		_, _, ok = ParseCoordinates("-6.2088", "181")
+		require.False(t, ok)
+	})
+}
+
+func TestHelper_IsValidCoordinates(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		require.True(t, IsValidCoordinates(-90, -180))
+		require.True(t, IsValidCoordinates(90, 180))
+	})
+
+	t.Run("out of range", func(t *testing.T) {
+		require.False(t, IsValidCoordinates(-90.1, 0))
+		require.False(t, IsValidCoordinates(0, 180.1))
+	})
+}
+
+func TestHelper_HaversineDistance(t *testing.T) {
+	t.Run("same coordinates", func(t *testing.T) {
+		require.Zero(t, HaversineDistance(-6.2088, 106.8456, -6.2088, 106.8456))
+	})
+
+	t.Run("jakarta to singapore", func(t *testing.T) {
+		distance := HaversineDistance(-6.2088, 106.8456, 1.3521, 103.8198)
+		require.InDelta(t, 905, distance, 1)
+	})
+}
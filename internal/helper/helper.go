@@ -1,10 +1,10 @@
 package helper
 
 import (
-	"errors"
+	"crypto/sha256"
+	"encoding/hex"
 	"github.com/sirupsen/logrus"
 	"github.com/ttacon/libphonenumber"
-	"golang.org/x/crypto/bcrypt"
 	"regexp"
 	"strings"
 )
@@ -50,27 +50,10 @@ func FormatPhoneNumberWithCountryCode(phone *string, countryCode string) error {
 	return nil
 }
 
-// HashString encrypt given text
-func HashString(text string) (string, error) {
-	bt, err := bcrypt.GenerateFromPassword([]byte(text), bcrypt.DefaultCost)
-	if err != nil {
-		return "", err
-	}
-	return string(bt), nil
-}
-
-// IsHashedStringMatch check the plain against the cipher using bcrypt.
-// If they don't match, will return false
-func IsHashedStringMatch(plain, cipher []byte) bool {
-	err := bcrypt.CompareHashAndPassword(cipher, plain)
-	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
-		return false
-	}
-	if err != nil {
-		logrus.Error(err)
-		return false
-	}
-	return true
+// HashToken hash the token using sha256, used to store token which needs to be looked up by its value
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
 }
 
 // FormatEmail converts email string to lower case
@@ -19,3 +19,12 @@ func TestHelper_FormatEmail(t *testing.T) {
 		require.Equal(t, a, FormatEmail(c))
 	})
 }
+
+func TestHelper_HashToken(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		hashed := HashToken("token")
+		require.Len(t, hashed, 64)
+		require.Equal(t, hashed, HashToken("token"))
+		require.NotEqual(t, hashed, HashToken("other-token"))
+	})
+}
@@ -0,0 +1,202 @@
+package helper
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+	"strings"
+)
+
+// password hash algorithms, the identifiers follow the PHC string format
+const (
+	PasswordHashAlgorithmArgon2id = "argon2id"
+	PasswordHashAlgorithmBcrypt   = "bcrypt"
+)
+
+// password hash errors
+var (
+	ErrPasswordHashAlgorithmUnknown = errors.New("password hash algorithm is unknown")
+	ErrPasswordHashInvalid          = errors.New("password hash is invalid")
+)
+
+var phcEncoding = base64.RawStdEncoding
+
+// Argon2idParams the argon2id parameters, the memory is in KiB
+type Argon2idParams struct {
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+// PasswordHasher hash the passwords with the current algorithm,
+// and verify the passwords hashed with any of the supported algorithms.
+// Each argon2id hash allocates its memory parameter, so the concurrent argon2id hashes are limited
+// by the semaphore to keep a burst of logins from exhausting the memory.
+type PasswordHasher struct {
+	algorithm      string
+	argon2idParams Argon2idParams
+	bcryptCost     int
+	argon2idSem    chan struct{}
+}
+
+// NewPasswordHasher PasswordHasher constructor, at most argon2idMaxConcurrency argon2id hashes run at once
+func NewPasswordHasher(algorithm string, argon2idParams Argon2idParams, bcryptCost, argon2idMaxConcurrency int) (*PasswordHasher, error) {
+	if argon2idMaxConcurrency <= 0 {
+		return nil, fmt.Errorf("argon2id max concurrency %d is invalid", argon2idMaxConcurrency)
+	}
+
+	switch algorithm {
+	case PasswordHashAlgorithmArgon2id:
+		if argon2idParams.Memory == 0 || argon2idParams.Iterations == 0 || argon2idParams.Parallelism == 0 ||
+			argon2idParams.SaltLength == 0 || argon2idParams.KeyLength == 0 {
+			return nil, fmt.Errorf("argon2id parameters are invalid: %+v", argon2idParams)
+		}
+	case PasswordHashAlgorithmBcrypt:
+		if bcryptCost < bcrypt.MinCost || bcryptCost > bcrypt.MaxCost {
+			return nil, fmt.Errorf("bcrypt cost %d is invalid", bcryptCost)
+		}
+	default:
+		return nil, ErrPasswordHashAlgorithmUnknown
+	}
+
+	return &PasswordHasher{
+		algorithm:      algorithm,
+		argon2idParams: argon2idParams,
+		bcryptCost:     bcryptCost,
+		argon2idSem:    make(chan struct{}, argon2idMaxConcurrency),
+	}, nil
+}
+
+// Hash hash the plain password with the current algorithm
+func (h *PasswordHasher) Hash(plain string) (string, error) {
+	if h.algorithm == PasswordHashAlgorithmBcrypt {
+		bt, err := bcrypt.GenerateFromPassword([]byte(plain), h.bcryptCost)
+		if err != nil {
+			return "", err
+		}
+		return string(bt), nil
+	}
+
+	salt := make([]byte, h.argon2idParams.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	p := h.argon2idParams
+	key := h.argon2idKey(plain, salt, p)
+
+	return fmt.Sprintf("$%s$v=%d$m=%d,t=%d,p=%d$%s$%s", PasswordHashAlgorithmArgon2id, argon2.Version,
+		p.Memory, p.Iterations, p.Parallelism, phcEncoding.EncodeToString(salt), phcEncoding.EncodeToString(key)), nil
+}
+
+// Verify check the plain password against the encoded hash, the algorithm is detected from the encoded hash.
+// If they don't match, will return false
+func (h *PasswordHasher) Verify(plain, encoded string) (bool, error) {
+	switch passwordHashAlgorithm(encoded) {
+	case PasswordHashAlgorithmBcrypt:
+		err := bcrypt.CompareHashAndPassword([]byte(encoded), []byte(plain))
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		return true, nil
+	case PasswordHashAlgorithmArgon2id:
+		p, salt, key, err := decodeArgon2idHash(encoded)
+		if err != nil {
+			return false, err
+		}
+
+		otherKey := h.argon2idKey(plain, salt, p)
+		return subtle.ConstantTimeCompare(key, otherKey) == 1, nil
+	default:
+		return false, ErrPasswordHashAlgorithmUnknown
+	}
+}
+
+// argon2idKey derive the argon2id key, waiting for the semaphore when the max concurrency is reached
+func (h *PasswordHasher) argon2idKey(plain string, salt []byte, p Argon2idParams) []byte {
+	h.argon2idSem <- struct{}{}
+	defer func() { <-h.argon2idSem }()
+
+	return argon2.IDKey([]byte(plain), salt, p.Iterations, p.Memory, p.Parallelism, p.KeyLength)
+}
+
+// NeedsRehash check whether the encoded hash is not hashed with the current algorithm and parameters
+func (h *PasswordHasher) NeedsRehash(encoded string) bool {
+	algorithm := passwordHashAlgorithm(encoded)
+	if algorithm != h.algorithm {
+		return true
+	}
+
+	if algorithm == PasswordHashAlgorithmBcrypt {
+		cost, err := bcrypt.Cost([]byte(encoded))
+		return err != nil || cost != h.bcryptCost
+	}
+
+	p, _, _, err := decodeArgon2idHash(encoded)
+	if err != nil {
+		return true
+	}
+
+	// the salt length is not compared, since it doesn't weaken the hash
+	return p.Memory != h.argon2idParams.Memory || p.Iterations != h.argon2idParams.Iterations ||
+		p.Parallelism != h.argon2idParams.Parallelism || p.KeyLength != h.argon2idParams.KeyLength
+}
+
+// passwordHashAlgorithm detect the algorithm from the encoded hash identifier,
+// bcrypt uses the modular crypt format with the `2a`, `2b` or `2y` identifier
+func passwordHashAlgorithm(encoded string) string {
+	parts := strings.SplitN(encoded, "$", 3)
+	if len(parts) < 3 || parts[0] != "" {
+		return ""
+	}
+
+	switch parts[1] {
+	case PasswordHashAlgorithmArgon2id:
+		return PasswordHashAlgorithmArgon2id
+	case "2a", "2b", "2y":
+		return PasswordHashAlgorithmBcrypt
+	default:
+		return ""
+	}
+}
+
+// decodeArgon2idHash decode the `$argon2id$v=19$m=65536,t=3,p=2$<salt>$<hash>` encoded hash
+func decodeArgon2idHash(encoded string) (p Argon2idParams, salt, key []byte, err error) {
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 {
+		return p, nil, nil, ErrPasswordHashInvalid
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return p, nil, nil, ErrPasswordHashInvalid
+	}
+
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.Memory, &p.Iterations, &p.Parallelism); err != nil {
+		return p, nil, nil, ErrPasswordHashInvalid
+	}
+
+	salt, err = phcEncoding.DecodeString(parts[4])
+	if err != nil {
+		return p, nil, nil, ErrPasswordHashInvalid
+	}
+
+	key, err = phcEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return p, nil, nil, ErrPasswordHashInvalid
+	}
+
+	p.SaltLength = uint32(len(salt))
+	p.KeyLength = uint32(len(key))
+
+	return p, salt, key, nil
+}
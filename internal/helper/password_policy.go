@@ -0,0 +1,266 @@
+package helper
+
+import (
+	"math"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// password policy violations, reported to the client as is
+const (
+	PasswordViolationTooShort             = "too_short"
+	PasswordViolationTooLong              = "too_long"
+	PasswordViolationMissingUppercase     = "missing_uppercase"
+	PasswordViolationMissingLowercase     = "missing_lowercase"
+	PasswordViolationMissingDigit         = "missing_digit"
+	PasswordViolationMissingSymbol        = "missing_symbol"
+	PasswordViolationContainsPersonalInfo = "contains_personal_info"
+	PasswordViolationTooWeak              = "too_weak"
+	PasswordViolationBreached             = "breached"
+)
+
+// MaxPasswordStrengthScore the strongest score of EstimatePasswordStrength
+const MaxPasswordStrengthScore = 4
+
+// minPersonalInfoLength the personal info shorter than this is too common to be rejected
+const minPersonalInfoLength = 3
+
+// the sequences walked by the keyboard patterns, e.g. qwerty and 1qaz
+var keyboardSequences = []string{
+	"`1234567890-=", "qwertyuiop[]\\", "asdfghjkl;'", "zxcvbnm,./",
+	"1qaz", "2wsx", "3edc", "4rfv", "5tgb", "6yhn", "7ujm", "8ik,", "9ol.", "0p;/",
+}
+
+// the most common passwords and words, the patterns are matched after the leet substitutions
+var commonPasswordWords = []string{
+	"password", "admin", "welcome", "login", "letmein", "master", "secret", "qwerty",
+	"iloveyou", "monkey", "dragon", "football", "baseball", "sunshine", "princess", "shadow", "superman",
+	"michael", "jessica", "charlie", "trustno1", "hello", "freedom", "whatever", "starwars", "computer",
+	"internet", "summer", "winter", "spring", "autumn", "love", "angel", "cookie", "flower", "soccer",
+	"hunter", "ranger", "buster", "killer", "pepper", "ginger", "jordan", "batman", "thomas", "hockey",
+	"andrew", "daniel", "robert", "matthew", "joshua", "jakarta", "indonesia", "bismillah", "sayang",
+	"rahasia", "cinta", "talent", "talenthub", "user", "test", "guest", "default", "changeme",
+}
+
+var leetSubstitutions = strings.NewReplacer(
+	"0", "o", "1", "i", "3", "e", "4", "a", "5", "s", "7", "t", "8", "b", "@", "a", "$", "s", "!", "i",
+)
+
+// PasswordPolicy the rules of the accepted passwords, the zero value only accepts any password
+type PasswordPolicy struct {
+	MinLength            int
+	MaxLength            int
+	RequireUppercase     bool
+	RequireLowercase     bool
+	RequireDigit         bool
+	RequireSymbol        bool
+	DisallowPersonalInfo bool
+	MinStrengthScore     int
+}
+
+// Check the password against the policy, then return the violations.
+// The personal info, e.g. the name, email and phone, is rejected when it's contained in the password.
+func (p PasswordPolicy) Check(password string, personalInfo ...string) []string {
+	var violations []string
+
+	length := utf8.RuneCountInString(password)
+	if p.MinLength > 0 && length < p.MinLength {
+		violations = append(violations, PasswordViolationTooShort)
+	}
+
+	if p.MaxLength > 0 && length > p.MaxLength {
+		violations = append(violations, PasswordViolationTooLong)
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			hasSymbol = true
+		}
+	}
+
+	if p.RequireUppercase && !hasUpper {
+		violations = append(violations, PasswordViolationMissingUppercase)
+	}
+
+	if p.RequireLowercase && !hasLower {
+		violations = append(violations, PasswordViolationMissingLowercase)
+	}
+
+	if p.RequireDigit && !hasDigit {
+		violations = append(violations, PasswordViolationMissingDigit)
+	}
+
+	if p.RequireSymbol && !hasSymbol {
+		violations = append(violations, PasswordViolationMissingSymbol)
+	}
+
+	if p.DisallowPersonalInfo && containsPersonalInfo(password, personalInfo) {
+		violations = append(violations, PasswordViolationContainsPersonalInfo)
+	}
+
+	if p.MinStrengthScore > 0 && EstimatePasswordStrength(password, personalInfo...) < p.MinStrengthScore {
+		violations = append(violations, PasswordViolationTooWeak)
+	}
+
+	return violations
+}
+
+// EstimatePasswordStrength estimate the password strength score from 0 to MaxPasswordStrengthScore, similar to zxcvbn.
+// The guesses are estimated from the class of each char, then discounted for the repeats, sequences,
+// keyboard patterns, common words and the personal info.
+func EstimatePasswordStrength(password string, personalInfo ...string) int {
+	runes := []rune(password)
+	if len(runes) == 0 {
+		return 0
+	}
+
+	bits := 0.0
+
+	// the dictionary matches are guessed as a whole word, instead of char by char
+	covered := make([]bool, len(runes))
+	words := append(personalInfoTokens(personalInfo), commonPasswordWords...)
+	normalized := []rune(leetSubstitutions.Replace(strings.ToLower(password)))
+	if len(normalized) == len(runes) {
+		for _, word := range words {
+			bits += coverWord(normalized, []rune(leetSubstitutions.Replace(word)), covered, math.Log2(float64(len(words)))+1)
+		}
+	}
+
+	lower := []rune(strings.ToLower(password))
+	for i := range runes {
+		if covered[i] {
+			continue
+		}
+
+		if i > 0 && !covered[i-1] && isPatternContinuation(lower[i-1], lower[i]) {
+			bits++
+			continue
+		}
+
+		bits += charBits(runes[i])
+	}
+
+	switch {
+	case bits < 10: // 1e3 guesses
+		return 0
+	case bits < 20: // 1e6 guesses
+		return 1
+	case bits < 27: // 1e8 guesses
+		return 2
+	case bits < 34: // 1e10 guesses
+		return 3
+	default:
+		return MaxPasswordStrengthScore
+	}
+}
+
+// coverWord mark the occurrences of the word, then return the bits to guess them
+func coverWord(password, word []rune, covered []bool, wordBits float64) float64 {
+	if len(word) < minPersonalInfoLength || len(word) > len(password) {
+		return 0
+	}
+
+	bits := 0.0
+	for i := 0; i+len(word) <= len(password); i++ {
+		if string(password[i:i+len(word)]) != string(word) || covered[i] {
+			continue
+		}
+
+		for j := i; j < i+len(word); j++ {
+			covered[j] = true
+		}
+		bits += wordBits
+		i += len(word) - 1
+	}
+
+	return bits
+}
+
+// isPatternContinuation check whether the char repeats, or continues the alphabet, digit or keyboard sequence of the previous char
+func isPatternContinuation(prev, cur rune) bool {
+	if prev == cur || cur-prev == 1 || prev-cur == 1 {
+		return true
+	}
+
+	for _, seq := range keyboardSequences {
+		idx := strings.IndexRune(seq, prev)
+		if idx < 0 {
+			continue
+		}
+
+		if (idx+1 < len(seq) && rune(seq[idx+1]) == cur) || (idx > 0 && rune(seq[idx-1]) == cur) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// charBits return the bits to guess the char, from the size of the char's class
+func charBits(r rune) float64 {
+	switch {
+	case r > unicode.MaxASCII:
+		return math.Log2(100)
+	case unicode.IsUpper(r), unicode.IsLower(r):
+		return math.Log2(26)
+	case unicode.IsDigit(r):
+		return math.Log2(10)
+	default:
+		return math.Log2(33)
+	}
+}
+
+// personalInfoTokens split the personal info into the lower case tokens, e.g. the name parts and the email's local part.
+// The phone's country code or leading zero is trimmed, so both the local and international formats are matched.
+func personalInfoTokens(personalInfo []string) []string {
+	var tokens []string
+	for _, info := range personalInfo {
+		info = strings.ToLower(strings.TrimSpace(info))
+		if info == "" {
+			continue
+		}
+
+		if at := strings.Index(info, "@"); at >= 0 {
+			tokens = append(tokens, info, info[:at])
+			continue
+		}
+
+		if strings.TrimLeft(info, "+0123456789 -") == "" {
+			phone := strings.NewReplacer("+", "", " ", "", "-", "").Replace(info)
+			phone = strings.TrimPrefix(phone, "62")
+			tokens = append(tokens, strings.TrimLeft(phone, "0"))
+			continue
+		}
+
+		tokens = append(tokens, strings.Fields(info)...)
+	}
+
+	var filtered []string
+	for _, token := range tokens {
+		if utf8.RuneCountInString(token) >= minPersonalInfoLength {
+			filtered = append(filtered, token)
+		}
+	}
+
+	return filtered
+}
+
+// containsPersonalInfo check whether the password contains the personal info, including the leet substituted one
+func containsPersonalInfo(password string, personalInfo []string) bool {
+	password = leetSubstitutions.Replace(strings.ToLower(password))
+	for _, token := range personalInfoTokens(personalInfo) {
+		if strings.Contains(password, leetSubstitutions.Replace(token)) {
+			return true
+		}
+	}
+	return false
+}
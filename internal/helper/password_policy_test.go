@@ -0,0 +1,68 @@
+package helper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHelper_PasswordPolicy_Check(t *testing.T) {
+	policy := PasswordPolicy{
+		MinLength:            8,
+		MaxLength:            16,
+		RequireUppercase:     true,
+		RequireLowercase:     true,
+		RequireDigit:         true,
+		RequireSymbol:        true,
+		DisallowPersonalInfo: true,
+		MinStrengthScore:     2,
+	}
+
+	t.Run("success", func(t *testing.T) {
+		require.Empty(t, policy.Check("Tq7#vLx9!pZ", "John Doe", "john.doe@mail.com", "+6281234567890"))
+	})
+
+	t.Run("failed, length", func(t *testing.T) {
+		require.Contains(t, policy.Check("Tq7#v"), PasswordViolationTooShort)
+		require.Contains(t, policy.Check("Tq7#vLx9!pZTq7#vLx9!pZ"), PasswordViolationTooLong)
+	})
+
+	t.Run("failed, character classes", func(t *testing.T) {
+		violations := policy.Check("qzvtxmlwrp")
+		require.Contains(t, violations, PasswordViolationMissingUppercase)
+		require.Contains(t, violations, PasswordViolationMissingDigit)
+		require.Contains(t, violations, PasswordViolationMissingSymbol)
+		require.NotContains(t, violations, PasswordViolationMissingLowercase)
+	})
+
+	t.Run("failed, contains personal info", func(t *testing.T) {
+		require.Contains(t, policy.Check("Doe#2026!xQ", "John Doe"), PasswordViolationContainsPersonalInfo)
+		require.Contains(t, policy.Check("J0hn.d0e#X", "john.d0e@mail.com"), PasswordViolationContainsPersonalInfo)
+		require.Contains(t, policy.Check("Xq#081234567890", "+6281234567890"), PasswordViolationContainsPersonalInfo)
+		require.NotContains(t, policy.Check("Tq7#vLx9!pZ", "Al", ""), PasswordViolationContainsPersonalInfo)
+	})
+
+	t.Run("failed, too weak", func(t *testing.T) {
+		require.Contains(t, policy.Check("Password123!"), PasswordViolationTooWeak)
+	})
+
+	t.Run("zero value accepts any password", func(t *testing.T) {
+		require.Empty(t, PasswordPolicy{}.Check("a"))
+	})
+}
+
+func TestHelper_EstimatePasswordStrength(t *testing.T) {
+	t.Run("weak", func(t *testing.T) {
+		require.Equal(t, 0, EstimatePasswordStrength(""))
+		require.LessOrEqual(t, EstimatePasswordStrength("aaaaaaaaaaaa"), 1)
+		require.LessOrEqual(t, EstimatePasswordStrength("abcdefgh"), 1)
+		require.LessOrEqual(t, EstimatePasswordStrength("qwertyuiop"), 1)
+		require.LessOrEqual(t, EstimatePasswordStrength("P@ssw0rd"), 1)
+		require.LessOrEqual(t, EstimatePasswordStrength("johndoe123", "John Doe"), 1)
+	})
+
+	t.Run("strong", func(t *testing.T) {
+		require.Equal(t, MaxPasswordStrengthScore, EstimatePasswordStrength("Tq7#vLx9!pZ"))
+		require.Equal(t, MaxPasswordStrengthScore, EstimatePasswordStrength("correct horse battery staple"))
+	})
+}
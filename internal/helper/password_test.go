@@ -0,0 +1,109 @@
+package helper
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var testArgon2idParams = Argon2idParams{
+	Memory:      1024,
+	Iterations:  1,
+	Parallelism: 1,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+func TestHelper_NewPasswordHasher(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		_, err := NewPasswordHasher(PasswordHashAlgorithmArgon2id, testArgon2idParams, bcrypt.MinCost, 1)
+		require.NoError(t, err)
+
+		_, err = NewPasswordHasher(PasswordHashAlgorithmBcrypt, Argon2idParams{}, bcrypt.MinCost, 1)
+		require.NoError(t, err)
+	})
+
+	t.Run("failed, unknown algorithm", func(t *testing.T) {
+		_, err := NewPasswordHasher("md5", testArgon2idParams, bcrypt.MinCost, 1)
+		require.ErrorIs(t, err, ErrPasswordHashAlgorithmUnknown)
+	})
+
+	t.Run("failed, invalid parameters", func(t *testing.T) {
+		_, err := NewPasswordHasher(PasswordHashAlgorithmArgon2id, Argon2idParams{}, bcrypt.MinCost, 1)
+		require.Error(t, err)
+
+		_, err = NewPasswordHasher(PasswordHashAlgorithmBcrypt, testArgon2idParams, bcrypt.MaxCost+1, 1)
+		require.Error(t, err)
+
+		_, err = NewPasswordHasher(PasswordHashAlgorithmArgon2id, testArgon2idParams, bcrypt.MinCost, 0)
+		require.Error(t, err)
+	})
+}
+
+func TestHelper_PasswordHasher(t *testing.T) {
+	hasher, err := NewPasswordHasher(PasswordHashAlgorithmArgon2id, testArgon2idParams, bcrypt.MinCost, 1)
+	require.NoError(t, err)
+
+	t.Run("argon2id", func(t *testing.T) {
+		encoded, err := hasher.Hash("Password123")
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(encoded, "$argon2id$v=19$m=1024,t=1,p=1$"))
+
+		otherEncoded, err := hasher.Hash("Password123")
+		require.NoError(t, err)
+		require.NotEqual(t, encoded, otherEncoded)
+
+		match, err := hasher.Verify("Password123", encoded)
+		require.NoError(t, err)
+		require.True(t, match)
+
+		match, err = hasher.Verify("password123", encoded)
+		require.NoError(t, err)
+		require.False(t, match)
+
+		require.False(t, hasher.NeedsRehash(encoded))
+	})
+
+	t.Run("legacy bcrypt", func(t *testing.T) {
+		bt, err := bcrypt.GenerateFromPassword([]byte("Password123"), bcrypt.MinCost)
+		require.NoError(t, err)
+
+		match, err := hasher.Verify("Password123", string(bt))
+		require.NoError(t, err)
+		require.True(t, match)
+
+		match, err = hasher.Verify("password123", string(bt))
+		require.NoError(t, err)
+		require.False(t, match)
+
+		require.True(t, hasher.NeedsRehash(string(bt)))
+	})
+
+	t.Run("changed parameters", func(t *testing.T) {
+		params := testArgon2idParams
+		params.Iterations = 2
+		otherHasher, err := NewPasswordHasher(PasswordHashAlgorithmArgon2id, params, bcrypt.MinCost, 1)
+		require.NoError(t, err)
+
+		encoded, err := hasher.Hash("Password123")
+		require.NoError(t, err)
+		require.True(t, otherHasher.NeedsRehash(encoded))
+
+		// the older parameters are still verifiable
+		match, err := otherHasher.Verify("Password123", encoded)
+		require.NoError(t, err)
+		require.True(t, match)
+	})
+
+	t.Run("invalid hash", func(t *testing.T) {
+		_, err := hasher.Verify("Password123", "plain-password")
+		require.ErrorIs(t, err, ErrPasswordHashAlgorithmUnknown)
+
+		_, err = hasher.Verify("Password123", "$argon2id$v=19$m=1024,t=1,p=1$salt")
+		require.ErrorIs(t, err, ErrPasswordHashInvalid)
+
+		require.True(t, hasher.NeedsRehash("plain-password"))
+	})
+}
@@ -0,0 +1,80 @@
+package helper
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"strings"
+)
+
+// encryptedSecretPrefix mark the encrypted secret with the format version, so the format can be changed later
+const encryptedSecretPrefix = "enc:v1:"
+
+// ErrSecretKeyInvalid the encryption key is not a 16, 24 or 32 bytes AES key
+var ErrSecretKeyInvalid = errors.New("secret encryption key must be 16, 24 or 32 bytes")
+
+// EncryptSecret encrypts the secret with AES-GCM, the random nonce is prepended to the cipher text
+func EncryptSecret(key []byte, secret string) (string, error) {
+	gcm, err := newSecretGCM(key)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	sealed := gcm.Seal(nonce, nonce, []byte(secret), nil)
+	return encryptedSecretPrefix + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// DecryptSecret decrypts the secret encrypted by EncryptSecret
+func DecryptSecret(key []byte, encrypted string) (string, error) {
+	if !isEncryptedSecret(encrypted) {
+		return "", errors.New("secret is not encrypted")
+	}
+
+	gcm, err := newSecretGCM(key)
+	if err != nil {
+		return "", err
+	}
+
+	sealed, err := base64.RawStdEncoding.DecodeString(strings.TrimPrefix(encrypted, encryptedSecretPrefix))
+	if err != nil {
+		return "", err
+	}
+
+	if len(sealed) < gcm.NonceSize() {
+		return "", errors.New("encrypted secret is too short")
+	}
+
+	secret, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], nil)
+	if err != nil {
+		return "", err
+	}
+
+	return string(secret), nil
+}
+
+// isEncryptedSecret check the secret is encrypted by EncryptSecret
+func isEncryptedSecret(secret string) bool {
+	return strings.HasPrefix(secret, encryptedSecretPrefix)
+}
+
+func newSecretGCM(key []byte) (cipher.AEAD, error) {
+	switch len(key) {
+	case 16, 24, 32:
+	default:
+		return nil, ErrSecretKeyInvalid
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,51 @@
+package helper
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHelper_EncryptSecret(t *testing.T) {
+	key := []byte(strings.Repeat("k", 32))
+
+	t.Run("success", func(t *testing.T) {
+		encrypted, err := EncryptSecret(key, rfc6238Secret)
+		require.NoError(t, err)
+		require.True(t, isEncryptedSecret(encrypted))
+		require.NotContains(t, encrypted, rfc6238Secret)
+
+		secret, err := DecryptSecret(key, encrypted)
+		require.NoError(t, err)
+		require.Equal(t, rfc6238Secret, secret)
+	})
+
+	t.Run("random nonce", func(t *testing.T) {
+		first, err := EncryptSecret(key, rfc6238Secret)
+		require.NoError(t, err)
+		second, err := EncryptSecret(key, rfc6238Secret)
+		require.NoError(t, err)
+		require.NotEqual(t, first, second)
+	})
+
+	t.Run("invalid key", func(t *testing.T) {
+		_, err := EncryptSecret([]byte("short"), rfc6238Secret)
+		require.ErrorIs(t, err, ErrSecretKeyInvalid)
+	})
+
+	t.Run("wrong key", func(t *testing.T) {
+		encrypted, err := EncryptSecret(key, rfc6238Secret)
+		require.NoError(t, err)
+
+		_, err = DecryptSecret([]byte(strings.Repeat("x", 32)), encrypted)
+		require.Error(t, err)
+	})
+
+	t.Run("not encrypted", func(t *testing.T) {
+		require.False(t, isEncryptedSecret(rfc6238Secret))
+
+		_, err := DecryptSecret(key, rfc6238Secret)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,71 @@
+package helper
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// secret of the RFC 6238 test vectors, "12345678901234567890" in base32
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestHelper_GenerateTOTPCode(t *testing.T) {
+	tests := []struct {
+		unix int64
+		code string
+	}{
+		{unix: 59, code: "287082"},
+		{unix: 1111111109, code: "081804"},
+		{unix: 1234567890, code: "005924"},
+		{unix: 2000000000, code: "279037"},
+	}
+
+	for _, tt := range tests {
+		code, err := GenerateTOTPCode(rfc6238Secret, TOTPCounter(time.Unix(tt.unix, 0)))
+		require.NoError(t, err)
+		require.Equal(t, tt.code, code)
+	}
+}
+
+func TestHelper_ValidateTOTPCode(t *testing.T) {
+	now := time.Unix(1111111109, 0)
+
+	t.Run("success", func(t *testing.T) {
+		counter, ok := ValidateTOTPCode(rfc6238Secret, "081804", now, 1)
+		require.True(t, ok)
+		require.Equal(t, TOTPCounter(now), counter)
+	})
+
+	t.Run("success within skew", func(t *testing.T) {
+		_, ok := ValidateTOTPCode(rfc6238Secret, "081804", now.Add(TOTPPeriod*time.Second), 1)
+		require.True(t, ok)
+	})
+
+	t.Run("failed outside skew", func(t *testing.T) {
+		_, ok := ValidateTOTPCode(rfc6238Secret, "081804", now.Add(3*TOTPPeriod*time.Second), 1)
+		require.False(t, ok)
+	})
+
+	t.Run("failed wrong code", func(t *testing.T) {
+		_, ok := ValidateTOTPCode(rfc6238Secret, "000000", now, 1)
+		require.False(t, ok)
+	})
+}
+
+func TestHelper_GenerateTOTPSecret(t *testing.T) {
+	secret, err := GenerateTOTPSecret()
+	require.NoError(t, err)
+	require.Len(t, secret, 32)
+
+	_, err = GenerateTOTPCode(secret, 1)
+	require.NoError(t, err)
+}
+
+func TestHelper_NewTOTPURI(t *testing.T) {
+	uri := NewTOTPURI("Talent Hub", "john@mail.com", rfc6238Secret)
+	require.True(t, strings.HasPrefix(uri, "otpauth://totp/Talent%20Hub:john@mail.com?"))
+	require.Contains(t, uri, "secret="+rfc6238Secret)
+	require.Contains(t, uri, "issuer=Talent+Hub")
+}
@@ -0,0 +1,71 @@
+package model
+
+import (
+	"context"
+	"gopkg.in/guregu/null.v4"
+	"time"
+)
+
+type (
+	APIKeyUsecase interface {
+		FindAll(ctx context.Context, requester *Candidate) ([]*APIKey, error)
+		Create(ctx context.Context, requester *Candidate, input CreateAPIKeyInput) (*APIKey, error)
+		Rotate(ctx context.Context, requester *Candidate, id int64) (*APIKey, error)
+		Revoke(ctx context.Context, requester *Candidate, id int64) error
+		Authenticate(ctx context.Context, key string) (*Candidate, error)
+	}
+
+	APIKeyRepository interface {
+		FindAll(ctx context.Context) ([]*APIKey, error)
+		FindByID(ctx context.Context, id int64) (*APIKey, error)
+		FindByPrefix(ctx context.Context, prefix string) (*APIKey, error)
+		Create(ctx context.Context, apiKey *APIKey) error
+		Update(ctx context.Context, apiKey *APIKey) error
+		UpdateLastUsedAt(ctx context.Context, apiKey *APIKey) error
+	}
+
+	// APIKey the machine credential of the partner integration, only the hash of the key is stored.
+	// The key is formatted as `<APIKeyPrefix><prefix>_<secret>`, the prefix is used to look up the key.
+	APIKey struct {
+		ID            int64
+		Name          string
+		Prefix        string
+		KeyHash       string
+		Scopes        []Permission `gorm:"serializer:json"`
+		ExpiredAt     null.Time
+		LastUsedAt    null.Time
+		RevokedAt     null.Time
+		RotatedFromID null.Int
+		CreatedBy     null.Int
+		CreatedAt     time.Time `gorm:"->;<-:create"`
+		UpdatedAt     time.Time
+
+		// Key the plain key, only set when the key is created or rotated
+		Key string `json:"-" gorm:"-"`
+	}
+)
+
+// APIKeyPrefix the prefix of every api key, so a leaked key can be recognized by the secret scanners
+const APIKeyPrefix = "thk_"
+
+// IsExpired check the key is expired
+func (k *APIKey) IsExpired() bool {
+	return k.ExpiredAt.Valid && time.Now().After(k.ExpiredAt.Time)
+}
+
+// IsRevoked check the key is revoked
+func (k *APIKey) IsRevoked() bool {
+	return k.RevokedAt.Valid
+}
+
+// CreateAPIKeyInput input
+type CreateAPIKeyInput struct {
+	Name      string       `json:"name" validate:"required,max=100"`
+	Scopes    []Permission `json:"scopes" validate:"required,min=1,dive,required"`
+	ExpiredAt null.Time    `json:"expires_at"`
+}
+
+// Validate validates the create api key input body.
+func (c *CreateAPIKeyInput) Validate() error {
+	return validate.Struct(c)
+}
@@ -17,10 +17,10 @@ type LoginRequest struct {
 	Identifier     string `json:"identifier" validate:"required,identifier"`
 	PlainPassword  string `json:"plain_password" validate:"required,min=5"`
 	IdentifierType IdentifierType
+	PhoneVerified  bool   `json:"-"` // the login otp already proves the phone, so it can't be the step-up factor
 	UserAgent      string `json:"user_agent"`
-	Latitude       string `json:"latitude"`
-	Longitude      string `json:"longitude"`
 	IPAddress      string `json:"ip_address"`
+	GeoLocation
 }
 
 // Validate validates the login input body.
@@ -32,15 +32,50 @@ func (c *LoginRequest) Validate() error {
 type RefreshTokenRequest struct {
 	RefreshToken string
 	UserAgent    string
-	Latitude     string
-	Longitude    string
 	IPAddress    string
+	GeoLocation
+}
+
+// RequestLoginOTPRequest request
+type RequestLoginOTPRequest struct {
+	Phone string `json:"phone" validate:"required,phonenumber"`
+}
+
+// Validate validates the request login otp input body.
+func (r *RequestLoginOTPRequest) Validate() error {
+	return validate.Struct(r)
+}
+
+// LoginByOTPRequest request
+type LoginByOTPRequest struct {
+	Phone     string `json:"phone" validate:"required,phonenumber"`
+	Code      string `json:"code" validate:"required,numeric"`
+	UserAgent string `json:"user_agent"`
+	IPAddress string `json:"ip_address"`
+	GeoLocation
+}
+
+// Validate validates the login by otp input body.
+func (r *LoginByOTPRequest) Validate() error {
+	return validate.Struct(r)
 }
 
 // AuthUsecase usecases
 type AuthUsecase interface {
-	LoginByIdentifierPassword(ctx context.Context, req LoginRequest) (*Session, error)
+	LoginByIdentifierPassword(ctx context.Context, req LoginRequest) (*Session, *MFAChallenge, error)
 	AuthenticateToken(ctx context.Context, accessToken string) (*Candidate, error)
 	RefreshToken(ctx context.Context, req RefreshTokenRequest) (*Session, error)
 	DeleteSessionByID(ctx context.Context, sessionID int64) error
+	Logout(ctx context.Context, requester *Candidate, client ClientInfo) error
+	FindAllSessions(ctx context.Context, requester *Candidate) ([]*Session, error)
+	RevokeSession(ctx context.Context, requester *Candidate, sessionID int64, client ClientInfo) error
+	RevokeOtherSessions(ctx context.Context, requester *Candidate, client ClientInfo) error
+	RequestLoginOTP(ctx context.Context, req RequestLoginOTPRequest) error
+	Impersonate(ctx context.Context, requester *Candidate, candidateID int64, req ImpersonateRequest) (*Session, error)
+	LoginByOTP(ctx context.Context, req LoginByOTPRequest) (*Session, *MFAChallenge, error)
+	VerifyMFAChallenge(ctx context.Context, req VerifyMFAChallengeRequest) (*Session, error)
+	StartOIDCLogin(ctx context.Context, provider string) (*OIDCAuthorization, error)
+	LoginByOIDC(ctx context.Context, req OIDCLoginRequest) (*Session, *MFAChallenge, error)
+	StartOIDCLink(ctx context.Context, requester *Candidate, provider string) (*OIDCAuthorization, error)
+	LinkOIDCIdentity(ctx context.Context, requester *Candidate, req OIDCLinkRequest) error
 }
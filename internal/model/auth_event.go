@@ -0,0 +1,109 @@
+package model
+
+import (
+	"context"
+	"github.com/irvankadhafi/talent-hub-service/internal/config"
+	"github.com/irvankadhafi/talent-hub-service/utils"
+	"gopkg.in/guregu/null.v4"
+	"time"
+)
+
+type (
+	AuthEventRepository interface {
+		Create(ctx context.Context, event *AuthEvent) error
+		FindAllByCriteria(ctx context.Context, criteria AuthEventCriteria) ([]*AuthEvent, int64, error)
+		DeleteCreatedBefore(ctx context.Context, createdBefore time.Time, batchSize int) (int64, error)
+	}
+
+	// AuthEventRecorder records the auth events in the background, so the authentication doesn't wait for the insert
+	AuthEventRecorder interface {
+		EnqueueRecord(event *AuthEvent)
+	}
+
+	AuthEventUsecase interface {
+		FindAllByCandidate(ctx context.Context, requester *Candidate, criteria AuthEventCriteria) ([]*AuthEvent, int64, error)
+		FindAll(ctx context.Context, requester *Candidate, criteria AuthEventCriteria) ([]*AuthEvent, int64, error)
+	}
+
+	// AuthEvent the audit record of an authentication event.
+	// The candidate is unknown when the login fails with an unregistered identifier.
+	AuthEvent struct {
+		ID          int64
+		EventType   AuthEventType
+		CandidateID null.Int
+		SessionID   null.Int
+		Identifier  string
+		IPAddress   string
+		UserAgent   string
+		Latitude    null.Float
+		Longitude   null.Float
+		Outcome     AuthEventOutcome
+		Reason      string
+		CreatedAt   time.Time
+	}
+
+	// AuthEventCriteria the filter and pagination of the auth events, the zero value filter is ignored
+	AuthEventCriteria struct {
+		Pagination
+		CandidateID int64
+		EventType   AuthEventType
+		Outcome     AuthEventOutcome
+	}
+)
+
+// AuthEventType the type of authentication event
+type AuthEventType string
+
+// AuthEventType constants
+const (
+	AuthEventTypeLogin         AuthEventType = "login"
+	AuthEventTypeLoginOTP      AuthEventType = "login_otp"
+	AuthEventTypeLoginOIDC     AuthEventType = "login_oidc"
+	AuthEventTypeMFAVerify     AuthEventType = "mfa_verify"
+	AuthEventTypeTokenRefresh  AuthEventType = "token_refresh"
+	AuthEventTypeLogout        AuthEventType = "logout"
+	AuthEventTypeSessionRevoke AuthEventType = "session_revoke"
+	AuthEventTypeImpersonate   AuthEventType = "impersonate"
+)
+
+// AuthEventOutcome the outcome of authentication event
+type AuthEventOutcome string
+
+// AuthEventOutcome constants
+const (
+	AuthEventOutcomeSuccess AuthEventOutcome = "success"
+	AuthEventOutcomeFailure AuthEventOutcome = "failure"
+	// AuthEventOutcomeChallenged the first factor succeeded, but the mfa challenge is required to create the session
+	AuthEventOutcomeChallenged AuthEventOutcome = "challenged"
+)
+
+// ClientInfo the client performing the request, recorded in the auth events
+type ClientInfo struct {
+	UserAgent string
+	IPAddress string
+}
+
+// Pagination the page and size of the paginated query, the page starts from 1
+type Pagination struct {
+	Page int64
+	Size int64
+}
+
+// Normalize set the default page and size, the size is capped to keep the query cheap
+func (p *Pagination) Normalize() {
+	if p.Page <= 0 {
+		p.Page = 1
+	}
+
+	switch {
+	case p.Size <= 0:
+		p.Size = config.DefaultPageSize
+	case p.Size > config.MaxPageSize:
+		p.Size = config.MaxPageSize
+	}
+}
+
+// Offset get the offset of the page
+func (p Pagination) Offset() int64 {
+	return utils.Offset(p.Page, p.Size)
+}
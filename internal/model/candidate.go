@@ -5,6 +5,7 @@ import (
 	"github.com/irvankadhafi/talent-hub-service/internal/helper"
 	"gopkg.in/guregu/null.v4"
 	"gorm.io/gorm"
+	"strings"
 	"time"
 )
 
@@ -12,6 +13,8 @@ type (
 	CandidateUsecase interface {
 		Create(ctx context.Context, input CreateCandidateInput) (*Candidate, error)
 		FindByID(ctx context.Context, id int64) (*Candidate, error)
+		FindByIDForPartner(ctx context.Context, requester *Candidate, id int64) (*Candidate, error)
+		UpdateProfile(ctx context.Context, requester *Candidate, input UpdateProfileInput) (*Candidate, error)
 	}
 
 	CandidateRepository interface {
@@ -23,31 +26,69 @@ type (
 		FindByPhone(ctx context.Context, phone string) (*Candidate, error)
 		Create(ctx context.Context, candidate *Candidate) error
 		Update(ctx context.Context, candidate *Candidate) error
+		FindTOTPSecretByID(ctx context.Context, id int64) (null.String, error)
+		UpdateTOTP(ctx context.Context, candidate *Candidate) error
+		UpdateProfile(ctx context.Context, candidate *Candidate, columns []string) error
+		UpdatePassword(ctx context.Context, id int64, password string) error
+		UpdateEmail(ctx context.Context, candidate *Candidate, email string) error
 	}
 
 	Candidate struct {
-		ID             int64          `json:"id"`
-		FullName       string         `json:"full_name"`
-		Email          null.String    `json:"email"`
-		Phone          null.String    `json:"phone"`
-		Password       string         `json:"password"`
-		DateOfBirth    null.Time      `json:"date_of_birth"`
-		Gender         Gender         `json:"gender"`
-		CityID         int64          `json:"city_id"`
-		ProvinceID     int64          `json:"province_id"`
-		LastEducation  time.Time      `json:"last_education"`
-		LastExperience time.Time      `json:"last_experience"`
-		LoginDate      time.Time      `json:"login_date"`
-		CreatedAt      time.Time      `json:"created_at" gorm:"->;<-:create"`
-		UpdatedAt      time.Time      `json:"updated_at"`
-		DeletedAt      gorm.DeletedAt `json:"deleted_at"`
-
-		SessionID int64  `json:"-" gorm:"-"`
-		Latitude  string `json:"latitude" gorm:"-"`
-		Longitude string `json:"longitude" gorm:"-"`
+		ID              int64          `json:"id"`
+		FullName        string         `json:"full_name"`
+		Email           null.String    `json:"email"`
+		EmailVerifiedAt null.Time      `json:"email_verified_at"`
+		Phone           null.String    `json:"phone"`
+		Password        string         `json:"-"`
+		DateOfBirth     null.Time      `json:"date_of_birth"`
+		Gender          Gender         `json:"gender"`
+		CityID          int64          `json:"city_id"`
+		ProvinceID      int64          `json:"province_id"`
+		LastEducation   time.Time      `json:"last_education"`
+		LastExperience  time.Time      `json:"last_experience"`
+		LoginDate       time.Time      `json:"login_date"`
+		TOTPSecret      null.String    `json:"-"` // encrypted, never cached
+		TOTPEnabledAt   null.Time      `json:"totp_enabled_at"`
+		CreatedAt       time.Time      `json:"created_at" gorm:"->;<-:create"`
+		UpdatedAt       time.Time      `json:"updated_at"`
+		DeletedAt       gorm.DeletedAt `json:"deleted_at"`
+
+		SessionID        int64        `json:"-" gorm:"-"`
+		MFAAuthenticated bool         `json:"-" gorm:"-"`
+		Roles            []Role       `json:"-" gorm:"-"`
+		ImpersonatorID   int64        `json:"-" gorm:"-"`
+		APIKeyID         int64        `json:"-" gorm:"-"`
+		Scopes           []Permission `json:"-" gorm:"-"`
+		Latitude         string       `json:"latitude" gorm:"-"`
+		Longitude        string       `json:"longitude" gorm:"-"`
 	}
 )
 
+// IsTOTPEnabled check the candidate has confirmed the TOTP enrollment
+func (c *Candidate) IsTOTPEnabled() bool {
+	return c.TOTPEnabledAt.Valid
+}
+
+// IsAPIKey check the requester is authenticated with the api key instead of the candidate's session
+func (c *Candidate) IsAPIKey() bool {
+	return c.APIKeyID != 0
+}
+
+// HasPermission check whether the requester is granted the permission,
+// the api key is only granted its scopes
+func (c *Candidate) HasPermission(permission Permission) bool {
+	if c.IsAPIKey() {
+		for _, scope := range c.Scopes {
+			if scope == permission {
+				return true
+			}
+		}
+		return false
+	}
+
+	return HasPermission(c.Roles, permission)
+}
+
 // Gender the candidate's gender
 type Gender string
 
@@ -60,11 +101,11 @@ const (
 // CreateCandidateInput :nodoc:
 type CreateCandidateInput struct {
 	FullName             string `json:"full_name" validate:"required"`
-	Email                string `json:"email" validate:"omitempty,emailEligibility"`
+	Email                string `json:"email" validate:"required_without=Phone,omitempty,emailEligibility"`
 	Phone                string `json:"phone" validate:"omitempty,phonenumber"`
 	Gender               Gender `json:"gender" validate:"required"`
-	Password             string `json:"password" validate:"required,min=6"`
-	PasswordConfirmation string `json:"password_confirmation" validate:"required,min=6,eqfield=Password"`
+	Password             string `json:"password" validate:"required"`
+	PasswordConfirmation string `json:"password_confirmation" validate:"required,eqfield=Password"`
 }
 
 // ValidateAndFormat do field validation and format the PhoneNumber
@@ -84,3 +125,31 @@ func (c *CreateCandidateInput) ValidateAndFormat() error {
 
 	return nil
 }
+
+// DateOfBirthLayout the layout of the candidate's date of birth
+const DateOfBirthLayout = "2006-01-02"
+
+// UpdateProfileInput the candidate's own profile update, only the set fields are updated.
+// The empty date of birth and the zero city and province clear the field.
+type UpdateProfileInput struct {
+	FullName    *string `json:"full_name" validate:"omitempty,min=1,max=255"`
+	DateOfBirth *string `json:"date_of_birth" validate:"omitempty,datetime=2006-01-02|len=0"`
+	Gender      *Gender `json:"gender" validate:"omitempty,oneof=MALE FEMALE"`
+	CityID      *int64  `json:"city_id" validate:"omitempty,gte=0"`
+	ProvinceID  *int64  `json:"province_id" validate:"omitempty,gte=0"`
+}
+
+// ValidateAndFormat trim the full name, then do field validation
+func (u *UpdateProfileInput) ValidateAndFormat() error {
+	if u.FullName != nil {
+		fullName := strings.TrimSpace(*u.FullName)
+		u.FullName = &fullName
+	}
+
+	return validate.Struct(u)
+}
+
+// IsEmpty check none of the fields is set
+func (u *UpdateProfileInput) IsEmpty() bool {
+	return u.FullName == nil && u.DateOfBirth == nil && u.Gender == nil && u.CityID == nil && u.ProvinceID == nil
+}
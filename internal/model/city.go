@@ -1,9 +1,14 @@
 package model
 
 import (
+	"context"
 	"time"
 )
 
+type CityRepository interface {
+	FindByID(ctx context.Context, id int64) (*City, error)
+}
+
 type City struct {
 	ID         int64
 	ProvinceID int64
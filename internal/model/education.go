@@ -2,20 +2,25 @@ package model
 
 import (
 	"context"
+	"gopkg.in/guregu/null.v4"
 	"gorm.io/gorm"
+	"strings"
 	"time"
 )
 
 type (
+	// Education the candidate's education, a candidate may have many educations.
+	// The end year is null when the education is until now.
 	Education struct {
 		ID              int64
 		CandidateID     int64
 		InstitutionName string
 		Major           string
 		StartYear       time.Time
-		EndYear         time.Time
+		EndYear         null.Time
 		UntilNow        bool
-		GPA             float64
+		GPA             null.Float
+		GPAScale        float64
 		Flag            string
 		CreatedAt       time.Time
 		UpdatedAt       time.Time
@@ -24,22 +29,57 @@ type (
 
 	EducationRepository interface {
 		FindByID(ctx context.Context, id int64) (*Education, error)
-		FindByCandidateID(ctx context.Context, candidateID int64) (*Education, error)
+		FindAllByCandidateID(ctx context.Context, candidateID int64, pagination Pagination) ([]*Education, int64, error)
 		Create(ctx context.Context, education *Education) error
 		Update(ctx context.Context, education *Education) error
+		Delete(ctx context.Context, education *Education) error
 	}
 
 	EducationUsecase interface {
-		FindByID(ctx context.Context, id int64) (*Education, error)
+		FindAllByCandidate(ctx context.Context, requester *Candidate, pagination Pagination) ([]*Education, int64, error)
+		FindAllByCandidateIDForPartner(ctx context.Context, requester *Candidate, candidateID int64, pagination Pagination) ([]*Education, int64, error)
+		FindByID(ctx context.Context, requester *Candidate, id int64) (*Education, error)
+		Create(ctx context.Context, requester *Candidate, input EducationInput) (*Education, error)
+		Update(ctx context.Context, requester *Candidate, id int64, input EducationInput) (*Education, error)
+		Delete(ctx context.Context, requester *Candidate, id int64) error
 	}
 
-	// CreateEducationInput create education input
-	CreateEducationInput struct {
-		Name             string  `json:"name" validate:"required,min=3,max=60"`
-		Email            string  `json:"email" validate:"required,emailEligibility"`
-		PhoneNumber      *string `json:"phone_number" validate:"omitempty,phonenumber"`
-		Address          string  `json:"address" validate:"required,min=1"`
-		LogoImageID      int64   `json:"logo_image_id" validate:"omitempty,required"`
-		IdentityColorHex string  `json:"identity_color_hex" validate:"required,hexcolor"`
+	// EducationInput the create and update education input, the update replaces all the fields
+	EducationInput struct {
+		InstitutionName string   `json:"institution_name" validate:"required,max=255"`
+		Major           string   `json:"major" validate:"required,max=255"`
+		StartDate       string   `json:"start_date" validate:"required,datetime=2006-01-02"`
+		EndDate         *string  `json:"end_date" validate:"omitempty,datetime=2006-01-02"`
+		UntilNow        bool     `json:"until_now"`
+		GPA             *float64 `json:"gpa" validate:"omitempty,gte=0"`
+		GPAScale        *float64 `json:"gpa_scale"`
 	}
 )
+
+// DateLayout the layout of the start and end dates of the candidate's educations and experiences
+const DateLayout = "2006-01-02"
+
+// DefaultGPAScale the gpa scale when the gpa is set without the scale
+const DefaultGPAScale float64 = 4
+
+// gpaScales the supported gpa scales
+var gpaScales = []float64{4, 5, 10, 100}
+
+// IsValidGPAScale check the gpa scale is supported
+func IsValidGPAScale(scale float64) bool {
+	for _, s := range gpaScales {
+		if s == scale {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ValidateAndFormat trim the institution name and the major, then do field validation
+func (e *EducationInput) ValidateAndFormat() error {
+	e.InstitutionName = strings.TrimSpace(e.InstitutionName)
+	e.Major = strings.TrimSpace(e.Major)
+
+	return validate.Struct(e)
+}
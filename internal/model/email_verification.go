@@ -0,0 +1,49 @@
+package model
+
+import (
+	"context"
+	"gopkg.in/guregu/null.v4"
+	"time"
+)
+
+type (
+	EmailVerificationUsecase interface {
+		SendVerification(ctx context.Context, candidateID int64) error
+		Verify(ctx context.Context, token string) error
+		ChangeEmail(ctx context.Context, requester *Candidate, input ChangeEmailInput) error
+	}
+
+	EmailVerificationTokenRepository interface {
+		Create(ctx context.Context, token *EmailVerificationToken) error
+		FindByToken(ctx context.Context, token string) (*EmailVerificationToken, error)
+		MarkAsUsed(ctx context.Context, token *EmailVerificationToken) (bool, error)
+	}
+
+	// EmailVerificationToken the single use token to verify the candidate's email, only the hash is stored.
+	// The token is only valid for the email it was sent to.
+	EmailVerificationToken struct {
+		ID          int64
+		CandidateID int64
+		Email       string
+		TokenHash   string
+		ExpiredAt   time.Time
+		UsedAt      null.Time
+		CreatedAt   time.Time
+	}
+)
+
+// IsUsable check the token is not used nor expired
+func (t *EmailVerificationToken) IsUsable() bool {
+	return !t.UsedAt.Valid && time.Now().Before(t.ExpiredAt)
+}
+
+// ChangeEmailInput :nodoc:
+type ChangeEmailInput struct {
+	Email    string `json:"email" validate:"required,email,max=255"`
+	Password string `json:"password" validate:"required"`
+}
+
+// Validate validates the change email input body
+func (c *ChangeEmailInput) Validate() error {
+	return validate.Struct(c)
+}
@@ -1,22 +1,79 @@
 package model
 
 import (
+	"context"
+	"gopkg.in/guregu/null.v4"
 	"gorm.io/gorm"
+	"strings"
 	"time"
 )
 
-type Experience struct {
-	ID             int64
-	CandidateID    int64
-	CompanyName    string
-	CompanyAddress string
-	Position       string
-	JobDescription string
-	StartYear      time.Time
-	EndYear        time.Time
-	UntilNow       bool
-	Flag           string
-	CreatedAt      time.Time
-	UpdatedAt      time.Time
-	DeletedAt      gorm.DeletedAt
+type (
+	// Experience the candidate's work experience, a candidate may have many experiences.
+	// The end year is null when the experience is until now.
+	Experience struct {
+		ID             int64
+		CandidateID    int64
+		CompanyName    string
+		CompanyAddress string
+		Position       string
+		JobDescription string `gorm:"column:job_desc"`
+		StartYear      time.Time
+		EndYear        null.Time
+		UntilNow       bool
+		Flag           string
+		CreatedAt      time.Time
+		UpdatedAt      time.Time
+		DeletedAt      gorm.DeletedAt
+	}
+
+	ExperienceRepository interface {
+		FindByID(ctx context.Context, id int64) (*Experience, error)
+		FindAllByCandidateID(ctx context.Context, candidateID int64, pagination Pagination) ([]*Experience, int64, error)
+		FindAllOverlapping(ctx context.Context, experience *Experience) ([]*Experience, error)
+		Create(ctx context.Context, experience *Experience) error
+		Update(ctx context.Context, experience *Experience) error
+		Delete(ctx context.Context, experience *Experience) error
+	}
+
+	// ExperienceUsecase the create and update also return the candidate's other experiences overlapping the experience,
+	// the overlap is only a warning since the candidate may have worked on more than one job at a time.
+	ExperienceUsecase interface {
+		FindAllByCandidate(ctx context.Context, requester *Candidate, pagination Pagination) ([]*Experience, int64, error)
+		FindAllByCandidateIDForPartner(ctx context.Context, requester *Candidate, candidateID int64, pagination Pagination) ([]*Experience, int64, error)
+		FindByID(ctx context.Context, requester *Candidate, id int64) (*Experience, error)
+		Create(ctx context.Context, requester *Candidate, input ExperienceInput) (experience *Experience, overlaps []*Experience, err error)
+		Update(ctx context.Context, requester *Candidate, id int64, input ExperienceInput) (experience *Experience, overlaps []*Experience, err error)
+		Delete(ctx context.Context, requester *Candidate, id int64) error
+	}
+
+	// ExperienceInput the create and update experience input, the update replaces all the fields
+	ExperienceInput struct {
+		CompanyName    string  `json:"company_name" validate:"required,max=255"`
+		CompanyAddress string  `json:"company_address" validate:"max=1000"`
+		Position       string  `json:"position" validate:"required,max=255"`
+		JobDescription string  `json:"job_description" validate:"max=5000"`
+		StartDate      string  `json:"start_date" validate:"required,datetime=2006-01-02"`
+		EndDate        *string `json:"end_date" validate:"omitempty,datetime=2006-01-02"`
+		UntilNow       bool    `json:"until_now"`
+	}
+)
+
+// ValidateAndFormat trim the text fields, then do field validation
+func (e *ExperienceInput) ValidateAndFormat() error {
+	e.CompanyName = strings.TrimSpace(e.CompanyName)
+	e.CompanyAddress = strings.TrimSpace(e.CompanyAddress)
+	e.Position = strings.TrimSpace(e.Position)
+	e.JobDescription = strings.TrimSpace(e.JobDescription)
+
+	return validate.Struct(e)
+}
+
+// Overlaps check whether the date ranges of the experiences overlap. The until now experience has no end,
+// the experience ended on the day the other starts is not overlapping.
+func (e *Experience) Overlaps(other *Experience) bool {
+	endsAfterOtherStarts := e.UntilNow || e.EndYear.Time.After(other.StartYear)
+	otherEndsAfterStarts := other.UntilNow || other.EndYear.Time.After(e.StartYear)
+
+	return endsAfterOtherStarts && otherEndsAfterStarts
 }
@@ -0,0 +1,92 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+)
+
+func TestExperience_Overlaps(t *testing.T) {
+	date := func(s string) time.Time {
+		d, err := time.Parse(DateLayout, s)
+		require.NoError(t, err)
+		return d
+	}
+	newExperience := func(start, end string) *Experience {
+		if end == "" {
+			return &Experience{StartYear: date(start), UntilNow: true}
+		}
+		return &Experience{StartYear: date(start), EndYear: null.TimeFrom(date(end))}
+	}
+
+	tests := []struct {
+		name     string
+		first    *Experience
+		second   *Experience
+		expected bool
+	}{
+		{
+			name:     "overlapping ranges",
+			first:    newExperience("2020-01-01", "2021-06-30"),
+			second:   newExperience("2021-01-01", "2022-12-31"),
+			expected: true,
+		},
+		{
+			name:     "contained range",
+			first:    newExperience("2020-01-01", "2022-12-31"),
+			second:   newExperience("2021-01-01", "2021-06-30"),
+			expected: true,
+		},
+		{
+			name:     "separated ranges",
+			first:    newExperience("2020-01-01", "2020-12-31"),
+			second:   newExperience("2021-06-01", "2022-12-31"),
+			expected: false,
+		},
+		{
+			name:     "ended on the day the other starts",
+			first:    newExperience("2020-01-01", "2021-01-01"),
+			second:   newExperience("2021-01-01", "2022-12-31"),
+			expected: false,
+		},
+		{
+			name:     "ended the day after the other starts",
+			first:    newExperience("2020-01-01", "2021-01-02"),
+			second:   newExperience("2021-01-01", "2022-12-31"),
+			expected: true,
+		},
+		{
+			name:     "until now started before the other ends",
+			first:    newExperience("2021-01-01", ""),
+			second:   newExperience("2020-01-01", "2021-06-30"),
+			expected: true,
+		},
+		{
+			name:     "until now started on the day the other ends",
+			first:    newExperience("2021-06-30", ""),
+			second:   newExperience("2020-01-01", "2021-06-30"),
+			expected: false,
+		},
+		{
+			name:     "until now started after the other ends",
+			first:    newExperience("2022-01-01", ""),
+			second:   newExperience("2020-01-01", "2021-06-30"),
+			expected: false,
+		},
+		{
+			name:     "both until now",
+			first:    newExperience("2020-01-01", ""),
+			second:   newExperience("2022-01-01", ""),
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, tt.first.Overlaps(tt.second))
+			require.Equal(t, tt.expected, tt.second.Overlaps(tt.first))
+		})
+	}
+}
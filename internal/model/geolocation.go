@@ -0,0 +1,19 @@
+package model
+
+import "gopkg.in/guregu/null.v4"
+
+// GeoLocation the client's coordinates, the location is unknown when either coordinate is null
+type GeoLocation struct {
+	Latitude  null.Float
+	Longitude null.Float
+}
+
+// IsKnown check both coordinates are set
+func (g GeoLocation) IsKnown() bool {
+	return g.Latitude.Valid && g.Longitude.Valid
+}
+
+// IPLocator resolves the coarse location of the ip address
+type IPLocator interface {
+	Locate(ipAddress string) (GeoLocation, error)
+}
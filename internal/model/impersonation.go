@@ -0,0 +1,37 @@
+package model
+
+import (
+	"context"
+	"time"
+)
+
+type (
+	ImpersonationLogRepository interface {
+		Create(ctx context.Context, log *ImpersonationLog) error
+	}
+
+	// ImpersonationLog the audit record of an impersonation session created by an admin
+	ImpersonationLog struct {
+		ID             int64
+		ImpersonatorID int64
+		CandidateID    int64
+		SessionID      int64
+		Reason         string
+		IPAddress      string
+		UserAgent      string
+		ExpiredAt      time.Time
+		CreatedAt      time.Time
+	}
+)
+
+// ImpersonateRequest request
+type ImpersonateRequest struct {
+	Reason    string `json:"reason" validate:"required,max=500"`
+	UserAgent string `json:"user_agent"`
+	IPAddress string `json:"ip_address"`
+}
+
+// Validate validates the impersonate input body.
+func (r *ImpersonateRequest) Validate() error {
+	return validate.Struct(r)
+}
@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+// LoginRiskFlag the suspicious signal of the login compared to the candidate's recent sessions
+type LoginRiskFlag string
+
+// LoginRiskFlag constants
+const (
+	LoginRiskFlagNewDevice        LoginRiskFlag = "new_device"
+	LoginRiskFlagImpossibleTravel LoginRiskFlag = "impossible_travel"
+)
+
+// LoginAlert the suspicious login to be notified to the candidate
+type LoginAlert struct {
+	RiskFlags []LoginRiskFlag
+	IPAddress string
+	UserAgent string
+	CreatedAt time.Time
+}
@@ -0,0 +1,70 @@
+package model
+
+import (
+	"context"
+	"gopkg.in/guregu/null.v4"
+	"time"
+)
+
+type (
+	MFAUsecase interface {
+		EnrollTOTP(ctx context.Context, requester *Candidate) (*TOTPEnrollment, error)
+		ConfirmTOTP(ctx context.Context, requester *Candidate, code string) ([]string, error)
+		DisableTOTP(ctx context.Context, requester *Candidate, code string) error
+		RegenerateRecoveryCodes(ctx context.Context, requester *Candidate) ([]string, error)
+	}
+
+	MFARecoveryCodeRepository interface {
+		ReplaceAllByCandidateID(ctx context.Context, candidateID int64, codeHashes []string) error
+		MarkAsUsed(ctx context.Context, candidateID int64, codeHash string) (bool, error)
+		DeleteAllByCandidateID(ctx context.Context, candidateID int64) error
+	}
+
+	// MFARecoveryCode the single use code to complete the second factor when the authenticator is lost,
+	// only the hash is stored
+	MFARecoveryCode struct {
+		ID          int64
+		CandidateID int64
+		CodeHash    string
+		UsedAt      null.Time
+		CreatedAt   time.Time
+	}
+
+	// TOTPEnrollment the pending TOTP secret to be added to the authenticator app
+	TOTPEnrollment struct {
+		Secret string
+		URI    string
+	}
+
+	// MFAChallenge the challenge to be completed with the second factor before the session is created
+	MFAChallenge struct {
+		Token     string
+		Method    MFAChallengeMethod
+		RiskFlags []LoginRiskFlag
+		ExpiredAt time.Time
+	}
+)
+
+// MFAChallengeMethod the second factor to complete the challenge
+type MFAChallengeMethod string
+
+// MFAChallengeMethod constants
+const (
+	MFAChallengeMethodTOTP MFAChallengeMethod = "totp"
+	// MFAChallengeMethodSMS the step-up code sent to the phone of the candidate without TOTP on a suspicious login
+	MFAChallengeMethodSMS MFAChallengeMethod = "sms"
+)
+
+// VerifyMFAChallengeRequest request
+type VerifyMFAChallengeRequest struct {
+	ChallengeToken string `json:"challenge_token" validate:"required"`
+	Code           string `json:"code" validate:"required"` // either the TOTP code, a recovery code or the step-up code
+	UserAgent      string `json:"user_agent"`
+	IPAddress      string `json:"ip_address"`
+	GeoLocation
+}
+
+// Validate validates the verify mfa challenge input body.
+func (v *VerifyMFAChallengeRequest) Validate() error {
+	return validate.Struct(v)
+}
@@ -0,0 +1,18 @@
+package model
+
+import "context"
+
+// EmailSender sends email to the candidate
+type EmailSender interface {
+	SendEmail(ctx context.Context, to, subject, body string) error
+}
+
+// SMSSender sends short message to the candidate's phone
+type SMSSender interface {
+	SendSMS(ctx context.Context, to, message string) error
+}
+
+// LoginAlertNotifier notifies the candidate about the suspicious login
+type LoginAlertNotifier interface {
+	NotifyLoginAlert(ctx context.Context, candidate *Candidate, alert LoginAlert) error
+}
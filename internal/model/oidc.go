@@ -0,0 +1,77 @@
+package model
+
+import (
+	"context"
+	"gopkg.in/guregu/null.v4"
+	"time"
+)
+
+type (
+	CandidateIdentityRepository interface {
+		FindByProviderAndSubject(ctx context.Context, provider, subject string) (*CandidateIdentity, error)
+		Create(ctx context.Context, identity *CandidateIdentity) error
+	}
+
+	// OIDCProvider the OpenID Connect provider to sign in with, e.g. Google or LinkedIn
+	OIDCProvider interface {
+		Name() string
+		// AuthorizationURL the url to redirect the candidate to, the PKCE code challenge is derived from the code verifier
+		AuthorizationURL(state, nonce, codeVerifier string) string
+		// Authenticate exchange the authorization code, then verify the ID token against the nonce
+		Authenticate(ctx context.Context, code, codeVerifier, nonce string) (*OIDCClaims, error)
+	}
+
+	// CandidateIdentity the external identity linked to the candidate
+	CandidateIdentity struct {
+		ID          int64
+		CandidateID int64
+		Provider    string
+		Subject     string
+		Email       null.String
+		CreatedAt   time.Time `gorm:"->;<-:create"`
+		UpdatedAt   time.Time
+	}
+
+	// OIDCClaims the verified claims of the ID token
+	OIDCClaims struct {
+		Subject       string
+		Email         string
+		EmailVerified bool
+		Name          string
+	}
+
+	// OIDCAuthorization the pending authorization, the candidate is redirected to the authorization url
+	OIDCAuthorization struct {
+		Provider         string
+		AuthorizationURL string
+		State            string
+		ExpiredAt        time.Time
+	}
+)
+
+// OIDCLoginRequest request, the code and state are taken from the provider's redirect
+type OIDCLoginRequest struct {
+	Provider  string `json:"provider" validate:"required"`
+	Code      string `json:"code" validate:"required"`
+	State     string `json:"state" validate:"required"`
+	UserAgent string `json:"user_agent"`
+	IPAddress string `json:"ip_address"`
+	GeoLocation
+}
+
+// Validate validates the oidc login input body.
+func (r *OIDCLoginRequest) Validate() error {
+	return validate.Struct(r)
+}
+
+// OIDCLinkRequest request, the code and state are taken from the provider's redirect
+type OIDCLinkRequest struct {
+	Provider string `json:"provider" validate:"required"`
+	Code     string `json:"code" validate:"required"`
+	State    string `json:"state" validate:"required"`
+}
+
+// Validate validates the oidc link input body.
+func (r *OIDCLinkRequest) Validate() error {
+	return validate.Struct(r)
+}
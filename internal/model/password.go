@@ -0,0 +1,84 @@
+package model
+
+import (
+	"context"
+	"gopkg.in/guregu/null.v4"
+	"time"
+)
+
+type (
+	PasswordUsecase interface {
+		ForgotPassword(ctx context.Context, input ForgotPasswordInput) error
+		ResetPassword(ctx context.Context, input ResetPasswordInput) error
+		ChangePassword(ctx context.Context, requester *Candidate, input ChangePasswordInput) error
+	}
+
+	PasswordResetTokenRepository interface {
+		Create(ctx context.Context, token *PasswordResetToken) error
+		FindByToken(ctx context.Context, token string) (*PasswordResetToken, error)
+		MarkAsUsed(ctx context.Context, token *PasswordResetToken) (bool, error)
+	}
+
+	// PasswordHasher hash the passwords into the PHC string format with the current algorithm,
+	// the passwords hashed with the older algorithms or parameters are still verifiable
+	PasswordHasher interface {
+		Hash(plain string) (string, error)
+		Verify(plain, encoded string) (bool, error)
+		NeedsRehash(encoded string) bool
+	}
+
+	// BreachedPasswordChecker check whether the password is exposed on the known data breaches
+	BreachedPasswordChecker interface {
+		IsBreached(password string) (bool, error)
+	}
+
+	// PasswordResetToken the single use token to reset the candidate's password, only the hash is stored
+	PasswordResetToken struct {
+		ID          int64
+		CandidateID int64
+		TokenHash   string
+		ExpiredAt   time.Time
+		UsedAt      null.Time
+		CreatedAt   time.Time
+	}
+)
+
+// IsUsable check the token is not used nor expired
+func (t *PasswordResetToken) IsUsable() bool {
+	return !t.UsedAt.Valid && time.Now().Before(t.ExpiredAt)
+}
+
+// ForgotPasswordInput :nodoc:
+type ForgotPasswordInput struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+// Validate validates the forgot password input body.
+func (f *ForgotPasswordInput) Validate() error {
+	return validate.Struct(f)
+}
+
+// ResetPasswordInput :nodoc:
+type ResetPasswordInput struct {
+	Token                string `json:"token" validate:"required"`
+	Password             string `json:"password" validate:"required"`
+	PasswordConfirmation string `json:"password_confirmation" validate:"required,eqfield=Password"`
+}
+
+// Validate validates the reset password input body, the password is checked against the password policy by the usecase.
+func (r *ResetPasswordInput) Validate() error {
+	return validate.Struct(r)
+}
+
+// ChangePasswordInput :nodoc:
+type ChangePasswordInput struct {
+	CurrentPassword      string `json:"current_password" validate:"required"`
+	Password             string `json:"password" validate:"required,nefield=CurrentPassword"`
+	PasswordConfirmation string `json:"password_confirmation" validate:"required,eqfield=Password"`
+	RevokeOtherSessions  bool   `json:"revoke_other_sessions"`
+}
+
+// Validate validates the change password input body, the password is checked against the password policy by the usecase.
+func (c *ChangePasswordInput) Validate() error {
+	return validate.Struct(c)
+}
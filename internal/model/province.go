@@ -1,9 +1,14 @@
 package model
 
 import (
+	"context"
 	"time"
 )
 
+type ProvinceRepository interface {
+	FindByID(ctx context.Context, id int64) (*Province, error)
+}
+
 type Province struct {
 	ID        int64
 	Name      string
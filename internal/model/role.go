@@ -0,0 +1,136 @@
+package model
+
+import (
+	"context"
+	"time"
+)
+
+type (
+	RoleUsecase interface {
+		FindAllByCandidateID(ctx context.Context, requester *Candidate, candidateID int64) ([]Role, error)
+		Assign(ctx context.Context, requester *Candidate, candidateID int64, role Role) error
+		Revoke(ctx context.Context, requester *Candidate, candidateID int64, role Role) error
+	}
+
+	RoleRepository interface {
+		FindAllByCandidateID(ctx context.Context, candidateID int64) ([]Role, error)
+		Create(ctx context.Context, candidateRole *CandidateRole) error
+		Delete(ctx context.Context, candidateID int64, role Role) error
+	}
+
+	// CandidateRole the role assigned to the candidate, the candidate role itself is implicit
+	CandidateRole struct {
+		CandidateID int64
+		Role        Role
+		CreatedAt   time.Time
+	}
+)
+
+// Role the principal's role
+type Role string
+
+// Role constants
+const (
+	RoleCandidate     Role = "candidate"
+	RoleRecruiter     Role = "recruiter"
+	RoleCompanyAdmin  Role = "company_admin"
+	RolePlatformAdmin Role = "platform_admin"
+)
+
+// Permission the action allowed to the principal, formatted as resource:action
+type Permission string
+
+// Permission constants
+const (
+	PermissionProfileRead    Permission = "profile:read"
+	PermissionProfileWrite   Permission = "profile:write"
+	PermissionCandidateRead  Permission = "candidate:read"
+	PermissionCandidateWrite Permission = "candidate:write"
+	PermissionJobWrite       Permission = "job:write"
+	PermissionCompanyManage  Permission = "company:manage"
+	PermissionRoleManage     Permission = "role:manage"
+
+	PermissionCandidateImpersonate Permission = "candidate:impersonate"
+	PermissionAuditRead            Permission = "audit:read"
+	PermissionAPIKeyManage         Permission = "api_key:manage"
+)
+
+var rolePermissions = map[Role][]Permission{
+	RoleCandidate: {
+		PermissionProfileRead,
+		PermissionProfileWrite,
+	},
+	RoleRecruiter: {
+		PermissionCandidateRead,
+		PermissionJobWrite,
+	},
+	RoleCompanyAdmin: {
+		PermissionCandidateRead,
+		PermissionJobWrite,
+		PermissionCompanyManage,
+	},
+	RolePlatformAdmin: {
+		PermissionCandidateRead,
+		PermissionCandidateWrite,
+		PermissionJobWrite,
+		PermissionCompanyManage,
+		PermissionRoleManage,
+		PermissionCandidateImpersonate,
+		PermissionAuditRead,
+		PermissionAPIKeyManage,
+	},
+}
+
+// apiKeyScopes the permissions allowed to be granted to the api key, only the ones checked by the partner endpoints.
+// The candidate's own profile and the administration permissions are never granted to a machine.
+var apiKeyScopes = []Permission{
+	PermissionCandidateRead,
+}
+
+// IsValid check the role is known
+func (r Role) IsValid() bool {
+	_, ok := rolePermissions[r]
+	return ok
+}
+
+// Permissions return the permissions granted to the role
+func (r Role) Permissions() []Permission {
+	return rolePermissions[r]
+}
+
+// IsAPIKeyScope check the permission is allowed to be granted to the api key
+func (p Permission) IsAPIKeyScope() bool {
+	for _, scope := range apiKeyScopes {
+		if scope == p {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FilterAPIKeyScopes return only the scopes still allowed to be granted to the api key,
+// so the scope removed from the allowed scopes is no longer granted to the existing api keys
+func FilterAPIKeyScopes(scopes []Permission) []Permission {
+	filtered := make([]Permission, 0, len(scopes))
+	for _, scope := range scopes {
+		if scope.IsAPIKeyScope() {
+			filtered = append(filtered, scope)
+		}
+	}
+
+	return filtered
+}
+
+// HasPermission check whether any of the roles is granted the permission
+func HasPermission(roles []Role, permission Permission) bool {
+	for _, role := range roles {
+		for _, p := range rolePermissions[role] {
+			if p == permission {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -3,6 +3,7 @@ package model
 import (
 	"context"
 	"fmt"
+	"gopkg.in/guregu/null.v4"
 	"time"
 )
 
@@ -15,6 +16,20 @@ type SessionRepository interface {
 	RefreshToken(ctx context.Context, oldSess, sess *Session) (*Session, error)
 	DeleteByCandidateIDAndMaxRemainderSession(ctx context.Context, userID int64, maxRemainderSess int) error
 	Delete(ctx context.Context, session *Session) error
+	FindAllActiveByCandidateID(ctx context.Context, candidateID int64) ([]*Session, error)
+	DeleteAllByCandidateID(ctx context.Context, candidateID int64, exceptIDs ...int64) error
+	FindSupersededRefreshToken(ctx context.Context, refreshToken string) (*SupersededRefreshToken, error)
+	DeleteExpiredSupersededRefreshTokens(ctx context.Context, expiredBefore time.Time, batchSize int) (int64, error)
+}
+
+// AccessTokenSigner signs the session's access token, so it can be verified without looking up the session
+type AccessTokenSigner interface {
+	SignAccessToken(session *Session) (string, error)
+}
+
+// SessionCleaner cleans up the candidate's sessions exceeding the max active session
+type SessionCleaner interface {
+	EnqueueCleanup(candidateID int64)
 }
 
 // Session the user's session
@@ -26,13 +41,27 @@ type Session struct {
 	AccessTokenExpiredAt  time.Time
 	RefreshTokenExpiredAt time.Time
 	UserAgent             string
-	Latitude              string
-	Longitude             string
+	Latitude              null.Float
+	Longitude             null.Float
 	IPAddress             string
+	MFAAuthenticated      bool
+	Roles                 []Role `gorm:"serializer:json"`
+	ImpersonatorID        null.Int
+	RiskFlags             []LoginRiskFlag `gorm:"serializer:json"`
 	CreatedAt             time.Time
 	UpdatedAt             time.Time
 }
 
+// SupersededRefreshToken the hashed refresh token which has been rotated,
+// used to detect a refresh token reuse
+type SupersededRefreshToken struct {
+	TokenHash   string
+	SessionID   int64
+	CandidateID int64
+	ExpiredAt   time.Time
+	CreatedAt   time.Time
+}
+
 // TokenType type of token
 type TokenType int
 
@@ -47,6 +76,11 @@ func (s *Session) IsAccessTokenExpired() bool {
 	return time.Now().After(s.AccessTokenExpiredAt)
 }
 
+// IsImpersonated check the session is created by an admin on behalf of the candidate
+func (s *Session) IsImpersonated() bool {
+	return s.ImpersonatorID.Valid
+}
+
 // NewSessionTokenCacheKey return cache key for session token
 func NewSessionTokenCacheKey(token string) string {
 	return fmt.Sprintf("cache:id:session_token:%s", token)
@@ -3,6 +3,7 @@ package model
 import (
 	"github.com/go-playground/validator/v10"
 	"github.com/irvankadhafi/talent-hub-service/internal/config"
+	"reflect"
 	"regexp"
 	"strings"
 	"sync"
@@ -20,6 +21,9 @@ func init() {
 	initOnce.Do(func() {
 		validate = validator.New()
 
+		// report the json field name on validation errors, so it can be shown to the client as is
+		validate.RegisterTagNameFunc(jsonTagName)
+
 		_ = validate.RegisterValidation("phonenumber", isPhoneValid)
 
 		_ = validate.RegisterValidation("emailEligibility", isEmailValid)
@@ -64,3 +68,12 @@ func isEmailValid(fl validator.FieldLevel) bool {
 func validateIdentifier(fl validator.FieldLevel) bool {
 	return isEmailValid(fl) || isPhoneValid(fl)
 }
+
+func jsonTagName(fld reflect.StructField) string {
+	name := strings.SplitN(fld.Tag.Get("json"), ",", 2)[0]
+	if name == "-" {
+		return ""
+	}
+
+	return name
+}
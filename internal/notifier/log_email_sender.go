@@ -0,0 +1,47 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"github.com/irvankadhafi/talent-hub-service/internal/model"
+	"github.com/sirupsen/logrus"
+	"os"
+	"sync"
+	"time"
+)
+
+type logEmailSender struct {
+	filePath string
+	mu       sync.Mutex
+}
+
+// NewLogEmailSender create email sender for development, the email is appended to the file
+// or written to the log when the file path is empty
+func NewLogEmailSender(filePath string) model.EmailSender {
+	return &logEmailSender{
+		filePath: filePath,
+	}
+}
+
+// SendEmail writes the email instead of sending it
+func (l *logEmailSender) SendEmail(ctx context.Context, to, subject, body string) error {
+	if l.filePath == "" {
+		logrus.WithFields(logrus.Fields{
+			"to":      to,
+			"subject": subject,
+		}).Info(body)
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	file, err := os.OpenFile(l.filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = fmt.Fprintf(file, "Date: %s\nTo: %s\nSubject: %s\n\n%s\n\n", time.Now().Format(time.RFC3339), to, subject, body)
+	return err
+}
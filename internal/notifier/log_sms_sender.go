@@ -0,0 +1,20 @@
+package notifier
+
+import (
+	"context"
+	"github.com/irvankadhafi/talent-hub-service/internal/model"
+	"github.com/sirupsen/logrus"
+)
+
+type logSMSSender struct{}
+
+// NewLogSMSSender create sms sender for development, the message is written to the log instead
+func NewLogSMSSender() model.SMSSender {
+	return &logSMSSender{}
+}
+
+// SendSMS writes the message to the log
+func (l *logSMSSender) SendSMS(ctx context.Context, to, message string) error {
+	logrus.WithField("to", to).Info(message)
+	return nil
+}
@@ -0,0 +1,53 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"github.com/irvankadhafi/talent-hub-service/internal/model"
+	"strings"
+	"time"
+)
+
+type loginAlertNotifier struct {
+	emailSender model.EmailSender
+	smsSender   model.SMSSender
+}
+
+// NewLoginAlertNotifier create login alert notifier, the alert is sent to the email or to the phone when the email is not set
+func NewLoginAlertNotifier(emailSender model.EmailSender, smsSender model.SMSSender) model.LoginAlertNotifier {
+	return &loginAlertNotifier{
+		emailSender: emailSender,
+		smsSender:   smsSender,
+	}
+}
+
+// NotifyLoginAlert sends the suspicious login alert to the candidate
+func (l *loginAlertNotifier) NotifyLoginAlert(ctx context.Context, candidate *model.Candidate, alert model.LoginAlert) error {
+	switch {
+	case candidate.Email.Valid:
+		return l.emailSender.SendEmail(ctx, candidate.Email.String, "New sign-in to your Talent Hub account", newLoginAlertEmailBody(alert))
+	case candidate.Phone.Valid:
+		message := fmt.Sprintf("Talent Hub: a new sign-in to your account from %s at %s. If it wasn't you, change your password now.",
+			alert.IPAddress, alert.CreatedAt.Format(time.RFC1123))
+		return l.smsSender.SendSMS(ctx, candidate.Phone.String, message)
+	default:
+		return nil
+	}
+}
+
+func newLoginAlertEmailBody(alert model.LoginAlert) string {
+	reasons := make([]string, 0, len(alert.RiskFlags))
+	for _, flag := range alert.RiskFlags {
+		switch flag {
+		case model.LoginRiskFlagNewDevice:
+			reasons = append(reasons, "- the sign-in is from a device you haven't used before")
+		case model.LoginRiskFlagImpossibleTravel:
+			reasons = append(reasons, "- the sign-in is from a location too far from your last activity")
+		}
+	}
+
+	return fmt.Sprintf("We noticed a new sign-in to your account.\n\n"+
+		"Time: %s\nIP address: %s\nDevice: %s\n\n%s\n\n"+
+		"If this was you, you can ignore this email. Otherwise, change your password and revoke the session immediately.",
+		alert.CreatedAt.Format(time.RFC1123), alert.IPAddress, alert.UserAgent, strings.Join(reasons, "\n"))
+}
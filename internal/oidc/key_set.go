@@ -0,0 +1,120 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// minKeySetRefreshInterval limits the JWKS refetch when the ID token is signed with an unknown key
+const minKeySetRefreshInterval = 1 * time.Minute
+
+type jsonWebKey struct {
+	KeyType string `json:"kty"`
+	KeyID   string `json:"kid"`
+	Use     string `json:"use"`
+	N       string `json:"n"`
+	E       string `json:"e"`
+}
+
+// remoteKeySet the provider's RSA public keys fetched from the JWKS uri,
+// the keys are refetched when the provider rotates the signing key
+type remoteKeySet struct {
+	jwksURI    string
+	httpClient *http.Client
+
+	mu          sync.Mutex
+	keys        map[string]*rsa.PublicKey
+	refreshedAt time.Time
+}
+
+func newRemoteKeySet(jwksURI string, httpClient *http.Client) *remoteKeySet {
+	return &remoteKeySet{
+		jwksURI:    jwksURI,
+		httpClient: httpClient,
+	}
+}
+
+// findKey find the key by the key id, the key set is refetched once when the key is not found
+func (r *remoteKeySet) findKey(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if key, ok := r.keys[kid]; ok {
+		return key, nil
+	}
+
+	if time.Since(r.refreshedAt) < minKeySetRefreshInterval {
+		return nil, fmt.Errorf("%w: signing key %q is not found", ErrIDTokenInvalid, kid)
+	}
+
+	keys, err := r.fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.keys = keys
+	r.refreshedAt = time.Now()
+
+	key, ok := r.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("%w: signing key %q is not found", ErrIDTokenInvalid, kid)
+	}
+
+	return key, nil
+}
+
+func (r *remoteKeySet) fetch(ctx context.Context) (map[string]*rsa.PublicKey, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.jwksURI, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close() // nolint:errcheck
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch jwks: status %d", resp.StatusCode)
+	}
+
+	jwks := struct {
+		Keys []jsonWebKey `json:"keys"`
+	}{}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(&jwks); err != nil {
+		return nil, err
+	}
+
+	keys := map[string]*rsa.PublicKey{}
+	for _, jwk := range jwks.Keys {
+		if jwk.KeyType != "RSA" || (jwk.Use != "" && jwk.Use != "sig") {
+			continue
+		}
+
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil {
+			continue
+		}
+
+		e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil {
+			continue
+		}
+
+		keys[jwk.KeyID] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+
+	return keys, nil
+}
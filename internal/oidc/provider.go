@@ -0,0 +1,201 @@
+package oidc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt"
+	"github.com/irvankadhafi/talent-hub-service/internal/model"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// maxResponseSize limits the token and JWKS response body read from the provider
+const maxResponseSize = 1 << 20
+
+// oidc errors
+var (
+	ErrTokenExchangeFailed = errors.New("authorization code exchange is failed")
+	ErrIDTokenInvalid      = errors.New("id token is invalid")
+)
+
+// ProviderConfig the relying party config of the provider, the endpoints are set explicitly
+// so a local stub provider can be used on the development and testing
+type ProviderConfig struct {
+	Name                  string
+	Issuer                string
+	ClientID              string
+	ClientSecret          string
+	AuthorizationEndpoint string
+	TokenEndpoint         string
+	JWKSURI               string
+	RedirectURL           string
+	Scopes                []string
+}
+
+// Provider the OpenID Connect relying party using the authorization code flow with PKCE
+type Provider struct {
+	config     ProviderConfig
+	httpClient *http.Client
+	keySet     *remoteKeySet
+}
+
+// NewProvider Provider constructor
+func NewProvider(config ProviderConfig, httpClient *http.Client) *Provider {
+	if len(config.Scopes) == 0 {
+		config.Scopes = []string{"openid", "email", "profile"}
+	}
+
+	return &Provider{
+		config:     config,
+		httpClient: httpClient,
+		keySet:     newRemoteKeySet(config.JWKSURI, httpClient),
+	}
+}
+
+// Name the provider name, e.g. google
+func (p *Provider) Name() string {
+	return p.config.Name
+}
+
+// AuthorizationURL the url to redirect the candidate to, the code challenge is derived from the code verifier with S256
+func (p *Provider) AuthorizationURL(state, nonce, codeVerifier string) string {
+	query := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {p.config.ClientID},
+		"redirect_uri":          {p.config.RedirectURL},
+		"scope":                 {strings.Join(p.config.Scopes, " ")},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {CodeChallengeS256(codeVerifier)},
+		"code_challenge_method": {"S256"},
+	}
+
+	separator := "?"
+	if strings.Contains(p.config.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+
+	return p.config.AuthorizationEndpoint + separator + query.Encode()
+}
+
+// Authenticate exchange the authorization code with the code verifier, then verify the ID token against the nonce
+func (p *Provider) Authenticate(ctx context.Context, code, codeVerifier, nonce string) (*model.OIDCClaims, error) {
+	rawIDToken, err := p.exchange(ctx, code, codeVerifier)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.verifyIDToken(ctx, rawIDToken, nonce)
+}
+
+type tokenResponse struct {
+	IDToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// exchange the authorization code for the ID token, the client is authenticated with client_secret_post
+func (p *Provider) exchange(ctx context.Context, code, codeVerifier string) (string, error) {
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {p.config.RedirectURL},
+		"client_id":     {p.config.ClientID},
+		"client_secret": {p.config.ClientSecret},
+		"code_verifier": {codeVerifier},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.config.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close() // nolint:errcheck
+
+	res := tokenResponse{}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(&res); err != nil {
+		return "", fmt.Errorf("%w: status %d", ErrTokenExchangeFailed, resp.StatusCode)
+	}
+
+	if resp.StatusCode != http.StatusOK || res.Error != "" {
+		return "", fmt.Errorf("%w: %s %s", ErrTokenExchangeFailed, res.Error, res.ErrorDescription)
+	}
+
+	if res.IDToken == "" {
+		return "", fmt.Errorf("%w: id token is not returned", ErrTokenExchangeFailed)
+	}
+
+	return res.IDToken, nil
+}
+
+// verifyIDToken verify the ID token's signature, issuer, audience, expiry and nonce
+func (p *Provider) verifyIDToken(ctx context.Context, rawIDToken, nonce string) (*model.OIDCClaims, error) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(rawIDToken, claims, func(token *jwt.Token) (interface{}, error) {
+		if token.Method != jwt.SigningMethodRS256 {
+			return nil, ErrIDTokenInvalid
+		}
+
+		kid, _ := token.Header["kid"].(string)
+		return p.keySet.findKey(ctx, kid)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrIDTokenInvalid, err)
+	}
+
+	if !claims.VerifyIssuer(p.config.Issuer, true) || !claims.VerifyAudience(p.config.ClientID, true) {
+		return nil, fmt.Errorf("%w: issuer or audience is not match", ErrIDTokenInvalid)
+	}
+
+	// the expiry is optional on the jwt, but it's required on the ID token
+	if !claims.VerifyExpiresAt(time.Now().Unix(), true) {
+		return nil, fmt.Errorf("%w: expiry is not set", ErrIDTokenInvalid)
+	}
+
+	if tokenNonce, _ := claims["nonce"].(string); tokenNonce == "" || tokenNonce != nonce {
+		return nil, fmt.Errorf("%w: nonce is not match", ErrIDTokenInvalid)
+	}
+
+	subject, _ := claims["sub"].(string)
+	if subject == "" {
+		return nil, fmt.Errorf("%w: subject is not set", ErrIDTokenInvalid)
+	}
+
+	email, _ := claims["email"].(string)
+	name, _ := claims["name"].(string)
+
+	// some providers encode the email_verified claim as a string
+	var emailVerified bool
+	switch v := claims["email_verified"].(type) {
+	case bool:
+		emailVerified = v
+	case string:
+		emailVerified = v == "true"
+	}
+
+	return &model.OIDCClaims{
+		Subject:       subject,
+		Email:         email,
+		EmailVerified: emailVerified,
+		Name:          name,
+	}, nil
+}
+
+// CodeChallengeS256 derive the PKCE code challenge from the code verifier
+func CodeChallengeS256(codeVerifier string) string {
+	sum := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
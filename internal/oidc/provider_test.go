@@ -0,0 +1,149 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/require"
+)
+
+// stubProvider a local OpenID Connect provider issuing the ID token signed with the generated key
+type stubProvider struct {
+	server *httptest.Server
+	key    *rsa.PrivateKey
+	claims jwt.MapClaims
+}
+
+func newStubProvider(t *testing.T) *stubProvider {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	stub := &stubProvider{key: key}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": "stub-key",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if r.PostFormValue("code") != "valid-code" || r.PostFormValue("code_verifier") != "verifier" {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, stub.claims)
+		token.Header["kid"] = "stub-key"
+		idToken, err := token.SignedString(key)
+		require.NoError(t, err)
+
+		_ = json.NewEncoder(w).Encode(map[string]string{"id_token": idToken})
+	})
+	stub.server = httptest.NewServer(mux)
+	t.Cleanup(stub.server.Close)
+
+	return stub
+}
+
+func (s *stubProvider) provider() *Provider {
+	return NewProvider(ProviderConfig{
+		Name:                  "stub",
+		Issuer:                s.server.URL,
+		ClientID:              "client-id",
+		ClientSecret:          "client-secret",
+		AuthorizationEndpoint: s.server.URL + "/authorize",
+		TokenEndpoint:         s.server.URL + "/token",
+		JWKSURI:               s.server.URL + "/jwks",
+		RedirectURL:           "http://localhost/callback",
+	}, s.server.Client())
+}
+
+func (s *stubProvider) validClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss":            s.server.URL,
+		"aud":            "client-id",
+		"sub":            "subject-1",
+		"exp":            time.Now().Add(time.Hour).Unix(),
+		"nonce":          "nonce",
+		"email":          "john.doe@mail.com",
+		"email_verified": "true",
+		"name":           "John Doe",
+	}
+}
+
+func TestOIDC_Provider_AuthorizationURL(t *testing.T) {
+	stub := newStubProvider(t)
+
+	authorizationURL, err := url.Parse(stub.provider().AuthorizationURL("state", "nonce", "verifier"))
+	require.NoError(t, err)
+
+	query := authorizationURL.Query()
+	require.Equal(t, "code", query.Get("response_type"))
+	require.Equal(t, "openid email profile", query.Get("scope"))
+	require.Equal(t, "state", query.Get("state"))
+	require.Equal(t, "nonce", query.Get("nonce"))
+	require.Equal(t, CodeChallengeS256("verifier"), query.Get("code_challenge"))
+	require.Equal(t, "S256", query.Get("code_challenge_method"))
+}
+
+func TestOIDC_Provider_Authenticate(t *testing.T) {
+	stub := newStubProvider(t)
+	ctx := context.TODO()
+
+	t.Run("success", func(t *testing.T) {
+		stub.claims = stub.validClaims()
+
+		claims, err := stub.provider().Authenticate(ctx, "valid-code", "verifier", "nonce")
+		require.NoError(t, err)
+		require.Equal(t, "subject-1", claims.Subject)
+		require.Equal(t, "john.doe@mail.com", claims.Email)
+		require.True(t, claims.EmailVerified)
+		require.Equal(t, "John Doe", claims.Name)
+	})
+
+	t.Run("failed, code exchange", func(t *testing.T) {
+		stub.claims = stub.validClaims()
+
+		_, err := stub.provider().Authenticate(ctx, "invalid-code", "verifier", "nonce")
+		require.ErrorIs(t, err, ErrTokenExchangeFailed)
+	})
+
+	t.Run("failed, nonce not match", func(t *testing.T) {
+		stub.claims = stub.validClaims()
+
+		_, err := stub.provider().Authenticate(ctx, "valid-code", "verifier", "other-nonce")
+		require.ErrorIs(t, err, ErrIDTokenInvalid)
+	})
+
+	t.Run("failed, audience not match", func(t *testing.T) {
+		stub.claims = stub.validClaims()
+		stub.claims["aud"] = "other-client-id"
+
+		_, err := stub.provider().Authenticate(ctx, "valid-code", "verifier", "nonce")
+		require.ErrorIs(t, err, ErrIDTokenInvalid)
+	})
+
+	t.Run("failed, expired", func(t *testing.T) {
+		stub.claims = stub.validClaims()
+		stub.claims["exp"] = time.Now().Add(-time.Hour).Unix()
+
+		_, err := stub.provider().Authenticate(ctx, "valid-code", "verifier", "nonce")
+		require.ErrorIs(t, err, ErrIDTokenInvalid)
+	})
+}
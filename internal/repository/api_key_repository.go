@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"github.com/irvankadhafi/talent-hub-service/internal/config"
+	"github.com/irvankadhafi/talent-hub-service/internal/model"
+	"github.com/irvankadhafi/talent-hub-service/pkg/cacher"
+	"github.com/irvankadhafi/talent-hub-service/utils"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"time"
+)
+
+type apiKeyRepository struct {
+	db           *gorm.DB
+	cacheManager cacher.CacheManager
+}
+
+// NewAPIKeyRepository apiKeyRepository constructor
+func NewAPIKeyRepository(
+	db *gorm.DB,
+	cacheManager cacher.CacheManager,
+) model.APIKeyRepository {
+	return &apiKeyRepository{
+		db:           db,
+		cacheManager: cacheManager,
+	}
+}
+
+// FindAll find all the api keys, including the revoked and expired keys
+func (a *apiKeyRepository) FindAll(ctx context.Context) ([]*model.APIKey, error) {
+	var apiKeys []*model.APIKey
+	if err := a.db.WithContext(ctx).Order("created_at DESC").Find(&apiKeys).Error; err != nil {
+		logrus.WithField("ctx", utils.DumpIncomingContext(ctx)).Error(err)
+		return nil, err
+	}
+
+	return apiKeys, nil
+}
+
+func (a *apiKeyRepository) FindByID(ctx context.Context, id int64) (*model.APIKey, error) {
+	apiKey := &model.APIKey{}
+	err := a.db.WithContext(ctx).Take(apiKey, "id = ?", id).Error
+	switch err {
+	case nil:
+		return apiKey, nil
+	case gorm.ErrRecordNotFound:
+		return nil, nil
+	default:
+		logrus.WithFields(logrus.Fields{
+			"ctx": utils.DumpIncomingContext(ctx),
+			"id":  id,
+		}).Error(err)
+		return nil, err
+	}
+}
+
+// FindByPrefix find the api key by the prefix, the key is looked up on every request authenticated with the api key
+func (a *apiKeyRepository) FindByPrefix(ctx context.Context, prefix string) (*model.APIKey, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":    utils.DumpIncomingContext(ctx),
+		"prefix": prefix,
+	})
+
+	cacheKey := a.newCacheKeyByPrefix(prefix)
+	if !config.DisableCaching() {
+		reply, mu, err := findFromCacheByKey[*model.APIKey](a.cacheManager, cacheKey)
+		if err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+
+		defer cacher.SafeUnlock(mu)
+
+		if mu == nil {
+			return reply, nil
+		}
+	}
+
+	apiKey := &model.APIKey{}
+	err := a.db.WithContext(ctx).Take(apiKey, "prefix = ?", prefix).Error
+	switch err {
+	case nil:
+	case gorm.ErrRecordNotFound:
+		storeNilCache(a.cacheManager, cacheKey)
+		return nil, nil
+	default:
+		logger.Error(err)
+		return nil, err
+	}
+
+	if err := a.cacheManager.StoreWithoutBlocking(cacher.NewItem(cacheKey, utils.Dump(apiKey))); err != nil {
+		logger.Error(err)
+	}
+
+	return apiKey, nil
+}
+
+func (a *apiKeyRepository) Create(ctx context.Context, apiKey *model.APIKey) error {
+	if err := a.db.WithContext(ctx).Create(apiKey).Error; err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":    utils.DumpIncomingContext(ctx),
+			"prefix": apiKey.Prefix,
+		}).Error(err)
+		return err
+	}
+
+	// the not found key may have been cached by the lookup of the same prefix
+	a.deleteCache(apiKey)
+
+	return nil
+}
+
+// Update update the expiry and the revocation of the api key
+func (a *apiKeyRepository) Update(ctx context.Context, apiKey *model.APIKey) error {
+	err := a.db.WithContext(ctx).Model(apiKey).Select("expired_at", "revoked_at", "updated_at").Updates(apiKey).Error
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx": utils.DumpIncomingContext(ctx),
+			"id":  apiKey.ID,
+		}).Error(err)
+		return err
+	}
+
+	a.deleteCache(apiKey)
+
+	return nil
+}
+
+// UpdateLastUsedAt update the last used time of the api key to now
+func (a *apiKeyRepository) UpdateLastUsedAt(ctx context.Context, apiKey *model.APIKey) error {
+	err := a.db.WithContext(ctx).Model(model.APIKey{}).Where("id = ?", apiKey.ID).UpdateColumn("last_used_at", time.Now()).Error
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx": utils.DumpIncomingContext(ctx),
+			"id":  apiKey.ID,
+		}).Error(err)
+		return err
+	}
+
+	a.deleteCache(apiKey)
+
+	return nil
+}
+
+func (a *apiKeyRepository) deleteCache(apiKey *model.APIKey) {
+	if err := a.cacheManager.DeleteByKeys([]string{a.newCacheKeyByPrefix(apiKey.Prefix)}); err != nil {
+		logrus.WithField("prefix", apiKey.Prefix).Error(err)
+	}
+}
+
+func (a *apiKeyRepository) newCacheKeyByPrefix(prefix string) string {
+	return fmt.Sprintf("cache:object:api_key:prefix:%s", prefix)
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"github.com/irvankadhafi/talent-hub-service/internal/model"
+	"github.com/irvankadhafi/talent-hub-service/utils"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"time"
+)
+
+type authEventRepository struct {
+	db *gorm.DB
+}
+
+// NewAuthEventRepository authEventRepository constructor
+func NewAuthEventRepository(db *gorm.DB) model.AuthEventRepository {
+	return &authEventRepository{
+		db: db,
+	}
+}
+
+func (a *authEventRepository) Create(ctx context.Context, event *model.AuthEvent) error {
+	if err := a.db.WithContext(ctx).Create(event).Error; err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":         utils.DumpIncomingContext(ctx),
+			"eventType":   event.EventType,
+			"candidateID": event.CandidateID.Int64,
+		}).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// FindAllByCriteria find the auth events matching the criteria ordered by the newest, along with the total count
+func (a *authEventRepository) FindAllByCriteria(ctx context.Context, criteria model.AuthEventCriteria) ([]*model.AuthEvent, int64, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":      utils.DumpIncomingContext(ctx),
+		"criteria": utils.Dump(criteria),
+	})
+
+	scope := a.db.WithContext(ctx).Model(&model.AuthEvent{})
+	if criteria.CandidateID > 0 {
+		scope = scope.Where("candidate_id = ?", criteria.CandidateID)
+	}
+	if criteria.EventType != "" {
+		scope = scope.Where("event_type = ?", criteria.EventType)
+	}
+	if criteria.Outcome != "" {
+		scope = scope.Where("outcome = ?", criteria.Outcome)
+	}
+
+	var count int64
+	if err := scope.Count(&count).Error; err != nil {
+		logger.Error(err)
+		return nil, 0, err
+	}
+
+	if count == 0 {
+		return nil, 0, nil
+	}
+
+	var events []*model.AuthEvent
+	err := scope.
+		Order("created_at DESC, id DESC").
+		Offset(int(criteria.Offset())).
+		Limit(int(criteria.Size)).
+		Find(&events).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, 0, err
+	}
+
+	return events, count, nil
+}
+
+// DeleteCreatedBefore delete at most batchSize auth events created before the given time,
+// return the number of deleted events so the caller can continue until nothing left
+func (a *authEventRepository) DeleteCreatedBefore(ctx context.Context, createdBefore time.Time, batchSize int) (int64, error) {
+	subQuery := a.db.Model(&model.AuthEvent{}).
+		Select("id").
+		Where("created_at < ?", createdBefore).
+		Limit(batchSize)
+
+	res := a.db.WithContext(ctx).Where("id IN (?)", subQuery).Delete(&model.AuthEvent{})
+	if res.Error != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":           utils.DumpIncomingContext(ctx),
+			"createdBefore": createdBefore,
+			"batchSize":     batchSize,
+		}).Error(res.Error)
+		return 0, res.Error
+	}
+
+	return res.RowsAffected, nil
+}
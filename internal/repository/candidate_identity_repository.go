@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	"github.com/irvankadhafi/talent-hub-service/internal/model"
+	"github.com/irvankadhafi/talent-hub-service/utils"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type candidateIdentityRepository struct {
+	db *gorm.DB
+}
+
+// NewCandidateIdentityRepository candidateIdentityRepository constructor
+func NewCandidateIdentityRepository(db *gorm.DB) model.CandidateIdentityRepository {
+	return &candidateIdentityRepository{
+		db: db,
+	}
+}
+
+// FindByProviderAndSubject find the identity linked by the provider's subject
+func (c *candidateIdentityRepository) FindByProviderAndSubject(ctx context.Context, provider, subject string) (*model.CandidateIdentity, error) {
+	identity := &model.CandidateIdentity{}
+	err := c.db.WithContext(ctx).Take(identity, "provider = ? AND subject = ?", provider, subject).Error
+	switch err {
+	case nil:
+		return identity, nil
+	case gorm.ErrRecordNotFound:
+		return nil, nil
+	default:
+		logrus.WithFields(logrus.Fields{
+			"ctx":      utils.DumpIncomingContext(ctx),
+			"provider": provider,
+			"subject":  subject,
+		}).Error(err)
+		return nil, err
+	}
+}
+
+// Create link the identity to the candidate
+func (c *candidateIdentityRepository) Create(ctx context.Context, identity *model.CandidateIdentity) error {
+	if err := c.db.WithContext(ctx).Create(identity).Error; err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":         utils.DumpIncomingContext(ctx),
+			"candidateID": identity.CandidateID,
+			"provider":    identity.Provider,
+		}).Error(err)
+		return err
+	}
+
+	return nil
+}
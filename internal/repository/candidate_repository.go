@@ -8,6 +8,7 @@ import (
 	"github.com/irvankadhafi/talent-hub-service/pkg/cacher"
 	"github.com/irvankadhafi/talent-hub-service/utils"
 	"github.com/sirupsen/logrus"
+	"gopkg.in/guregu/null.v4"
 	"gorm.io/gorm"
 )
 
@@ -246,12 +247,18 @@ func (c *candidateRepository) FindUnscopedByPhone(ctx context.Context, phone str
 }
 
 func (c *candidateRepository) Create(ctx context.Context, candidate *model.Candidate) error {
-	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+	logger := logrus.WithFields(logrus.Fields{
 		"ctx":       utils.DumpIncomingContext(ctx),
 		"candidate": utils.Dump(candidate),
 	})
 
-	if err := c.db.WithContext(ctx).Create(candidate).Error; err != nil {
+	// the candidate signed in with the external identity has not set the gender, which is not a valid enum value
+	tx := c.db.WithContext(ctx)
+	if candidate.Gender == "" {
+		tx = tx.Omit("gender")
+	}
+
+	if err := tx.Create(candidate).Error; err != nil {
 		logger.Error(err)
 		return err
 	}
@@ -264,7 +271,7 @@ func (c *candidateRepository) Create(ctx context.Context, candidate *model.Candi
 }
 
 func (c *candidateRepository) Update(ctx context.Context, candidate *model.Candidate) error {
-	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+	logger := logrus.WithFields(logrus.Fields{
 		"ctx":       utils.DumpIncomingContext(ctx),
 		"candidate": utils.Dump(candidate),
 	})
@@ -282,11 +289,116 @@ func (c *candidateRepository) Update(ctx context.Context, candidate *model.Candi
 	return nil
 }
 
+// FindTOTPSecretByID find the encrypted TOTP secret of the candidate, the secret is not cached
+func (c *candidateRepository) FindTOTPSecretByID(ctx context.Context, id int64) (null.String, error) {
+	var secret null.String
+	err := c.db.WithContext(ctx).Model(model.Candidate{}).Select("totp_secret").Take(&secret, "id = ?", id).Error
+	switch err {
+	case nil, gorm.ErrRecordNotFound:
+		return secret, nil
+	default:
+		logrus.WithFields(logrus.Fields{
+			"ctx": utils.DumpIncomingContext(ctx),
+			"id":  id,
+		}).Error(err)
+		return secret, err
+	}
+}
+
+// UpdateTOTP updates the candidate's TOTP secret and enabled at, including the null values
+func (c *candidateRepository) UpdateTOTP(ctx context.Context, candidate *model.Candidate) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         utils.DumpIncomingContext(ctx),
+		"candidateID": candidate.ID,
+	})
+
+	if err := c.db.WithContext(ctx).Model(model.Candidate{}).Select("totp_secret", "totp_enabled_at", "updated_at").
+		Where("id = ?", candidate.ID).Updates(candidate).Error; err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if err := c.deleteCommonCache(candidate); err != nil {
+		logger.Error(err)
+	}
+
+	return nil
+}
+
+// UpdateProfile updates only the given columns of the candidate, including the zero and null values
+func (c *candidateRepository) UpdateProfile(ctx context.Context, candidate *model.Candidate, columns []string) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":       utils.DumpIncomingContext(ctx),
+		"candidate": utils.Dump(candidate),
+		"columns":   columns,
+	})
+
+	if err := c.db.WithContext(ctx).Model(model.Candidate{}).Select(append(columns, "updated_at")).
+		Where("id = ?", candidate.ID).Updates(candidate).Error; err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if err := c.deleteCommonCache(candidate); err != nil {
+		logger.Error(err)
+	}
+
+	return nil
+}
+
+// UpdatePassword updates the candidate's password, then replace the cached password
+func (c *candidateRepository) UpdatePassword(ctx context.Context, id int64, password string) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         utils.DumpIncomingContext(ctx),
+		"candidateID": id,
+	})
+
+	if err := c.db.WithContext(ctx).Model(model.Candidate{}).
+		Where("id = ?", id).Updates(model.Candidate{Password: password}).Error; err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if err := c.cacheManager.StoreWithoutBlocking(cacher.NewItem(c.newPasswordCacheKeyByID(id), password)); err != nil {
+		logger.Error(err)
+	}
+
+	return nil
+}
+
+// UpdateEmail updates the candidate's email and clears the email verified at,
+// the caches of both the previous and the new email are purged
+func (c *candidateRepository) UpdateEmail(ctx context.Context, candidate *model.Candidate, email string) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         utils.DumpIncomingContext(ctx),
+		"candidateID": candidate.ID,
+		"email":       email,
+	})
+
+	err := c.db.WithContext(ctx).Model(model.Candidate{}).Where("id = ?", candidate.ID).
+		Updates(map[string]interface{}{"email": email, "email_verified_at": nil}).Error
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if err := c.deleteCommonCache(candidate); err != nil {
+		logger.Error(err)
+	}
+
+	if err := c.cacheManager.DeleteByKeys([]string{c.newCacheKeyByEmail(email)}); err != nil {
+		logger.Error(err)
+	}
+
+	return nil
+}
+
 func (c *candidateRepository) deleteCommonCache(candidate *model.Candidate) error {
 	cacheKeys := []string{
 		c.newCacheKeyByID(candidate.ID),
 		c.newCacheKeyByEmail(candidate.Email.String),
 		c.newCacheKeyByPhone(candidate.Phone.String),
+		c.newPasswordCacheKeyByID(candidate.ID),
 	}
 
 	return c.cacheManager.DeleteByKeys(cacheKeys)
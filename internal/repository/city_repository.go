@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"github.com/irvankadhafi/talent-hub-service/internal/model"
+	"github.com/irvankadhafi/talent-hub-service/pkg/cacher"
+	"gorm.io/gorm"
+)
+
+type cityRepository struct {
+	db           *gorm.DB
+	cacheManager cacher.CacheManager
+}
+
+// NewCityRepository cityRepository constructor
+func NewCityRepository(
+	db *gorm.DB,
+	cacheManager cacher.CacheManager,
+) model.CityRepository {
+	return &cityRepository{
+		db:           db,
+		cacheManager: cacheManager,
+	}
+}
+
+func (r *cityRepository) FindByID(ctx context.Context, id int64) (*model.City, error) {
+	return findByIDWithCache[model.City](ctx, r.db, r.cacheManager, r.newCacheKeyByID(id), id)
+}
+
+func (r *cityRepository) newCacheKeyByID(id int64) string {
+	return fmt.Sprintf("cache:object:city:id:%d", id)
+}
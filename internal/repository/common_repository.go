@@ -1,10 +1,16 @@
 package repository
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"github.com/go-redsync/redsync/v4"
+	"github.com/irvankadhafi/talent-hub-service/internal/config"
+	"github.com/irvankadhafi/talent-hub-service/internal/model"
 	"github.com/irvankadhafi/talent-hub-service/pkg/cacher"
+	"github.com/irvankadhafi/talent-hub-service/utils"
 	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
 )
 
 func findFromCacheByKey[T any](cacheKeeper cacher.CacheManager, key string) (item T, mutex *redsync.Mutex, err error) {
@@ -27,8 +33,179 @@ func findFromCacheByKey[T any](cacheKeeper cacher.CacheManager, key string) (ite
 	return
 }
 
+// findByIDWithCache find the record by the id from the cache, then from the database on the cache miss.
+// The found record is cached, the missing record is cached as nil.
+func findByIDWithCache[T any](ctx context.Context, db *gorm.DB, cacheManager cacher.CacheManager, cacheKey string, id int64) (*T, error) {
+	if id <= 0 {
+		return nil, nil
+	}
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx": utils.DumpIncomingContext(ctx),
+		"id":  id,
+	})
+
+	if !config.DisableCaching() {
+		reply, mu, err := findFromCacheByKey[*T](cacheManager, cacheKey)
+		if err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+
+		defer cacher.SafeUnlock(mu)
+
+		if mu == nil {
+			return reply, nil
+		}
+	}
+
+	var record T
+	err := db.WithContext(ctx).Take(&record, "id = ?", id).Error
+	switch err {
+	case nil:
+	case gorm.ErrRecordNotFound:
+		storeNilCache(cacheManager, cacheKey)
+		return nil, nil
+	default:
+		logger.Error(err)
+		return nil, err
+	}
+
+	if err := cacheManager.StoreWithoutBlocking(cacher.NewItem(cacheKey, utils.Dump(record))); err != nil {
+		logger.Error(err)
+	}
+
+	return &record, nil
+}
+
+// findIDsByBucketWithCache find the ids and the total count of the page from the hash bucket of the list cache,
+// then with the findIDs on the cache miss. The found ids are cached as the page member of the bucket,
+// so the whole bucket is invalidated on a write.
+func findIDsByBucketWithCache(
+	ctx context.Context,
+	cacheManager cacher.CacheManager,
+	bucketKey string,
+	pagination model.Pagination,
+	findIDs func() ([]int64, int64, error),
+) ([]int64, int64, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":       utils.DumpIncomingContext(ctx),
+		"bucketKey": bucketKey,
+		"page":      pagination.Page,
+		"size":      pagination.Size,
+	})
+
+	memberKey := newCacheMemberKeyByPagination(pagination)
+	if !config.DisableCaching() {
+		reply, mu, err := cacheManager.GetHashMemberOrLock(bucketKey, memberKey)
+		if err != nil {
+			logger.Error(err)
+			return nil, 0, err
+		}
+
+		defer cacher.SafeUnlock(mu)
+
+		if mu == nil && reply != nil {
+			bt, _ := reply.([]byte)
+			mr, err := cacher.NewMultiResponseFromByte(bt)
+			if err == nil {
+				return mr.IDs, mr.Count, nil
+			}
+			logger.Error(err)
+		}
+	}
+
+	ids, count, err := findIDs()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err := cacheManager.StoreHashMember(bucketKey, cacher.NewItem(memberKey, cacher.ToMultiResponse(ids, count).ToByte())); err != nil {
+		logger.Error(err)
+	}
+
+	return ids, count, nil
+}
+
+// findAllByIDsWithCache find the records by the ids keeping the order, the deleted records are skipped.
+// The cached records are taken from the cache, the rest are found with a single query then cached.
+func findAllByIDsWithCache[T any](
+	ctx context.Context,
+	db *gorm.DB,
+	cacheManager cacher.CacheManager,
+	ids []int64,
+	newCacheKeyByID func(id int64) string,
+	idOf func(record *T) int64,
+) ([]*T, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx": utils.DumpIncomingContext(ctx),
+		"ids": ids,
+	})
+
+	records := make(map[int64]*T, len(ids))
+	var missingIDs []int64
+	for _, id := range ids {
+		reply, err := cacheManager.Get(newCacheKeyByID(id))
+		if err != nil {
+			logger.Error(err)
+		}
+
+		bt, _ := reply.([]byte)
+		if bt == nil {
+			missingIDs = append(missingIDs, id)
+			continue
+		}
+
+		// the deleted record is cached as nil
+		var record *T
+		if err := json.Unmarshal(bt, &record); err != nil {
+			logger.Error(err)
+			missingIDs = append(missingIDs, id)
+			continue
+		}
+
+		if record != nil {
+			records[id] = record
+		}
+	}
+
+	if len(missingIDs) > 0 {
+		var found []*T
+		if err := db.WithContext(ctx).Where("id IN ?", missingIDs).Find(&found).Error; err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+
+		items := make([]cacher.Item, 0, len(found))
+		for _, record := range found {
+			id := idOf(record)
+			records[id] = record
+			items = append(items, cacher.NewItem(newCacheKeyByID(id), utils.Dump(record)))
+		}
+
+		if len(items) > 0 {
+			if err := cacheManager.StoreMultiWithoutBlocking(items); err != nil {
+				logger.Error(err)
+			}
+		}
+	}
+
+	var result []*T
+	for _, id := range ids {
+		if record, ok := records[id]; ok {
+			result = append(result, record)
+		}
+	}
+
+	return result, nil
+}
+
 func storeNilCache(cache cacher.CacheManager, cacheKey string) {
 	if err := cache.StoreNil(cacheKey); err != nil {
 		logrus.Error(err)
 	}
 }
+
+// newCacheMemberKeyByPagination the member key of the page on the hash bucket of the list cache
+func newCacheMemberKeyByPagination(pagination model.Pagination) string {
+	return fmt.Sprintf("page:%d:size:%d", pagination.Page, pagination.Size)
+}
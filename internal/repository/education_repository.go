@@ -3,7 +3,6 @@ package repository
 import (
 	"context"
 	"fmt"
-	"github.com/irvankadhafi/talent-hub-service/internal/config"
 	"github.com/irvankadhafi/talent-hub-service/internal/model"
 	"github.com/irvankadhafi/talent-hub-service/pkg/cacher"
 	"github.com/irvankadhafi/talent-hub-service/utils"
@@ -27,99 +26,84 @@ func NewEducationRepository(
 }
 
 func (e *educationRepository) FindByID(ctx context.Context, id int64) (*model.Education, error) {
-	if id <= 0 {
-		return nil, nil
-	}
+	return findByIDWithCache[model.Education](ctx, e.db, e.cacheManager, e.newCacheKeyByID(id), id)
+}
+
+// FindAllByCandidateID find the candidate's educations, the ongoing education first then ordered by the latest dates.
+// The ids of every page are cached on the candidate's hash bucket, so the whole bucket is invalidated on a write.
+func (e *educationRepository) FindAllByCandidateID(ctx context.Context, candidateID int64, pagination model.Pagination) ([]*model.Education, int64, error) {
 	logger := logrus.WithFields(logrus.Fields{
-		"ctx": utils.DumpIncomingContext(ctx),
-		"id":  id,
+		"ctx":         utils.DumpIncomingContext(ctx),
+		"candidateID": candidateID,
+		"page":        pagination.Page,
+		"size":        pagination.Size,
 	})
 
-	cacheKey := e.newCacheKeyByID(id)
-	if !config.DisableCaching() {
-		reply, mu, err := findFromCacheByKey[*model.Education](e.cacheManager, cacheKey)
+	ids, count, err := findIDsByBucketWithCache(ctx, e.cacheManager, e.newCacheKeyBucketByCandidateID(candidateID), pagination, func() ([]int64, int64, error) {
+		var count int64
+		err := e.db.WithContext(ctx).Model(model.Education{}).Where("candidate_id = ?", candidateID).Count(&count).Error
 		if err != nil {
 			logger.Error(err)
-			return nil, err
+			return nil, 0, err
 		}
 
-		defer cacher.SafeUnlock(mu)
-
-		if mu == nil {
-			return reply, nil
+		var ids []int64
+		err = e.db.WithContext(ctx).Model(model.Education{}).
+			Where("candidate_id = ?", candidateID).
+			Order("until_now DESC, end_year DESC NULLS FIRST, start_year DESC, id DESC").
+			Offset(int(pagination.Offset())).
+			Limit(int(pagination.Size)).
+			Pluck("id", &ids).Error
+		if err != nil {
+			logger.Error(err)
+			return nil, 0, err
 		}
-	}
 
-	var education model.Education
-	err := e.db.WithContext(ctx).Take(&education, "id = ?", id).Error
-	switch err {
-	case nil:
-	case gorm.ErrRecordNotFound:
-		storeNilCache(e.cacheManager, cacheKey)
-		return nil, nil
-	default:
-		logger.Error(err)
-		return nil, err
+		return ids, count, nil
+	})
+	if err != nil {
+		return nil, 0, err
 	}
 
-	if err := e.cacheManager.StoreWithoutBlocking(cacher.NewItem(cacheKey, utils.Dump(education))); err != nil {
-		logger.Error(err)
+	educations, err := findAllByIDsWithCache(ctx, e.db, e.cacheManager, ids, e.newCacheKeyByID, func(education *model.Education) int64 {
+		return education.ID
+	})
+	if err != nil {
+		return nil, 0, err
 	}
 
-	return &education, nil
+	return educations, count, nil
 }
 
-func (e *educationRepository) FindByCandidateID(ctx context.Context, candidateID int64) (*model.Education, error) {
-	if candidateID <= 0 {
-		return nil, nil
-	}
-
+func (e *educationRepository) Create(ctx context.Context, education *model.Education) error {
 	logger := logrus.WithFields(logrus.Fields{
-		"ctx":         utils.DumpIncomingContext(ctx),
-		"candidateID": candidateID,
+		"ctx":       utils.DumpIncomingContext(ctx),
+		"education": utils.Dump(education),
 	})
 
-	cacheKey := e.newCacheKeyByCandidateID(candidateID)
-	if !config.DisableCaching() {
-		id, mu, err := findFromCacheByKey[int64](e.cacheManager, cacheKey)
-		if err != nil {
-			logger.Error(err)
-			return nil, err
-		}
-
-		defer cacher.SafeUnlock(mu)
-
-		if mu == nil {
-			return e.FindByID(ctx, id)
-		}
-	}
-
-	var id int64
-	err := e.db.WithContext(ctx).Model(model.Education{}).Select("id").Take(&id, "candidate_id = ?", candidateID).Error
-	switch err {
-	case nil:
-	case gorm.ErrRecordNotFound:
-		storeNilCache(e.cacheManager, cacheKey)
-		return nil, nil
-	default:
+	if err := e.db.WithContext(ctx).Create(education).Error; err != nil {
 		logger.Error(err)
-		return nil, err
+		return err
 	}
 
-	if err := e.cacheManager.StoreWithoutBlocking(cacher.NewItem(cacheKey, id)); err != nil {
+	if err := e.deleteCommonCache(education); err != nil {
 		logger.Error(err)
 	}
 
-	return e.FindByID(ctx, id)
+	return nil
 }
 
-func (e *educationRepository) Create(ctx context.Context, education *model.Education) error {
-	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+func (e *educationRepository) Update(ctx context.Context, education *model.Education) error {
+	logger := logrus.WithFields(logrus.Fields{
 		"ctx":       utils.DumpIncomingContext(ctx),
 		"education": utils.Dump(education),
 	})
 
-	if err := e.db.WithContext(ctx).Create(education).Error; err != nil {
+	// select the columns explicitly, so the false until now and the null end year and gpa are updated
+	err := e.db.WithContext(ctx).Model(education).
+		Select("institution_name", "major", "start_year", "end_year", "until_now", "gpa", "gpa_scale", "updated_at").
+		Updates(education).Error
+	if err != nil {
 		logger.Error(err)
 		return err
 	}
@@ -131,14 +115,14 @@ func (e *educationRepository) Create(ctx context.Context, education *model.Educa
 	return nil
 }
 
-func (e *educationRepository) Update(ctx context.Context, education *model.Education) error {
-	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
-		"ctx":       utils.DumpIncomingContext(ctx),
-		"education": utils.Dump(education),
+// Delete soft deletes the education
+func (e *educationRepository) Delete(ctx context.Context, education *model.Education) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx": utils.DumpIncomingContext(ctx),
+		"id":  education.ID,
 	})
 
-	if err := e.db.WithContext(ctx).Model(model.Education{}).
-		Where("id = ?", education.ID).Updates(education).Error; err != nil {
+	if err := e.db.WithContext(ctx).Delete(education).Error; err != nil {
 		logger.Error(err)
 		return err
 	}
@@ -154,14 +138,14 @@ func (e *educationRepository) newCacheKeyByID(id int64) string {
 	return fmt.Sprintf("cache:object:education:id:%d", id)
 }
 
-func (e *educationRepository) newCacheKeyByCandidateID(candidateID int64) string {
-	return fmt.Sprintf("cache:id:education:candidate_id:%d", candidateID)
+func (e *educationRepository) newCacheKeyBucketByCandidateID(candidateID int64) string {
+	return fmt.Sprintf("cache:hash:education:candidate_id:%d", candidateID)
 }
 
 func (e *educationRepository) deleteCommonCache(education *model.Education) error {
 	cacheKeys := []string{
 		e.newCacheKeyByID(education.ID),
-		e.newCacheKeyByCandidateID(education.CandidateID),
+		e.newCacheKeyBucketByCandidateID(education.CandidateID),
 	}
 
 	return e.cacheManager.DeleteByKeys(cacheKeys)
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"github.com/irvankadhafi/talent-hub-service/internal/helper"
+	"github.com/irvankadhafi/talent-hub-service/internal/model"
+	"github.com/irvankadhafi/talent-hub-service/utils"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"time"
+)
+
+type emailVerificationTokenRepository struct {
+	db *gorm.DB
+}
+
+// NewEmailVerificationTokenRepository emailVerificationTokenRepository constructor
+func NewEmailVerificationTokenRepository(db *gorm.DB) model.EmailVerificationTokenRepository {
+	return &emailVerificationTokenRepository{
+		db: db,
+	}
+}
+
+func (e *emailVerificationTokenRepository) Create(ctx context.Context, token *model.EmailVerificationToken) error {
+	if err := e.db.WithContext(ctx).Create(token).Error; err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":         utils.DumpIncomingContext(ctx),
+			"candidateID": token.CandidateID,
+		}).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// FindByToken find the verification token by the plain token
+func (e *emailVerificationTokenRepository) FindByToken(ctx context.Context, token string) (*model.EmailVerificationToken, error) {
+	verificationToken := &model.EmailVerificationToken{}
+	err := e.db.WithContext(ctx).Take(verificationToken, "token_hash = ?", helper.HashToken(token)).Error
+	switch err {
+	case nil:
+	case gorm.ErrRecordNotFound:
+		return nil, nil
+	default:
+		logrus.WithField("ctx", utils.DumpIncomingContext(ctx)).Error(err)
+		return nil, err
+	}
+
+	return verificationToken, nil
+}
+
+// MarkAsUsed mark the token as used, return false when the token has been used before
+func (e *emailVerificationTokenRepository) MarkAsUsed(ctx context.Context, token *model.EmailVerificationToken) (bool, error) {
+	res := e.db.WithContext(ctx).Model(model.EmailVerificationToken{}).
+		Where("id = ? AND used_at IS NULL", token.ID).
+		Update("used_at", time.Now())
+	if res.Error != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":     utils.DumpIncomingContext(ctx),
+			"tokenID": token.ID,
+		}).Error(res.Error)
+		return false, res.Error
+	}
+
+	return res.RowsAffected > 0, nil
+}
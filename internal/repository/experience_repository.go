@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"github.com/irvankadhafi/talent-hub-service/internal/model"
+	"github.com/irvankadhafi/talent-hub-service/pkg/cacher"
+	"github.com/irvankadhafi/talent-hub-service/utils"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type experienceRepository struct {
+	db           *gorm.DB
+	cacheManager cacher.CacheManager
+}
+
+func NewExperienceRepository(
+	db *gorm.DB,
+	cacheManager cacher.CacheManager,
+) model.ExperienceRepository {
+	return &experienceRepository{
+		db:           db,
+		cacheManager: cacheManager,
+	}
+}
+
+func (e *experienceRepository) FindByID(ctx context.Context, id int64) (*model.Experience, error) {
+	return findByIDWithCache[model.Experience](ctx, e.db, e.cacheManager, e.newCacheKeyByID(id), id)
+}
+
+// FindAllByCandidateID find the candidate's experiences, the ongoing experience first then ordered by the latest dates.
+// The ids of every page are cached on the candidate's hash bucket, so the whole bucket is invalidated on a write.
+func (e *experienceRepository) FindAllByCandidateID(ctx context.Context, candidateID int64, pagination model.Pagination) ([]*model.Experience, int64, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         utils.DumpIncomingContext(ctx),
+		"candidateID": candidateID,
+		"page":        pagination.Page,
+		"size":        pagination.Size,
+	})
+
+	ids, count, err := findIDsByBucketWithCache(ctx, e.cacheManager, e.newCacheKeyBucketByCandidateID(candidateID), pagination, func() ([]int64, int64, error) {
+		var count int64
+		err := e.db.WithContext(ctx).Model(model.Experience{}).Where("candidate_id = ?", candidateID).Count(&count).Error
+		if err != nil {
+			logger.Error(err)
+			return nil, 0, err
+		}
+
+		var ids []int64
+		err = e.db.WithContext(ctx).Model(model.Experience{}).
+			Where("candidate_id = ?", candidateID).
+			Order("until_now DESC, end_year DESC NULLS FIRST, start_year DESC, id DESC").
+			Offset(int(pagination.Offset())).
+			Limit(int(pagination.Size)).
+			Pluck("id", &ids).Error
+		if err != nil {
+			logger.Error(err)
+			return nil, 0, err
+		}
+
+		return ids, count, nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	experiences, err := findAllByIDsWithCache(ctx, e.db, e.cacheManager, ids, e.newCacheKeyByID, func(experience *model.Experience) int64 {
+		return experience.ID
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return experiences, count, nil
+}
+
+// FindAllOverlapping find the candidate's other experiences overlapping the date range of the experience,
+// see model.Experience Overlaps for the overlap rules.
+func (e *experienceRepository) FindAllOverlapping(ctx context.Context, experience *model.Experience) ([]*model.Experience, error) {
+	var others []*model.Experience
+	err := e.db.WithContext(ctx).
+		Where("candidate_id = ? AND id <> ?", experience.CandidateID, experience.ID).
+		Order("start_year").
+		Find(&others).Error
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":          utils.DumpIncomingContext(ctx),
+			"experienceID": experience.ID,
+			"candidateID":  experience.CandidateID,
+		}).Error(err)
+		return nil, err
+	}
+
+	var experiences []*model.Experience
+	for _, other := range others {
+		if experience.Overlaps(other) {
+			experiences = append(experiences, other)
+		}
+	}
+
+	return experiences, nil
+}
+
+func (e *experienceRepository) Create(ctx context.Context, experience *model.Experience) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":        utils.DumpIncomingContext(ctx),
+		"experience": utils.Dump(experience),
+	})
+
+	if err := e.db.WithContext(ctx).Create(experience).Error; err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if err := e.deleteCommonCache(experience); err != nil {
+		logger.Error(err)
+	}
+
+	return nil
+}
+
+func (e *experienceRepository) Update(ctx context.Context, experience *model.Experience) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":        utils.DumpIncomingContext(ctx),
+		"experience": utils.Dump(experience),
+	})
+
+	// select the columns explicitly, so the false until now and the null end year are updated
+	err := e.db.WithContext(ctx).Model(experience).
+		Select("company_name", "company_address", "position", "job_desc", "start_year", "end_year", "until_now", "updated_at").
+		Updates(experience).Error
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if err := e.deleteCommonCache(experience); err != nil {
+		logger.Error(err)
+	}
+
+	return nil
+}
+
+// Delete soft deletes the experience
+func (e *experienceRepository) Delete(ctx context.Context, experience *model.Experience) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx": utils.DumpIncomingContext(ctx),
+		"id":  experience.ID,
+	})
+
+	if err := e.db.WithContext(ctx).Delete(experience).Error; err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if err := e.deleteCommonCache(experience); err != nil {
+		logger.Error(err)
+	}
+
+	return nil
+}
+
+func (e *experienceRepository) newCacheKeyByID(id int64) string {
+	return fmt.Sprintf("cache:object:experience:id:%d", id)
+}
+
+func (e *experienceRepository) newCacheKeyBucketByCandidateID(candidateID int64) string {
+	return fmt.Sprintf("cache:hash:experience:candidate_id:%d", candidateID)
+}
+
+func (e *experienceRepository) deleteCommonCache(experience *model.Experience) error {
+	cacheKeys := []string{
+		e.newCacheKeyByID(experience.ID),
+		e.newCacheKeyBucketByCandidateID(experience.CandidateID),
+	}
+
+	return e.cacheManager.DeleteByKeys(cacheKeys)
+}
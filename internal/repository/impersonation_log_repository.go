@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+	"github.com/irvankadhafi/talent-hub-service/internal/model"
+	"github.com/irvankadhafi/talent-hub-service/utils"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type impersonationLogRepository struct {
+	db *gorm.DB
+}
+
+// NewImpersonationLogRepository impersonationLogRepository constructor
+func NewImpersonationLogRepository(db *gorm.DB) model.ImpersonationLogRepository {
+	return &impersonationLogRepository{
+		db: db,
+	}
+}
+
+func (i *impersonationLogRepository) Create(ctx context.Context, log *model.ImpersonationLog) error {
+	if err := i.db.WithContext(ctx).Create(log).Error; err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":            utils.DumpIncomingContext(ctx),
+			"impersonatorID": log.ImpersonatorID,
+			"candidateID":    log.CandidateID,
+		}).Error(err)
+		return err
+	}
+
+	return nil
+}
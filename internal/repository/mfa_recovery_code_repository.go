@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"github.com/irvankadhafi/talent-hub-service/internal/model"
+	"github.com/irvankadhafi/talent-hub-service/utils"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"time"
+)
+
+type mfaRecoveryCodeRepository struct {
+	db *gorm.DB
+}
+
+// NewMFARecoveryCodeRepository mfaRecoveryCodeRepository constructor
+func NewMFARecoveryCodeRepository(db *gorm.DB) model.MFARecoveryCodeRepository {
+	return &mfaRecoveryCodeRepository{
+		db: db,
+	}
+}
+
+// ReplaceAllByCandidateID replace all the candidate's recovery codes with the new ones
+func (m *mfaRecoveryCodeRepository) ReplaceAllByCandidateID(ctx context.Context, candidateID int64, codeHashes []string) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         utils.DumpIncomingContext(ctx),
+		"candidateID": candidateID,
+	})
+
+	codes := make([]*model.MFARecoveryCode, 0, len(codeHashes))
+	for _, codeHash := range codeHashes {
+		codes = append(codes, &model.MFARecoveryCode{
+			ID:          utils.GenerateID(),
+			CandidateID: candidateID,
+			CodeHash:    codeHash,
+		})
+	}
+
+	err := m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&model.MFARecoveryCode{}, "candidate_id = ?", candidateID).Error; err != nil {
+			return err
+		}
+
+		if len(codes) == 0 {
+			return nil
+		}
+
+		return tx.Create(&codes).Error
+	})
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// MarkAsUsed mark the candidate's recovery code as used, return false when the code is not found or has been used
+func (m *mfaRecoveryCodeRepository) MarkAsUsed(ctx context.Context, candidateID int64, codeHash string) (bool, error) {
+	res := m.db.WithContext(ctx).Model(model.MFARecoveryCode{}).
+		Where("candidate_id = ? AND code_hash = ? AND used_at IS NULL", candidateID, codeHash).
+		Update("used_at", time.Now())
+	if res.Error != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":         utils.DumpIncomingContext(ctx),
+			"candidateID": candidateID,
+		}).Error(res.Error)
+		return false, res.Error
+	}
+
+	return res.RowsAffected > 0, nil
+}
+
+// DeleteAllByCandidateID delete all the candidate's recovery codes
+func (m *mfaRecoveryCodeRepository) DeleteAllByCandidateID(ctx context.Context, candidateID int64) error {
+	if err := m.db.WithContext(ctx).Delete(&model.MFARecoveryCode{}, "candidate_id = ?", candidateID).Error; err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":         utils.DumpIncomingContext(ctx),
+			"candidateID": candidateID,
+		}).Error(err)
+		return err
+	}
+
+	return nil
+}
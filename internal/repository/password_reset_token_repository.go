@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"github.com/irvankadhafi/talent-hub-service/internal/config"
+	"github.com/irvankadhafi/talent-hub-service/internal/helper"
+	"github.com/irvankadhafi/talent-hub-service/internal/model"
+	"github.com/irvankadhafi/talent-hub-service/pkg/cacher"
+	"github.com/irvankadhafi/talent-hub-service/utils"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"time"
+)
+
+type passwordResetTokenRepository struct {
+	db           *gorm.DB
+	cacheManager cacher.CacheManager
+}
+
+// NewPasswordResetTokenRepository passwordResetTokenRepository constructor
+func NewPasswordResetTokenRepository(
+	db *gorm.DB,
+	cacheManager cacher.CacheManager,
+) model.PasswordResetTokenRepository {
+	return &passwordResetTokenRepository{
+		db:           db,
+		cacheManager: cacheManager,
+	}
+}
+
+func (p *passwordResetTokenRepository) Create(ctx context.Context, token *model.PasswordResetToken) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         utils.DumpIncomingContext(ctx),
+		"candidateID": token.CandidateID,
+	})
+
+	if err := p.db.WithContext(ctx).Create(token).Error; err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	item := cacher.NewItemWithCustomTTL(p.newCacheKeyByTokenHash(token.TokenHash), utils.Dump(token), time.Until(token.ExpiredAt))
+	if err := p.cacheManager.StoreWithoutBlocking(item); err != nil {
+		logger.Error(err)
+	}
+
+	return nil
+}
+
+// FindByToken find the reset token by the plain token
+func (p *passwordResetTokenRepository) FindByToken(ctx context.Context, token string) (*model.PasswordResetToken, error) {
+	tokenHash := helper.HashToken(token)
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx": utils.DumpIncomingContext(ctx),
+	})
+
+	cacheKey := p.newCacheKeyByTokenHash(tokenHash)
+	if !config.DisableCaching() {
+		reply, mu, err := findFromCacheByKey[*model.PasswordResetToken](p.cacheManager, cacheKey)
+		if err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+
+		defer cacher.SafeUnlock(mu)
+
+		if mu == nil {
+			return reply, nil
+		}
+	}
+
+	resetToken := &model.PasswordResetToken{}
+	err := p.db.WithContext(ctx).Take(resetToken, "token_hash = ?", tokenHash).Error
+	switch err {
+	case nil:
+	case gorm.ErrRecordNotFound:
+		storeNilCache(p.cacheManager, cacheKey)
+		return nil, nil
+	default:
+		logger.Error(err)
+		return nil, err
+	}
+
+	if err := p.cacheManager.StoreWithoutBlocking(cacher.NewItem(cacheKey, utils.Dump(resetToken))); err != nil {
+		logger.Error(err)
+	}
+
+	return resetToken, nil
+}
+
+// MarkAsUsed mark the token as used, return false when the token has been used before
+func (p *passwordResetTokenRepository) MarkAsUsed(ctx context.Context, token *model.PasswordResetToken) (bool, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":     utils.DumpIncomingContext(ctx),
+		"tokenID": token.ID,
+	})
+
+	res := p.db.WithContext(ctx).Model(model.PasswordResetToken{}).
+		Where("id = ? AND used_at IS NULL", token.ID).
+		Update("used_at", time.Now())
+	if res.Error != nil {
+		logger.Error(res.Error)
+		return false, res.Error
+	}
+
+	if err := p.cacheManager.DeleteByKeys([]string{p.newCacheKeyByTokenHash(token.TokenHash)}); err != nil {
+		logger.Error(err)
+	}
+
+	return res.RowsAffected > 0, nil
+}
+
+func (p *passwordResetTokenRepository) newCacheKeyByTokenHash(tokenHash string) string {
+	return fmt.Sprintf("cache:object:password_reset_token:token_hash:%s", tokenHash)
+}
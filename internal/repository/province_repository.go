@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"github.com/irvankadhafi/talent-hub-service/internal/model"
+	"github.com/irvankadhafi/talent-hub-service/pkg/cacher"
+	"gorm.io/gorm"
+)
+
+type provinceRepository struct {
+	db           *gorm.DB
+	cacheManager cacher.CacheManager
+}
+
+// NewProvinceRepository provinceRepository constructor
+func NewProvinceRepository(
+	db *gorm.DB,
+	cacheManager cacher.CacheManager,
+) model.ProvinceRepository {
+	return &provinceRepository{
+		db:           db,
+		cacheManager: cacheManager,
+	}
+}
+
+func (r *provinceRepository) FindByID(ctx context.Context, id int64) (*model.Province, error) {
+	return findByIDWithCache[model.Province](ctx, r.db, r.cacheManager, r.newCacheKeyByID(id), id)
+}
+
+func (r *provinceRepository) newCacheKeyByID(id int64) string {
+	return fmt.Sprintf("cache:object:province:id:%d", id)
+}
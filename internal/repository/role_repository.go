@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"github.com/irvankadhafi/talent-hub-service/internal/config"
+	"github.com/irvankadhafi/talent-hub-service/internal/model"
+	"github.com/irvankadhafi/talent-hub-service/pkg/cacher"
+	"github.com/irvankadhafi/talent-hub-service/utils"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type roleRepository struct {
+	db           *gorm.DB
+	cacheManager cacher.CacheManager
+}
+
+// NewRoleRepository roleRepository constructor
+func NewRoleRepository(
+	db *gorm.DB,
+	cacheManager cacher.CacheManager,
+) model.RoleRepository {
+	return &roleRepository{
+		db:           db,
+		cacheManager: cacheManager,
+	}
+}
+
+// FindAllByCandidateID find the roles assigned to the candidate
+func (r *roleRepository) FindAllByCandidateID(ctx context.Context, candidateID int64) ([]model.Role, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         utils.DumpIncomingContext(ctx),
+		"candidateID": candidateID,
+	})
+
+	cacheKey := r.newCacheKeyByCandidateID(candidateID)
+	if !config.DisableCaching() {
+		reply, mu, err := findFromCacheByKey[[]model.Role](r.cacheManager, cacheKey)
+		if err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+
+		defer cacher.SafeUnlock(mu)
+
+		if mu == nil {
+			return reply, nil
+		}
+	}
+
+	var roles []model.Role
+	err := r.db.WithContext(ctx).Model(model.CandidateRole{}).
+		Where("candidate_id = ?", candidateID).
+		Order("created_at ASC").
+		Pluck("role", &roles).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if len(roles) == 0 {
+		storeNilCache(r.cacheManager, cacheKey)
+		return nil, nil
+	}
+
+	if err := r.cacheManager.StoreWithoutBlocking(cacher.NewItem(cacheKey, utils.Dump(roles))); err != nil {
+		logger.Error(err)
+	}
+
+	return roles, nil
+}
+
+// Create assign the role to the candidate, assigning the same role twice is ignored
+func (r *roleRepository) Create(ctx context.Context, candidateRole *model.CandidateRole) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         utils.DumpIncomingContext(ctx),
+		"candidateID": candidateRole.CandidateID,
+		"role":        candidateRole.Role,
+	})
+
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(candidateRole).Error
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if err := r.cacheManager.DeleteByKeys([]string{r.newCacheKeyByCandidateID(candidateRole.CandidateID)}); err != nil {
+		logger.Error(err)
+	}
+
+	return nil
+}
+
+// Delete revoke the role from the candidate
+func (r *roleRepository) Delete(ctx context.Context, candidateID int64, role model.Role) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         utils.DumpIncomingContext(ctx),
+		"candidateID": candidateID,
+		"role":        role,
+	})
+
+	err := r.db.WithContext(ctx).Delete(&model.CandidateRole{}, "candidate_id = ? AND role = ?", candidateID, role).Error
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if err := r.cacheManager.DeleteByKeys([]string{r.newCacheKeyByCandidateID(candidateID)}); err != nil {
+		logger.Error(err)
+	}
+
+	return nil
+}
+
+func (r *roleRepository) newCacheKeyByCandidateID(candidateID int64) string {
+	return fmt.Sprintf("cache:object:candidate_roles:candidate_id:%d", candidateID)
+}
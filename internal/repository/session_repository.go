@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/irvankadhafi/talent-hub-service/internal/config"
+	"github.com/irvankadhafi/talent-hub-service/internal/helper"
 	"github.com/irvankadhafi/talent-hub-service/internal/model"
 	"github.com/irvankadhafi/talent-hub-service/pkg/cacher"
 	"github.com/irvankadhafi/talent-hub-service/utils"
@@ -144,7 +145,9 @@ func (s *sessionRepo) CheckToken(ctx context.Context, token string) (exist bool,
 	return string(bt) != "", nil
 }
 
-// RefreshToken update access and refresh token string value and expired_at
+// RefreshToken update access and refresh token string value and expired_at.
+// The session is only updated while it still holds the old refresh token, so when the same token
+// is refreshed concurrently only the first one wins and the others return nil session.
 func (s *sessionRepo) RefreshToken(ctx context.Context, oldSess, sess *model.Session) (*model.Session, error) {
 	logger := logrus.WithFields(logrus.Fields{
 		"ctx":     utils.DumpIncomingContext(ctx),
@@ -152,20 +155,47 @@ func (s *sessionRepo) RefreshToken(ctx context.Context, oldSess, sess *model.Ses
 	})
 
 	sess.UpdatedAt = time.Now()
-	err := s.db.WithContext(ctx).Model(model.Session{}).Select(
-		"access_token",
-		"refresh_token",
-		"access_token_expired_at",
-		"refresh_token_expired_at",
-		"user_agent",
-		"ip_address",
-		"updated_at",
-	).Where("id = ?", sess.ID).Updates(sess).Error
+	rotated := false
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(model.Session{}).Select(
+			"access_token",
+			"refresh_token",
+			"access_token_expired_at",
+			"refresh_token_expired_at",
+			"user_agent",
+			"ip_address",
+			"latitude",
+			"longitude",
+			"roles",
+			"updated_at",
+		).Where("id = ? AND refresh_token = ?", sess.ID, oldSess.RefreshToken).Updates(sess)
+		if res.Error != nil {
+			return res.Error
+		}
+
+		// the refresh token is already rotated by the concurrent refresh
+		if res.RowsAffected == 0 {
+			return nil
+		}
+		rotated = true
+
+		// keep the hash of the old refresh token, so it can be detected when it's reused
+		return tx.Create(&model.SupersededRefreshToken{
+			TokenHash:   helper.HashToken(oldSess.RefreshToken),
+			SessionID:   oldSess.ID,
+			CandidateID: oldSess.CandidateID,
+			ExpiredAt:   oldSess.RefreshTokenExpiredAt,
+		}).Error
+	})
 	if err != nil {
 		logger.Error(err)
 		return nil, err
 	}
 
+	if !rotated {
+		return nil, nil
+	}
+
 	if err = s.deleteCaches(oldSess); err != nil {
 		logger.Error(err)
 	}
@@ -176,6 +206,47 @@ func (s *sessionRepo) RefreshToken(ctx context.Context, oldSess, sess *model.Ses
 	return s.FindByID(ctx, sess.ID)
 }
 
+// FindSupersededRefreshToken find the superseded refresh token by the plain refresh token
+func (s *sessionRepo) FindSupersededRefreshToken(ctx context.Context, refreshToken string) (*model.SupersededRefreshToken, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx": utils.DumpIncomingContext(ctx),
+	})
+
+	superseded := &model.SupersededRefreshToken{}
+	err := s.db.WithContext(ctx).Take(superseded, "token_hash = ?", helper.HashToken(refreshToken)).Error
+	switch err {
+	case nil:
+	case gorm.ErrRecordNotFound:
+		return nil, nil
+	default:
+		logger.Error(err)
+		return nil, err
+	}
+
+	return superseded, nil
+}
+
+// DeleteExpiredSupersededRefreshTokens delete at most batchSize superseded refresh tokens expired before the given time,
+// return the number of deleted tokens so the caller can continue until nothing left
+func (s *sessionRepo) DeleteExpiredSupersededRefreshTokens(ctx context.Context, expiredBefore time.Time, batchSize int) (int64, error) {
+	subQuery := s.db.Model(&model.SupersededRefreshToken{}).
+		Select("token_hash").
+		Where("expired_at < ?", expiredBefore).
+		Limit(batchSize)
+
+	res := s.db.WithContext(ctx).Where("token_hash IN (?)", subQuery).Delete(&model.SupersededRefreshToken{})
+	if res.Error != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":           utils.DumpIncomingContext(ctx),
+			"expiredBefore": expiredBefore,
+			"batchSize":     batchSize,
+		}).Error(res.Error)
+		return 0, res.Error
+	}
+
+	return res.RowsAffected, nil
+}
+
 // DeleteByCandidateIDAndMaxRemainderSession delete session by candidate id
 func (s *sessionRepo) DeleteByCandidateIDAndMaxRemainderSession(ctx context.Context, candidateID int64, maxRemainderSess int) error {
 	logger := logrus.WithFields(logrus.Fields{
@@ -215,6 +286,78 @@ func (s *sessionRepo) DeleteByCandidateIDAndMaxRemainderSession(ctx context.Cont
 	return nil
 }
 
+// FindAllActiveByCandidateID find all candidate's sessions which refresh token is not expired yet,
+// ordered by the last activity
+func (s *sessionRepo) FindAllActiveByCandidateID(ctx context.Context, candidateID int64) ([]*model.Session, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         utils.DumpIncomingContext(ctx),
+		"candidateID": candidateID,
+	})
+
+	var sessions []*model.Session
+	err := s.db.WithContext(ctx).
+		Where("candidate_id = ? AND refresh_token_expired_at > ?", candidateID, time.Now()).
+		Order("updated_at desc").
+		Find(&sessions).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+// DeleteAllByCandidateID delete all candidate's sessions except the given session ids
+func (s *sessionRepo) DeleteAllByCandidateID(ctx context.Context, candidateID int64, exceptIDs ...int64) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         utils.DumpIncomingContext(ctx),
+		"candidateID": candidateID,
+		"exceptIDs":   exceptIDs,
+	})
+
+	var sessions []*model.Session
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		query := tx.Where("candidate_id = ?", candidateID)
+		if len(exceptIDs) > 0 {
+			query = query.Where("id NOT IN ?", exceptIDs)
+		}
+
+		if err := query.Select("id", "access_token", "refresh_token").Find(&sessions).Error; err != nil {
+			return err
+		}
+
+		if len(sessions) == 0 {
+			return nil
+		}
+
+		deleteIDs := make([]int64, 0, len(sessions))
+		for _, session := range sessions {
+			deleteIDs = append(deleteIDs, session.ID)
+		}
+
+		return tx.Delete(&model.Session{}, deleteIDs).Error
+	})
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	var cacheKeys []string
+	for _, session := range sessions {
+		cacheKeys = append(cacheKeys,
+			model.NewSessionTokenCacheKey(session.AccessToken),
+			model.NewSessionTokenCacheKey(session.RefreshToken),
+			s.newCacheKeyByID(session.ID),
+		)
+	}
+
+	if err := s.cacheManager.DeleteByKeys(cacheKeys); err != nil {
+		logger.Error(err)
+	}
+
+	return nil
+}
+
 // Delete deletes existing session by id.
 func (s *sessionRepo) Delete(ctx context.Context, session *model.Session) error {
 	logger := logrus.WithFields(logrus.Fields{
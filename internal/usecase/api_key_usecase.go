@@ -0,0 +1,262 @@
+package usecase
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"github.com/irvankadhafi/talent-hub-service/internal/config"
+	"github.com/irvankadhafi/talent-hub-service/internal/helper"
+	"github.com/irvankadhafi/talent-hub-service/internal/model"
+	"github.com/irvankadhafi/talent-hub-service/utils"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/guregu/null.v4"
+	"strings"
+	"time"
+)
+
+const (
+	apiKeyPrefixLength = 6  // bytes, hex encoded
+	apiKeySecretLength = 32 // bytes, base64 url encoded
+)
+
+type apiKeyUsecase struct {
+	apiKeyRepo model.APIKeyRepository
+}
+
+// NewAPIKeyUsecase apiKeyUsecase constructor
+func NewAPIKeyUsecase(apiKeyRepo model.APIKeyRepository) model.APIKeyUsecase {
+	return &apiKeyUsecase{
+		apiKeyRepo: apiKeyRepo,
+	}
+}
+
+// FindAll find all the api keys, the key hash is never returned
+func (a *apiKeyUsecase) FindAll(ctx context.Context, requester *model.Candidate) ([]*model.APIKey, error) {
+	if !requester.HasPermission(model.PermissionAPIKeyManage) {
+		return nil, ErrPermissionDenied
+	}
+
+	apiKeys, err := a.apiKeyRepo.FindAll(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":         utils.DumpIncomingContext(ctx),
+			"requesterID": requester.ID,
+		}).Error(err)
+		return nil, err
+	}
+
+	return apiKeys, nil
+}
+
+// Create creates the api key with the scopes, the plain key is only returned once on the created api key
+func (a *apiKeyUsecase) Create(ctx context.Context, requester *model.Candidate, input model.CreateAPIKeyInput) (*model.APIKey, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         utils.DumpIncomingContext(ctx),
+		"requesterID": requester.ID,
+		"name":        input.Name,
+	})
+
+	if !requester.HasPermission(model.PermissionAPIKeyManage) {
+		return nil, ErrPermissionDenied
+	}
+
+	if err := input.Validate(); err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	for _, scope := range input.Scopes {
+		if !scope.IsAPIKeyScope() {
+			return nil, ErrAPIKeyScopeInvalid
+		}
+	}
+
+	if input.ExpiredAt.Valid && !input.ExpiredAt.Time.After(time.Now()) {
+		return nil, ErrAPIKeyExpiryInvalid
+	}
+
+	apiKey, err := newAPIKey(input.Name, input.Scopes, input.ExpiredAt, requester.ID)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if err := a.apiKeyRepo.Create(ctx, apiKey); err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return apiKey, nil
+}
+
+// Rotate creates a new key with the same name, scopes and expiry.
+// The rotated key is still valid for the grace period, so the partner can roll out the new key without downtime.
+func (a *apiKeyUsecase) Rotate(ctx context.Context, requester *model.Candidate, id int64) (*model.APIKey, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         utils.DumpIncomingContext(ctx),
+		"requesterID": requester.ID,
+		"apiKeyID":    id,
+	})
+
+	if !requester.HasPermission(model.PermissionAPIKeyManage) {
+		return nil, ErrPermissionDenied
+	}
+
+	oldKey, err := a.findByID(ctx, id)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if oldKey.IsRevoked() || oldKey.IsExpired() {
+		return nil, ErrAPIKeyRevoked
+	}
+
+	newKey, err := newAPIKey(oldKey.Name, oldKey.Scopes, oldKey.ExpiredAt, requester.ID)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+	newKey.RotatedFromID = null.IntFrom(oldKey.ID)
+
+	if err := a.apiKeyRepo.Create(ctx, newKey); err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	gracePeriodEnd := time.Now().Add(config.APIKeyRotationGracePeriod())
+	if !oldKey.ExpiredAt.Valid || oldKey.ExpiredAt.Time.After(gracePeriodEnd) {
+		oldKey.ExpiredAt = null.TimeFrom(gracePeriodEnd)
+		if err := a.apiKeyRepo.Update(ctx, oldKey); err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+	}
+
+	return newKey, nil
+}
+
+// Revoke revokes the api key immediately, revoking the revoked key is ignored
+func (a *apiKeyUsecase) Revoke(ctx context.Context, requester *model.Candidate, id int64) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         utils.DumpIncomingContext(ctx),
+		"requesterID": requester.ID,
+		"apiKeyID":    id,
+	})
+
+	if !requester.HasPermission(model.PermissionAPIKeyManage) {
+		return ErrPermissionDenied
+	}
+
+	apiKey, err := a.findByID(ctx, id)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if apiKey.IsRevoked() {
+		return nil
+	}
+
+	apiKey.RevokedAt = null.TimeFrom(time.Now())
+	if err := a.apiKeyRepo.Update(ctx, apiKey); err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// Authenticate authenticates the plain api key, the authenticated api key is only granted its scopes.
+// The last used time is updated at most once per the configured interval.
+func (a *apiKeyUsecase) Authenticate(ctx context.Context, key string) (*model.Candidate, error) {
+	prefix, ok := parseAPIKeyPrefix(key)
+	if !ok {
+		return nil, ErrAPIKeyInvalid
+	}
+
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":    utils.DumpIncomingContext(ctx),
+		"prefix": prefix,
+	})
+
+	apiKey, err := a.apiKeyRepo.FindByPrefix(ctx, prefix)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if apiKey == nil || subtle.ConstantTimeCompare([]byte(apiKey.KeyHash), []byte(helper.HashToken(key))) != 1 || apiKey.IsRevoked() {
+		return nil, ErrAPIKeyInvalid
+	}
+
+	if apiKey.IsExpired() {
+		return nil, ErrAPIKeyExpired
+	}
+
+	if !apiKey.LastUsedAt.Valid || time.Since(apiKey.LastUsedAt.Time) >= config.APIKeyLastUsedInterval() {
+		// the request is not failed when the last used time can't be updated
+		if err := a.apiKeyRepo.UpdateLastUsedAt(ctx, apiKey); err != nil {
+			logger.Error(err)
+		}
+	}
+
+	return &model.Candidate{
+		APIKeyID: apiKey.ID,
+		Scopes:   model.FilterAPIKeyScopes(apiKey.Scopes),
+	}, nil
+}
+
+func (a *apiKeyUsecase) findByID(ctx context.Context, id int64) (*model.APIKey, error) {
+	apiKey, err := a.apiKeyRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if apiKey == nil {
+		return nil, ErrNotFound
+	}
+
+	return apiKey, nil
+}
+
+// newAPIKey generates the key formatted as `<model.APIKeyPrefix><prefix>_<secret>`, only the hash of the whole key is stored
+func newAPIKey(name string, scopes []model.Permission, expiredAt null.Time, createdBy int64) (*model.APIKey, error) {
+	prefix, err := utils.GenerateRandomBytes(apiKeyPrefixLength)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := utils.GenerateRandomBytes(apiKeySecretLength)
+	if err != nil {
+		return nil, err
+	}
+
+	apiKey := &model.APIKey{
+		ID:        utils.GenerateID(),
+		Name:      name,
+		Prefix:    hex.EncodeToString(prefix),
+		Scopes:    scopes,
+		ExpiredAt: expiredAt,
+		CreatedBy: null.NewInt(createdBy, createdBy != 0),
+	}
+	apiKey.Key = model.APIKeyPrefix + apiKey.Prefix + "_" + base64.RawURLEncoding.EncodeToString(secret)
+	apiKey.KeyHash = helper.HashToken(apiKey.Key)
+
+	return apiKey, nil
+}
+
+// parseAPIKeyPrefix get the lookup prefix of the plain key
+func parseAPIKeyPrefix(key string) (string, bool) {
+	if !strings.HasPrefix(key, model.APIKeyPrefix) {
+		return "", false
+	}
+
+	parts := strings.SplitN(strings.TrimPrefix(key, model.APIKeyPrefix), "_", 2)
+	if len(parts) != 2 || len(parts[0]) != hex.EncodedLen(apiKeyPrefixLength) || parts[1] == "" {
+		return "", false
+	}
+
+	return parts[0], true
+}
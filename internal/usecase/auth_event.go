@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"context"
+	"github.com/irvankadhafi/talent-hub-service/internal/model"
+	"github.com/irvankadhafi/talent-hub-service/utils"
+	"gopkg.in/guregu/null.v4"
+	"strings"
+	"time"
+)
+
+// recordAuthEvent enqueues the auth event to be persisted for the audit, the event fails when the error is set.
+// The event is written in the background, so it never blocks the authentication.
+func (a *authUsecase) recordAuthEvent(ctx context.Context, event *model.AuthEvent, err error) {
+	event.ID = utils.GenerateID()
+	event.CreatedAt = time.Now()
+	switch {
+	case err != nil:
+		event.Outcome = model.AuthEventOutcomeFailure
+		event.Reason = err.Error()
+	case event.Outcome == "":
+		event.Outcome = model.AuthEventOutcomeSuccess
+	}
+
+	a.authEventRecorder.EnqueueRecord(event)
+}
+
+// recordLoginEvent records the passed first factor, which either creates the session or requires the mfa challenge
+func (a *authUsecase) recordLoginEvent(ctx context.Context, event *model.AuthEvent, session *model.Session, challenge *model.MFAChallenge) {
+	var riskFlags []model.LoginRiskFlag
+	if challenge != nil {
+		event.Outcome = model.AuthEventOutcomeChallenged
+		riskFlags = challenge.RiskFlags
+	}
+
+	if session != nil {
+		event.SessionID = null.IntFrom(session.ID)
+		riskFlags = session.RiskFlags
+	}
+
+	// the risk flags are the reason of the suspicious login
+	reasons := make([]string, 0, len(riskFlags))
+	for _, flag := range riskFlags {
+		reasons = append(reasons, string(flag))
+	}
+	event.Reason = strings.Join(reasons, ",")
+
+	a.recordAuthEvent(ctx, event, nil)
+}
+
+func newAuthEvent(eventType model.AuthEventType, candidateID int64, req model.LoginRequest) *model.AuthEvent {
+	return &model.AuthEvent{
+		EventType:   eventType,
+		CandidateID: null.NewInt(candidateID, candidateID > 0),
+		Identifier:  req.Identifier,
+		IPAddress:   req.IPAddress,
+		UserAgent:   req.UserAgent,
+		Latitude:    req.Latitude,
+		Longitude:   req.Longitude,
+	}
+}
+
+func newSessionAuthEvent(eventType model.AuthEventType, session *model.Session, client model.ClientInfo) *model.AuthEvent {
+	return &model.AuthEvent{
+		EventType:   eventType,
+		CandidateID: null.IntFrom(session.CandidateID),
+		SessionID:   null.IntFrom(session.ID),
+		IPAddress:   client.IPAddress,
+		UserAgent:   client.UserAgent,
+	}
+}
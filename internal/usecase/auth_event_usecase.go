@@ -0,0 +1,49 @@
+package usecase
+
+import (
+	"context"
+	"github.com/irvankadhafi/talent-hub-service/internal/model"
+	"github.com/irvankadhafi/talent-hub-service/utils"
+	"github.com/sirupsen/logrus"
+)
+
+type authEventUsecase struct {
+	authEventRepo model.AuthEventRepository
+}
+
+// NewAuthEventUsecase authEventUsecase constructor
+func NewAuthEventUsecase(authEventRepo model.AuthEventRepository) model.AuthEventUsecase {
+	return &authEventUsecase{
+		authEventRepo: authEventRepo,
+	}
+}
+
+// FindAllByCandidate find the requester's own auth events as the recent activity
+func (a *authEventUsecase) FindAllByCandidate(ctx context.Context, requester *model.Candidate, criteria model.AuthEventCriteria) ([]*model.AuthEvent, int64, error) {
+	criteria.CandidateID = requester.ID
+	return a.findAllByCriteria(ctx, criteria)
+}
+
+// FindAll find the auth events of all candidates, only allowed for the auditor
+func (a *authEventUsecase) FindAll(ctx context.Context, requester *model.Candidate, criteria model.AuthEventCriteria) ([]*model.AuthEvent, int64, error) {
+	if !requester.HasPermission(model.PermissionAuditRead) {
+		return nil, 0, ErrPermissionDenied
+	}
+
+	return a.findAllByCriteria(ctx, criteria)
+}
+
+func (a *authEventUsecase) findAllByCriteria(ctx context.Context, criteria model.AuthEventCriteria) ([]*model.AuthEvent, int64, error) {
+	criteria.Normalize()
+
+	events, count, err := a.authEventRepo.FindAllByCriteria(ctx, criteria)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":      utils.DumpIncomingContext(ctx),
+			"criteria": utils.Dump(criteria),
+		}).Error(err)
+		return nil, 0, err
+	}
+
+	return events, count, nil
+}
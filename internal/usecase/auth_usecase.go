@@ -5,28 +5,83 @@ import (
 	"github.com/irvankadhafi/talent-hub-service/internal/config"
 	"github.com/irvankadhafi/talent-hub-service/internal/helper"
 	"github.com/irvankadhafi/talent-hub-service/internal/model"
+	"github.com/irvankadhafi/talent-hub-service/pkg/cacher"
 	"github.com/irvankadhafi/talent-hub-service/utils"
 	"github.com/sirupsen/logrus"
+	"gopkg.in/guregu/null.v4"
 	"time"
 )
 
 type authUsecase struct {
-	candidateRepo model.CandidateRepository
-	sessionRepo   model.SessionRepository
+	candidateRepo  model.CandidateRepository
+	sessionRepo    model.SessionRepository
+	roleRepo       model.RoleRepository
+	sessionCleaner model.SessionCleaner
+	cacheManager   cacher.CacheManager
+	smsSender      model.SMSSender
+
+	mfaRecoveryCodeRepo model.MFARecoveryCodeRepository
+	accessTokenSigner   model.AccessTokenSigner
+	loginAlertNotifier  model.LoginAlertNotifier
+
+	impersonationLogRepo model.ImpersonationLogRepository
+	authEventRecorder    model.AuthEventRecorder
+	ipLocator            model.IPLocator
+	passwordHasher       model.PasswordHasher
+
+	candidateIdentityRepo model.CandidateIdentityRepository
+	oidcProviders         map[string]model.OIDCProvider
 }
 
-func NewAuthUsecase(
-	candidateRepo model.CandidateRepository,
-	sessionRepo model.SessionRepository,
-) model.AuthUsecase {
+// AuthUsecaseDeps the dependencies of the authUsecase
+type AuthUsecaseDeps struct {
+	CandidateRepo  model.CandidateRepository
+	SessionRepo    model.SessionRepository
+	RoleRepo       model.RoleRepository
+	SessionCleaner model.SessionCleaner
+	CacheManager   cacher.CacheManager
+	SMSSender      model.SMSSender
+
+	MFARecoveryCodeRepo model.MFARecoveryCodeRepository
+	AccessTokenSigner   model.AccessTokenSigner
+	LoginAlertNotifier  model.LoginAlertNotifier
+
+	ImpersonationLogRepo model.ImpersonationLogRepository
+	AuthEventRecorder    model.AuthEventRecorder
+	IPLocator            model.IPLocator
+	PasswordHasher       model.PasswordHasher
+
+	CandidateIdentityRepo model.CandidateIdentityRepository
+	OIDCProviders         map[string]model.OIDCProvider
+}
+
+// NewAuthUsecase authUsecase constructor
+func NewAuthUsecase(deps AuthUsecaseDeps) model.AuthUsecase {
 	return &authUsecase{
-		candidateRepo: candidateRepo,
-		sessionRepo:   sessionRepo,
+		candidateRepo:  deps.CandidateRepo,
+		sessionRepo:    deps.SessionRepo,
+		roleRepo:       deps.RoleRepo,
+		sessionCleaner: deps.SessionCleaner,
+		cacheManager:   deps.CacheManager,
+		smsSender:      deps.SMSSender,
+
+		mfaRecoveryCodeRepo: deps.MFARecoveryCodeRepo,
+		accessTokenSigner:   deps.AccessTokenSigner,
+		loginAlertNotifier:  deps.LoginAlertNotifier,
+
+		impersonationLogRepo: deps.ImpersonationLogRepo,
+		authEventRecorder:    deps.AuthEventRecorder,
+		ipLocator:            deps.IPLocator,
+		passwordHasher:       deps.PasswordHasher,
+
+		candidateIdentityRepo: deps.CandidateIdentityRepo,
+		oidcProviders:         deps.OIDCProviders,
 	}
 }
 
 // LoginByIdentifierPassword is refactored to handle both email and phone logins.
-func (a *authUsecase) LoginByIdentifierPassword(ctx context.Context, req model.LoginRequest) (*model.Session, error) {
+// When the candidate has enabled the TOTP, a challenge is returned instead of the session.
+func (a *authUsecase) LoginByIdentifierPassword(ctx context.Context, req model.LoginRequest) (*model.Session, *model.MFAChallenge, error) {
 	logger := logrus.WithFields(logrus.Fields{
 		"ctx":        utils.DumpIncomingContext(ctx),
 		"identifier": req.Identifier,
@@ -34,32 +89,64 @@ func (a *authUsecase) LoginByIdentifierPassword(ctx context.Context, req model.L
 
 	if err := req.Validate(); err != nil {
 		logger.Error(err)
-		return nil, err
+		return nil, nil, err
 	}
 
-	var candidate *model.Candidate
-	var err error
-	if helper.ValidateEmail(req.Identifier) {
-		candidate, err = a.findCandidateByEmail(ctx, req.Identifier)
+	isEmail := helper.ValidateEmail(req.Identifier)
+	if isEmail {
+		req.IdentifierType = model.IdentifierTypeEmail
+		req.Identifier = helper.FormatEmail(req.Identifier)
 	} else {
-		if err := helper.RemoveLeadingZeroPhoneNumber(&req.Identifier); err != nil {
+		req.IdentifierType = model.IdentifierTypePhone
+		if err := normalizePhone(&req.Identifier); err != nil {
 			logger.Error(err)
-			return nil, err
+			return nil, nil, err
 		}
+	}
 
-		if err := helper.FormatPhoneNumberWithCountryCode(&req.Identifier, "ID"); err != nil {
-			logger.Error(err)
-			return nil, err
-		}
+	req.GeoLocation = a.resolveGeoLocation(ctx, req.IPAddress, req.GeoLocation)
+	event := newAuthEvent(model.AuthEventTypeLogin, 0, req)
+	if a.isLoginLocked(req.Identifier, req.IPAddress) {
+		logger.Warn(ErrLoginByEmailPasswordLocked)
+		a.recordAuthEvent(ctx, event, ErrLoginByEmailPasswordLocked)
+		return nil, nil, ErrLoginByEmailPasswordLocked
+	}
 
+	var candidate *model.Candidate
+	var err error
+	if isEmail {
+		candidate, err = a.findCandidateByEmail(ctx, req.Identifier)
+	} else {
 		candidate, err = a.findCandidateByPhone(ctx, req.Identifier)
 	}
-	if err != nil {
+	switch err {
+	case nil:
+	case ErrNotFound:
+		a.recordFailedLogin(req.Identifier, req.IPAddress)
+		a.recordAuthEvent(ctx, event, err)
+		return nil, nil, err
+	default:
 		logger.Error(err)
-		return nil, err
+		return nil, nil, err
 	}
 
-	return a.authenticateAndCreateSession(ctx, candidate, req)
+	event.CandidateID = null.IntFrom(candidate.ID)
+	session, challenge, err := a.authenticateAndCreateSession(ctx, candidate, req)
+	switch err {
+	case nil:
+		// the failed attempts are only reset once the second factor is completed
+		if session != nil {
+			a.resetFailedLogin(req.Identifier)
+		}
+		a.recordLoginEvent(ctx, event, session, challenge)
+	case ErrUnauthorized:
+		a.recordFailedLogin(req.Identifier, req.IPAddress)
+		a.recordAuthEvent(ctx, event, err)
+	case ErrEmailNotVerified, ErrLoginByEmailPasswordLocked:
+		a.recordAuthEvent(ctx, event, err)
+	}
+
+	return session, challenge, err
 }
 
 func (a *authUsecase) AuthenticateToken(ctx context.Context, accessToken string) (*model.Candidate, error) {
@@ -87,6 +174,9 @@ func (a *authUsecase) AuthenticateToken(ctx context.Context, accessToken string)
 	}
 
 	candidate.SessionID = session.ID
+	candidate.MFAAuthenticated = session.MFAAuthenticated
+	candidate.Roles = session.Roles
+	candidate.ImpersonatorID = session.ImpersonatorID.Int64
 
 	return candidate, nil
 }
@@ -111,9 +201,109 @@ func (a *authUsecase) DeleteSessionByID(ctx context.Context, sessionID int64) er
 	err = a.sessionRepo.Delete(ctx, session)
 	if err != nil {
 		logger.Error(err)
+		return err
+	}
+
+	a.recordAuthEvent(ctx, newSessionAuthEvent(model.AuthEventTypeSessionRevoke, session, model.ClientInfo{}), nil)
+
+	return nil
+}
+
+// Logout deletes the requester's current session
+func (a *authUsecase) Logout(ctx context.Context, requester *model.Candidate, client model.ClientInfo) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         utils.DumpIncomingContext(ctx),
+		"candidateID": requester.ID,
+		"sessionID":   requester.SessionID,
+	})
+
+	session, err := a.sessionRepo.FindByID(ctx, requester.SessionID)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if session == nil {
+		return ErrNotFound
+	}
+
+	if err := a.sessionRepo.Delete(ctx, session); err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	a.recordAuthEvent(ctx, newSessionAuthEvent(model.AuthEventTypeLogout, session, client), nil)
+
+	return nil
+}
+
+// FindAllSessions find all active sessions of the requester
+func (a *authUsecase) FindAllSessions(ctx context.Context, requester *model.Candidate) ([]*model.Session, error) {
+	sessions, err := a.sessionRepo.FindAllActiveByCandidateID(ctx, requester.ID)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":         utils.DumpIncomingContext(ctx),
+			"candidateID": requester.ID,
+		}).Error(err)
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+// RevokeSession deletes the requester's session by id.
+// Session owned by other candidate is treated as not found.
+func (a *authUsecase) RevokeSession(ctx context.Context, requester *model.Candidate, sessionID int64, client model.ClientInfo) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         utils.DumpIncomingContext(ctx),
+		"candidateID": requester.ID,
+		"sessionID":   sessionID,
+	})
+
+	session, err := a.sessionRepo.FindByID(ctx, sessionID)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if session == nil || session.CandidateID != requester.ID {
+		return ErrNotFound
+	}
+
+	err = a.sessionRepo.Delete(ctx, session)
+	if err != nil {
+		logger.Error(err)
+		return err
 	}
 
-	return err
+	a.recordAuthEvent(ctx, newSessionAuthEvent(model.AuthEventTypeSessionRevoke, session, client), nil)
+
+	return nil
+}
+
+// RevokeOtherSessions deletes all the requester's sessions except the current one
+func (a *authUsecase) RevokeOtherSessions(ctx context.Context, requester *model.Candidate, client model.ClientInfo) error {
+	err := a.sessionRepo.DeleteAllByCandidateID(ctx, requester.ID, requester.SessionID)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":         utils.DumpIncomingContext(ctx),
+			"candidateID": requester.ID,
+			"sessionID":   requester.SessionID,
+		}).Error(err)
+		return err
+	}
+
+	// recorded on the current session, since the other sessions are deleted at once
+	a.recordAuthEvent(ctx, &model.AuthEvent{
+		EventType:   model.AuthEventTypeSessionRevoke,
+		CandidateID: null.IntFrom(requester.ID),
+		SessionID:   null.IntFrom(requester.SessionID),
+		IPAddress:   client.IPAddress,
+		UserAgent:   client.UserAgent,
+		Reason:      "other sessions revoked",
+	}, nil)
+
+	return nil
 }
 
 // RefreshToken refresh the user's access and refresh token
@@ -131,6 +321,10 @@ func (a *authUsecase) RefreshToken(ctx context.Context, req model.RefreshTokenRe
 	}
 
 	if session == nil {
+		if err := a.revokeSessionOnRefreshTokenReuse(ctx, req); err != nil {
+			return nil, err
+		}
+
 		logger.Error(ErrNotFound)
 		return nil, ErrNotFound
 	}
@@ -145,81 +339,205 @@ func (a *authUsecase) RefreshToken(ctx context.Context, req model.RefreshTokenRe
 		return nil, ErrNotFound
 	}
 
+	req.GeoLocation = a.resolveGeoLocation(ctx, req.IPAddress, req.GeoLocation)
+	event := newSessionAuthEvent(model.AuthEventTypeTokenRefresh, session, model.ClientInfo{
+		UserAgent: req.UserAgent,
+		IPAddress: req.IPAddress,
+	})
+	event.Latitude = req.Latitude
+	event.Longitude = req.Longitude
+
+	// the impersonation session is time limited
+	if session.IsImpersonated() {
+		a.recordAuthEvent(ctx, event, ErrPermissionDenied)
+		return nil, ErrPermissionDenied
+	}
+
 	// old session is used to delete the old session cache
 	oldSess := *session
 
 	if session.RefreshTokenExpiredAt.Before(time.Now()) {
 		logger.Error(ErrRefreshTokenExpired)
+		a.recordAuthEvent(ctx, event, ErrRefreshTokenExpired)
 		return nil, ErrRefreshTokenExpired
 	}
 
-	newAccessToken, err := GenerateToken(a.sessionRepo, session.CandidateID)
-	if err != nil {
-		logger.Error(err)
-		return nil, err
-	}
-
-	newRefreshToken, err := GenerateToken(a.sessionRepo, session.CandidateID)
+	// reload the roles, so the role changes take effect on the next refresh
+	roles, err := findCandidateRoles(ctx, a.roleRepo, session.CandidateID)
 	if err != nil {
 		logger.Error(err)
 		return nil, err
 	}
 
-	session.AccessToken = newAccessToken
-	session.RefreshToken = newRefreshToken
 	session.IPAddress = req.IPAddress
 	session.UserAgent = req.UserAgent
 	session.Latitude = req.Latitude
 	session.Longitude = req.Longitude
+	session.Roles = roles
 
 	now := time.Now()
 	session.AccessTokenExpiredAt = now.Add(config.AccessTokenDuration())
 	session.RefreshTokenExpiredAt = now.Add(config.RefreshTokenDuration())
 
+	newAccessToken, err := GenerateAccessToken(a.sessionRepo, a.accessTokenSigner, session)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	newRefreshToken, err := GenerateToken(a.sessionRepo, session.CandidateID)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	session.AccessToken = newAccessToken
+	session.RefreshToken = newRefreshToken
+
 	session, err = a.sessionRepo.RefreshToken(ctx, &oldSess, session)
 	if err != nil {
 		logger.Error(err)
 		return nil, err
 	}
 
+	// the same refresh token is rotated by the concurrent refresh
+	if session == nil {
+		logger.Error(ErrNotFound)
+		a.recordAuthEvent(ctx, event, ErrNotFound)
+		return nil, ErrNotFound
+	}
+
+	a.recordAuthEvent(ctx, event, nil)
+
 	return session, nil
 }
 
-func (a *authUsecase) authenticateAndCreateSession(ctx context.Context, candidate *model.Candidate, req model.LoginRequest) (*model.Session, error) {
+// revokeSessionOnRefreshTokenReuse revoke the whole session when a superseded refresh token is replayed,
+// since either the legitimate client or an attacker is holding a stolen token.
+func (a *authUsecase) revokeSessionOnRefreshTokenReuse(ctx context.Context, req model.RefreshTokenRequest) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":       utils.DumpIncomingContext(ctx),
+		"ipAddress": req.IPAddress,
+		"userAgent": req.UserAgent,
+	})
+
+	superseded, err := a.sessionRepo.FindSupersededRefreshToken(ctx, req.RefreshToken)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if superseded == nil {
+		return nil
+	}
+
+	logger = logger.WithFields(logrus.Fields{
+		"securityEvent": "refresh_token_reuse",
+		"candidateID":   superseded.CandidateID,
+		"sessionID":     superseded.SessionID,
+	})
+	logger.Warn("superseded refresh token is reused, revoking the session")
+
+	session, err := a.sessionRepo.FindByID(ctx, superseded.SessionID)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if session != nil {
+		if err := a.sessionRepo.Delete(ctx, session); err != nil {
+			logger.Error(err)
+			return err
+		}
+	}
+
+	a.recordAuthEvent(ctx, &model.AuthEvent{
+		EventType:   model.AuthEventTypeTokenRefresh,
+		CandidateID: null.IntFrom(superseded.CandidateID),
+		SessionID:   null.IntFrom(superseded.SessionID),
+		IPAddress:   req.IPAddress,
+		UserAgent:   req.UserAgent,
+		Latitude:    req.Latitude,
+		Longitude:   req.Longitude,
+	}, ErrRefreshTokenReused)
+
+	return ErrRefreshTokenReused
+}
+
+func (a *authUsecase) authenticateAndCreateSession(ctx context.Context, candidate *model.Candidate, req model.LoginRequest) (*model.Session, *model.MFAChallenge, error) {
 	logger := logrus.WithFields(logrus.Fields{
 		"ctx":         utils.DumpIncomingContext(ctx),
 		"candidateID": candidate.ID,
 		"userAgent":   req.UserAgent,
 		"ipAddress":   req.IPAddress,
-		"latitude":    req.Latitude,
-		"longitude":   req.Longitude,
+		"geoLocation": utils.Dump(req.GeoLocation),
 	})
 
 	// Find the password associated with the candidate.
 	cipherPass, err := a.candidateRepo.FindPasswordByID(ctx, candidate.ID)
 	if err != nil {
 		logger.Error(err)
-		return nil, err
+		return nil, nil, err
 	}
 
-	if cipherPass == nil {
-		return nil, ErrUnauthorized
+	// the candidate signed up with the oidc provider has no password
+	if len(cipherPass) == 0 {
+		return nil, nil, ErrUnauthorized
 	}
 
 	// Check if the provided password matches.
-	if !helper.IsHashedStringMatch([]byte(req.PlainPassword), cipherPass) {
-		return nil, ErrUnauthorized
+	match, err := a.passwordHasher.Verify(req.PlainPassword, string(cipherPass))
+	if err != nil {
+		logger.Error(err)
+	}
+	if !match {
+		return nil, nil, ErrUnauthorized
 	}
 
-	// Generate access and refresh tokens.
-	accessToken, err := GenerateToken(a.sessionRepo, candidate.ID)
+	// The password hashed with the legacy algorithm or parameters is upgraded transparently,
+	// the login is not failed when the upgrade is failed.
+	if a.passwordHasher.NeedsRehash(string(cipherPass)) {
+		a.rehashPassword(ctx, candidate.ID, req.PlainPassword)
+	}
+
+	// Only block after the password matches, so the verification state is not leaked.
+	if req.IdentifierType == model.IdentifierTypeEmail && config.EmailVerificationRequiredForLogin() && !candidate.EmailVerifiedAt.Valid {
+		return nil, nil, ErrEmailNotVerified
+	}
+
+	return a.createSessionOrChallenge(ctx, candidate, req)
+}
+
+// rehashPassword hash the verified plain password with the current algorithm, then update the candidate's password
+func (a *authUsecase) rehashPassword(ctx context.Context, candidateID int64, plainPassword string) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         utils.DumpIncomingContext(ctx),
+		"candidateID": candidateID,
+	})
+
+	cipherPwd, err := a.passwordHasher.Hash(plainPassword)
 	if err != nil {
 		logger.Error(err)
-		return nil, err
+		return
 	}
 
-	refreshToken, err := GenerateToken(a.sessionRepo, candidate.ID)
+	if err := a.candidateRepo.UpdatePassword(ctx, candidateID, cipherPwd); err != nil {
+		logger.Error(err)
+	}
+}
+
+// createSession creates a new session for the authenticated candidate
+func (a *authUsecase) createSession(ctx context.Context, candidate *model.Candidate, req model.LoginRequest, mfaAuthenticated bool, riskFlags []model.LoginRiskFlag) (*model.Session, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         utils.DumpIncomingContext(ctx),
+		"candidateID": candidate.ID,
+		"userAgent":   req.UserAgent,
+		"ipAddress":   req.IPAddress,
+	})
+
+	roles, err := findCandidateRoles(ctx, a.roleRepo, candidate.ID)
 	if err != nil {
+		logger.Error(err)
 		return nil, err
 	}
 
@@ -227,22 +545,39 @@ func (a *authUsecase) authenticateAndCreateSession(ctx context.Context, candidat
 	session := &model.Session{
 		ID:                    utils.GenerateID(),
 		CandidateID:           candidate.ID,
-		AccessToken:           accessToken,
-		RefreshToken:          refreshToken,
 		AccessTokenExpiredAt:  now.Add(config.AccessTokenDuration()),
 		RefreshTokenExpiredAt: now.Add(config.RefreshTokenDuration()),
 		IPAddress:             req.IPAddress,
 		UserAgent:             req.UserAgent,
 		Latitude:              req.Latitude,
 		Longitude:             req.Longitude,
+		MFAAuthenticated:      mfaAuthenticated,
+		Roles:                 roles,
+		RiskFlags:             riskFlags,
+	}
+
+	// Generate access and refresh tokens.
+	accessToken, err := GenerateAccessToken(a.sessionRepo, a.accessTokenSigner, session)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
 	}
 
+	refreshToken, err := GenerateToken(a.sessionRepo, candidate.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	session.AccessToken = accessToken
+	session.RefreshToken = refreshToken
+
 	if err = a.sessionRepo.Create(ctx, session); err != nil {
 		logger.Error(err)
 		return nil, err
 	}
 
-	// TODO: implement delete session by worker
+	// delete the oldest sessions exceeding the max active session without blocking the login
+	a.sessionCleaner.EnqueueCleanup(candidate.ID)
 
 	return session, nil
 }
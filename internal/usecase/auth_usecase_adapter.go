@@ -44,7 +44,42 @@ func newAuthCandidate(candidate *model.Candidate) *auth.Candidate {
 		return nil
 	}
 	return &auth.Candidate{
-		ID:        candidate.ID,
-		SessionID: candidate.SessionID,
+		ID:               candidate.ID,
+		SessionID:        candidate.SessionID,
+		MFAAuthenticated: candidate.MFAAuthenticated,
+		Roles:            candidate.Roles,
+		ImpersonatorID:   candidate.ImpersonatorID,
+		APIKeyID:         candidate.APIKeyID,
+		Scopes:           candidate.Scopes,
 	}
 }
+
+// APIKeyAutherAdapter adapter for auth.APIKeyAuthenticator
+type APIKeyAutherAdapter struct {
+	apiKeyUsecase model.APIKeyUsecase
+}
+
+// NewAPIKeyAutherAdapter constructor
+func NewAPIKeyAutherAdapter(apiKeyUsecase model.APIKeyUsecase) *APIKeyAutherAdapter {
+	return &APIKeyAutherAdapter{
+		apiKeyUsecase: apiKeyUsecase,
+	}
+}
+
+// AuthenticateAPIKey authenticate api key
+func (a *APIKeyAutherAdapter) AuthenticateAPIKey(ctx context.Context, apiKey string) (*auth.Candidate, error) {
+	candidate, err := a.apiKeyUsecase.Authenticate(ctx, apiKey)
+	if errors.Is(err, ErrAPIKeyInvalid) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	if errors.Is(err, ErrAPIKeyExpired) {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return newAuthCandidate(candidate), nil
+}
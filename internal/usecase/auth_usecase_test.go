@@ -0,0 +1,119 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/irvankadhafi/talent-hub-service/internal/model"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+)
+
+func TestUsecase_RefreshToken(t *testing.T) {
+	const sessionID = int64(10)
+
+	tests := []struct {
+		name string
+		// setup return the refresh token to send
+		setup              func(t *testing.T, tu *testAuthUsecase) string
+		wantErr            error
+		wantSessionRevoked bool
+		wantFailureReason  string
+	}{
+		{
+			name: "rotate the current refresh token",
+			setup: func(t *testing.T, tu *testAuthUsecase) string {
+				return "refresh-token-1"
+			},
+		},
+		{
+			name: "reuse the superseded refresh token revokes the session",
+			setup: func(t *testing.T, tu *testAuthUsecase) string {
+				_, err := tu.RefreshToken(context.Background(), model.RefreshTokenRequest{RefreshToken: "refresh-token-1"})
+				require.NoError(t, err)
+				return "refresh-token-1"
+			},
+			wantErr:            ErrRefreshTokenReused,
+			wantSessionRevoked: true,
+			wantFailureReason:  ErrRefreshTokenReused.Error(),
+		},
+		{
+			name: "reuse the superseded refresh token of the revoked session",
+			setup: func(t *testing.T, tu *testAuthUsecase) string {
+				_, err := tu.RefreshToken(context.Background(), model.RefreshTokenRequest{RefreshToken: "refresh-token-1"})
+				require.NoError(t, err)
+				require.NoError(t, tu.sessionRepo.Delete(context.Background(), &model.Session{ID: sessionID}))
+				return "refresh-token-1"
+			},
+			wantErr:            ErrRefreshTokenReused,
+			wantSessionRevoked: true,
+			wantFailureReason:  ErrRefreshTokenReused.Error(),
+		},
+		{
+			name: "unknown refresh token",
+			setup: func(t *testing.T, tu *testAuthUsecase) string {
+				return "unknown-refresh-token"
+			},
+			wantErr: ErrNotFound,
+		},
+		{
+			name: "the refresh token is rotated by the concurrent refresh",
+			setup: func(t *testing.T, tu *testAuthUsecase) string {
+				tu.sessionRepo.afterFindByToken = func() {
+					tu.sessionRepo.sessions[sessionID].RefreshToken = "refresh-token-concurrent"
+				}
+				return "refresh-token-1"
+			},
+			wantErr:           ErrNotFound,
+			wantFailureReason: ErrNotFound.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tu := newTestAuthUsecase(t)
+			tu.candidateRepo.candidates[1] = &model.Candidate{ID: 1}
+			tu.sessionRepo.sessions[sessionID] = &model.Session{
+				ID:                    sessionID,
+				CandidateID:           1,
+				AccessToken:           "access-token-1",
+				RefreshToken:          "refresh-token-1",
+				AccessTokenExpiredAt:  time.Now().Add(time.Hour),
+				RefreshTokenExpiredAt: time.Now().Add(time.Hour),
+			}
+
+			refreshToken := tt.setup(t, tu)
+			tu.authEventRecorder.events = nil
+
+			session, err := tu.RefreshToken(context.Background(), model.RefreshTokenRequest{RefreshToken: refreshToken})
+			require.Equal(t, tt.wantErr, err)
+
+			stored, err := tu.sessionRepo.FindByID(context.Background(), sessionID)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantSessionRevoked, stored == nil)
+
+			if tt.wantFailureReason != "" {
+				require.Len(t, tu.authEventRecorder.events, 1)
+				event := tu.authEventRecorder.events[0]
+				require.Equal(t, model.AuthEventOutcomeFailure, event.Outcome)
+				require.Equal(t, tt.wantFailureReason, event.Reason)
+				require.Equal(t, null.IntFrom(1), event.CandidateID)
+			}
+
+			if tt.wantErr != nil {
+				require.Nil(t, session)
+				return
+			}
+
+			require.NotNil(t, session)
+			require.NotEqual(t, refreshToken, session.RefreshToken)
+			require.Equal(t, session.RefreshToken, stored.RefreshToken)
+
+			superseded, err := tu.sessionRepo.FindSupersededRefreshToken(context.Background(), refreshToken)
+			require.NoError(t, err)
+			require.NotNil(t, superseded)
+			require.Equal(t, sessionID, superseded.SessionID)
+		})
+	}
+}
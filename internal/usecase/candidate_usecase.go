@@ -7,15 +7,30 @@ import (
 	"github.com/irvankadhafi/talent-hub-service/utils"
 	"github.com/sirupsen/logrus"
 	"gopkg.in/guregu/null.v4"
+	"time"
 )
 
 type candidateUsecase struct {
-	candidateRepo model.CandidateRepository
+	candidateRepo           model.CandidateRepository
+	cityRepo                model.CityRepository
+	provinceRepo            model.ProvinceRepository
+	passwordHasher          model.PasswordHasher
+	breachedPasswordChecker model.BreachedPasswordChecker
 }
 
-func NewCandidateUsecase(candidateRepo model.CandidateRepository) model.CandidateUsecase {
+func NewCandidateUsecase(
+	candidateRepo model.CandidateRepository,
+	cityRepo model.CityRepository,
+	provinceRepo model.ProvinceRepository,
+	passwordHasher model.PasswordHasher,
+	breachedPasswordChecker model.BreachedPasswordChecker,
+) model.CandidateUsecase {
 	return &candidateUsecase{
-		candidateRepo: candidateRepo,
+		candidateRepo:           candidateRepo,
+		cityRepo:                cityRepo,
+		provinceRepo:            provinceRepo,
+		passwordHasher:          passwordHasher,
+		breachedPasswordChecker: breachedPasswordChecker,
 	}
 }
 
@@ -33,12 +48,22 @@ func (c *candidateUsecase) Create(ctx context.Context, input model.CreateCandida
 		return nil, err
 	}
 
+	err := checkPasswordPolicy(c.breachedPasswordChecker, input.Password, &model.Candidate{
+		FullName: input.FullName,
+		Email:    null.StringFrom(input.Email),
+		Phone:    null.StringFrom(input.Phone),
+	})
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
 	if err := c.checkCandidateExistence(ctx, input.Email, input.Phone); err != nil {
 		logger.Error(err)
 		return nil, err
 	}
 
-	cipherPwd, err := helper.HashString(input.Password)
+	cipherPwd, err := c.passwordHasher.Hash(input.Password)
 	if err != nil {
 		logger.Error(err)
 		return nil, err
@@ -75,6 +100,149 @@ func (c *candidateUsecase) FindByID(ctx context.Context, id int64) (*model.Candi
 	return candidate, nil
 }
 
+// FindByIDForPartner find the other candidate's profile, only allowed for the requester granted the candidate read
+func (c *candidateUsecase) FindByIDForPartner(ctx context.Context, requester *model.Candidate, id int64) (*model.Candidate, error) {
+	if !requester.HasPermission(model.PermissionCandidateRead) {
+		return nil, ErrPermissionDenied
+	}
+
+	return c.FindByID(ctx, id)
+}
+
+// UpdateProfile updates the requester's own profile, the fields not set on the input are left unchanged.
+// The city must be in the province, the province is taken from the city when only the city is set.
+// Only the set fields are written, so the cleared date of birth, city and province are saved.
+func (c *candidateUsecase) UpdateProfile(ctx context.Context, requester *model.Candidate, input model.UpdateProfileInput) (*model.Candidate, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         utils.DumpIncomingContext(ctx),
+		"candidateID": requester.ID,
+		"input":       utils.Dump(input),
+	})
+
+	if err := input.ValidateAndFormat(); err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	candidate, err := c.FindByID(ctx, requester.ID)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if input.IsEmpty() {
+		return candidate, nil
+	}
+
+	// the email and phone are set to invalidate the candidate's cache
+	profile := &model.Candidate{
+		ID:    candidate.ID,
+		Email: candidate.Email,
+		Phone: candidate.Phone,
+	}
+
+	var columns []string
+	if input.FullName != nil {
+		profile.FullName = *input.FullName
+		columns = append(columns, "full_name")
+	}
+
+	if input.Gender != nil {
+		profile.Gender = *input.Gender
+		columns = append(columns, "gender")
+	}
+
+	if input.DateOfBirth != nil {
+		profile.DateOfBirth, err = parseDateOfBirth(*input.DateOfBirth)
+		if err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+		columns = append(columns, "date_of_birth")
+	}
+
+	if input.CityID != nil || input.ProvinceID != nil {
+		profile.CityID, profile.ProvinceID, err = c.resolveCityAndProvince(ctx, candidate, input)
+		if err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+		columns = append(columns, "city_id", "province_id")
+	}
+
+	if err := c.candidateRepo.UpdateProfile(ctx, profile, columns); err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return c.FindByID(ctx, candidate.ID)
+}
+
+// resolveCityAndProvince check the updated city is in the updated province,
+// the candidate's current city is kept when only the province is updated.
+// The zero city clears the city, the province can only be cleared together with the city.
+func (c *candidateUsecase) resolveCityAndProvince(ctx context.Context, candidate *model.Candidate, input model.UpdateProfileInput) (cityID, provinceID int64, err error) {
+	cityID = candidate.CityID
+	if input.CityID != nil {
+		cityID = *input.CityID
+	}
+
+	provinceID = candidate.ProvinceID
+	if input.ProvinceID != nil {
+		provinceID = *input.ProvinceID
+	}
+
+	if input.ProvinceID != nil && provinceID != 0 {
+		province, err := c.provinceRepo.FindByID(ctx, provinceID)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		if province == nil {
+			return 0, 0, ErrProvinceInvalid
+		}
+	}
+
+	if cityID == 0 {
+		return cityID, provinceID, nil
+	}
+
+	city, err := c.cityRepo.FindByID(ctx, cityID)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	switch {
+	case city == nil:
+		return 0, 0, ErrCityInvalid
+	case input.ProvinceID == nil:
+		// the province follows the updated city
+		return cityID, city.ProvinceID, nil
+	case city.ProvinceID != provinceID:
+		return 0, 0, ErrCityInvalid
+	}
+
+	return cityID, provinceID, nil
+}
+
+// parseDateOfBirth parse the date of birth which must be in the past, the empty date of birth clears it
+func parseDateOfBirth(value string) (null.Time, error) {
+	if value == "" {
+		return null.Time{}, nil
+	}
+
+	dateOfBirth, err := time.Parse(model.DateOfBirthLayout, value)
+	if err != nil {
+		return null.Time{}, err
+	}
+
+	if !dateOfBirth.Before(time.Now()) {
+		return null.Time{}, ErrDateOfBirthInvalid
+	}
+
+	return null.TimeFrom(dateOfBirth), nil
+}
+
 func (c *candidateUsecase) checkCandidateExistence(ctx context.Context, email, phone string) error {
 	if email != "" {
 		if _, err := c.findCandidate(ctx, "email", email); err != nil {
@@ -103,8 +271,10 @@ func (c *candidateUsecase) findCandidate(ctx context.Context, field, value strin
 	}
 
 	switch {
-	case candidate != nil:
-		return nil, ErrDuplicateCandidate
+	case candidate != nil && field == "email":
+		return nil, ErrDuplicateEmail
+	case candidate != nil && field == "phone":
+		return nil, ErrDuplicatePhone
 	case err == ErrNotFound:
 		return nil, nil
 	case err != nil:
@@ -11,6 +11,16 @@ import (
 	"time"
 )
 
+// GenerateAccessToken generates the session's access token, it's signed when the signer is set,
+// otherwise it's an opaque token
+func GenerateAccessToken(sr model.SessionRepository, signer model.AccessTokenSigner, session *model.Session) (string, error) {
+	if signer == nil {
+		return GenerateToken(sr, session.CandidateID)
+	}
+
+	return signer.SignAccessToken(session)
+}
+
 // GenerateToken and check uniqueness
 func GenerateToken(sr model.SessionRepository, candidateID int64) (token string, err error) {
 	sleep := 10 * time.Millisecond
@@ -41,3 +51,20 @@ func GenerateToken(sr model.SessionRepository, candidateID int64) (token string,
 
 	return token, err
 }
+
+// findCandidateRoles find the roles of the candidate, every candidate has the candidate role
+func findCandidateRoles(ctx context.Context, roleRepo model.RoleRepository, candidateID int64) ([]model.Role, error) {
+	assigned, err := roleRepo.FindAllByCandidateID(ctx, candidateID)
+	if err != nil {
+		return nil, err
+	}
+
+	roles := []model.Role{model.RoleCandidate}
+	for _, role := range assigned {
+		if role != model.RoleCandidate {
+			roles = append(roles, role)
+		}
+	}
+
+	return roles, nil
+}
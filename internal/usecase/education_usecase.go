@@ -0,0 +1,213 @@
+package usecase
+
+import (
+	"context"
+	"github.com/irvankadhafi/talent-hub-service/internal/model"
+	"github.com/irvankadhafi/talent-hub-service/utils"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/guregu/null.v4"
+	"time"
+)
+
+type educationUsecase struct {
+	educationRepo model.EducationRepository
+}
+
+// NewEducationUsecase educationUsecase constructor
+func NewEducationUsecase(educationRepo model.EducationRepository) model.EducationUsecase {
+	return &educationUsecase{
+		educationRepo: educationRepo,
+	}
+}
+
+// FindAllByCandidate find the requester's own educations
+func (e *educationUsecase) FindAllByCandidate(ctx context.Context, requester *model.Candidate, pagination model.Pagination) ([]*model.Education, int64, error) {
+	pagination.Normalize()
+
+	educations, count, err := e.educationRepo.FindAllByCandidateID(ctx, requester.ID, pagination)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":         utils.DumpIncomingContext(ctx),
+			"requesterID": requester.ID,
+		}).Error(err)
+		return nil, 0, err
+	}
+
+	return educations, count, nil
+}
+
+// FindAllByCandidateIDForPartner find the other candidate's educations, only allowed for the requester granted the candidate read
+func (e *educationUsecase) FindAllByCandidateIDForPartner(ctx context.Context, requester *model.Candidate, candidateID int64, pagination model.Pagination) ([]*model.Education, int64, error) {
+	if !requester.HasPermission(model.PermissionCandidateRead) {
+		return nil, 0, ErrPermissionDenied
+	}
+
+	pagination.Normalize()
+
+	educations, count, err := e.educationRepo.FindAllByCandidateID(ctx, candidateID, pagination)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":         utils.DumpIncomingContext(ctx),
+			"candidateID": candidateID,
+		}).Error(err)
+		return nil, 0, err
+	}
+
+	return educations, count, nil
+}
+
+// FindByID find the requester's own education, the education of the other candidate is not found
+func (e *educationUsecase) FindByID(ctx context.Context, requester *model.Candidate, id int64) (*model.Education, error) {
+	education, err := e.educationRepo.FindByID(ctx, id)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":         utils.DumpIncomingContext(ctx),
+			"requesterID": requester.ID,
+			"educationID": id,
+		}).Error(err)
+		return nil, err
+	}
+
+	if education == nil || education.CandidateID != requester.ID {
+		return nil, ErrNotFound
+	}
+
+	return education, nil
+}
+
+func (e *educationUsecase) Create(ctx context.Context, requester *model.Candidate, input model.EducationInput) (*model.Education, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         utils.DumpIncomingContext(ctx),
+		"requesterID": requester.ID,
+	})
+
+	education := &model.Education{
+		ID:          utils.GenerateID(),
+		CandidateID: requester.ID,
+	}
+	if err := setEducationInput(education, input); err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if err := e.educationRepo.Create(ctx, education); err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return e.educationRepo.FindByID(ctx, education.ID)
+}
+
+// Update replaces all the fields of the requester's own education
+func (e *educationUsecase) Update(ctx context.Context, requester *model.Candidate, id int64, input model.EducationInput) (*model.Education, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         utils.DumpIncomingContext(ctx),
+		"requesterID": requester.ID,
+		"educationID": id,
+	})
+
+	education, err := e.FindByID(ctx, requester, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := setEducationInput(education, input); err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if err := e.educationRepo.Update(ctx, education); err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return e.educationRepo.FindByID(ctx, education.ID)
+}
+
+func (e *educationUsecase) Delete(ctx context.Context, requester *model.Candidate, id int64) error {
+	education, err := e.FindByID(ctx, requester, id)
+	if err != nil {
+		return err
+	}
+
+	if err := e.educationRepo.Delete(ctx, education); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":         utils.DumpIncomingContext(ctx),
+			"requesterID": requester.ID,
+			"educationID": id,
+		}).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// setEducationInput validate the input, then set the fields of the education.
+// The gpa scale defaults to the 4 point scale, the gpa must not exceed the scale.
+func setEducationInput(education *model.Education, input model.EducationInput) error {
+	if err := input.ValidateAndFormat(); err != nil {
+		return err
+	}
+
+	startDate, endDate, err := parseDateRange(input.StartDate, input.EndDate, input.UntilNow)
+	if err != nil {
+		return err
+	}
+
+	gpaScale := model.DefaultGPAScale
+	if input.GPAScale != nil {
+		gpaScale = *input.GPAScale
+	}
+
+	if !model.IsValidGPAScale(gpaScale) {
+		return ErrGPAScaleInvalid
+	}
+
+	gpa := null.FloatFromPtr(input.GPA)
+	if gpa.Valid && gpa.Float64 > gpaScale {
+		return ErrGPAExceedsScale
+	}
+
+	education.InstitutionName = input.InstitutionName
+	education.Major = input.Major
+	education.StartYear = startDate
+	education.EndYear = endDate
+	education.UntilNow = input.UntilNow
+	education.GPA = gpa
+	education.GPAScale = gpaScale
+
+	return nil
+}
+
+// parseDateRange parse the start and end dates formatted as model.DateLayout.
+// The end date is required unless until now, and must not be before the start date.
+func parseDateRange(start string, end *string, untilNow bool) (startDate time.Time, endDate null.Time, err error) {
+	startDate, err = time.Parse(model.DateLayout, start)
+	if err != nil {
+		return startDate, endDate, err
+	}
+
+	if startDate.After(time.Now()) {
+		return startDate, endDate, ErrStartDateInvalid
+	}
+
+	switch {
+	case untilNow && end != nil:
+		return startDate, endDate, ErrEndDateUntilNowConflict
+	case untilNow:
+		return startDate, endDate, nil
+	case end == nil:
+		return startDate, endDate, ErrEndDateRequired
+	}
+
+	parsedEndDate, err := time.Parse(model.DateLayout, *end)
+	if err != nil {
+		return startDate, endDate, err
+	}
+
+	if parsedEndDate.Before(startDate) {
+		return startDate, endDate, ErrEndDateBeforeStartDate
+	}
+
+	return startDate, null.TimeFrom(parsedEndDate), nil
+}
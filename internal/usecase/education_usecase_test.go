@@ -0,0 +1,98 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+)
+
+func TestUsecase_parseDateRange(t *testing.T) {
+	ptr := func(s string) *string {
+		return &s
+	}
+	date := func(s string) time.Time {
+		d, err := time.Parse("2006-01-02", s)
+		require.NoError(t, err)
+		return d
+	}
+	tomorrow := time.Now().AddDate(0, 0, 1).Format("2006-01-02")
+
+	tests := []struct {
+		name          string
+		start         string
+		end           *string
+		untilNow      bool
+		expectedStart time.Time
+		expectedEnd   null.Time
+		expectedErr   error
+	}{
+		{
+			name:          "with end date",
+			start:         "2020-01-01",
+			end:           ptr("2022-06-30"),
+			expectedStart: date("2020-01-01"),
+			expectedEnd:   null.TimeFrom(date("2022-06-30")),
+		},
+		{
+			name:          "end date on the start date",
+			start:         "2020-01-01",
+			end:           ptr("2020-01-01"),
+			expectedStart: date("2020-01-01"),
+			expectedEnd:   null.TimeFrom(date("2020-01-01")),
+		},
+		{
+			name:          "until now",
+			start:         "2020-01-01",
+			untilNow:      true,
+			expectedStart: date("2020-01-01"),
+		},
+		{
+			name:        "start date in the future",
+			start:       tomorrow,
+			untilNow:    true,
+			expectedErr: ErrStartDateInvalid,
+		},
+		{
+			name:        "end date with until now",
+			start:       "2020-01-01",
+			end:         ptr("2022-06-30"),
+			untilNow:    true,
+			expectedErr: ErrEndDateUntilNowConflict,
+		},
+		{
+			name:        "missing end date",
+			start:       "2020-01-01",
+			expectedErr: ErrEndDateRequired,
+		},
+		{
+			name:        "end date before the start date",
+			start:       "2020-01-02",
+			end:         ptr("2020-01-01"),
+			expectedErr: ErrEndDateBeforeStartDate,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			startDate, endDate, err := parseDateRange(tt.start, tt.end, tt.untilNow)
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedStart, startDate)
+			require.Equal(t, tt.expectedEnd, endDate)
+		})
+	}
+
+	t.Run("invalid dates", func(t *testing.T) {
+		_, _, err := parseDateRange("2020-13-01", nil, true)
+		require.Error(t, err)
+
+		_, _, err = parseDateRange("2020-01-01", ptr("2020-02-30"), false)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,263 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"github.com/irvankadhafi/talent-hub-service/internal/config"
+	"github.com/irvankadhafi/talent-hub-service/internal/helper"
+	"github.com/irvankadhafi/talent-hub-service/internal/model"
+	"github.com/irvankadhafi/talent-hub-service/pkg/cacher"
+	"github.com/irvankadhafi/talent-hub-service/utils"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/guregu/null.v4"
+	"time"
+)
+
+type emailVerificationUsecase struct {
+	candidateRepo              model.CandidateRepository
+	emailVerificationTokenRepo model.EmailVerificationTokenRepository
+	emailSender                model.EmailSender
+	passwordHasher             model.PasswordHasher
+	cacheManager               cacher.CacheManager
+}
+
+// NewEmailVerificationUsecase emailVerificationUsecase constructor
+func NewEmailVerificationUsecase(
+	candidateRepo model.CandidateRepository,
+	emailVerificationTokenRepo model.EmailVerificationTokenRepository,
+	emailSender model.EmailSender,
+	passwordHasher model.PasswordHasher,
+	cacheManager cacher.CacheManager,
+) model.EmailVerificationUsecase {
+	return &emailVerificationUsecase{
+		candidateRepo:              candidateRepo,
+		emailVerificationTokenRepo: emailVerificationTokenRepo,
+		emailSender:                emailSender,
+		passwordHasher:             passwordHasher,
+		cacheManager:               cacheManager,
+	}
+}
+
+// SendVerification sends the verification token to the candidate's current email in the background,
+// it can only be resent after the resend cooldown
+func (e *emailVerificationUsecase) SendVerification(ctx context.Context, candidateID int64) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         utils.DumpIncomingContext(ctx),
+		"candidateID": candidateID,
+	})
+
+	candidate, err := e.candidateRepo.FindByID(ctx, candidateID)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	switch {
+	case candidate == nil:
+		return ErrNotFound
+	case candidate.Email.String == "":
+		return ErrEmailNotSet
+	case candidate.EmailVerifiedAt.Valid:
+		return ErrEmailAlreadyVerified
+	}
+
+	// the cooldown is claimed atomically, so only one of the concurrent requests sends the email
+	cooldown := cacher.NewItemWithCustomTTL(newEmailVerificationCooldownCacheKey(candidate.ID), time.Now().Unix(), config.EmailVerificationResendCooldown())
+	claimed, err := e.cacheManager.StoreIfNotExist(cooldown)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if !claimed {
+		return ErrTooManyRequests
+	}
+
+	if err := e.sendVerificationEmail(ctx, candidate, logger); err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// Verify marks the candidate's email as verified using the single use token.
+// The token sent to the previous email is no longer valid once the email is changed.
+func (e *emailVerificationUsecase) Verify(ctx context.Context, token string) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx": utils.DumpIncomingContext(ctx),
+	})
+
+	if token == "" {
+		return ErrEmailVerificationTokenInvalid
+	}
+
+	verificationToken, err := e.emailVerificationTokenRepo.FindByToken(ctx, token)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if verificationToken == nil || !verificationToken.IsUsable() {
+		return ErrEmailVerificationTokenInvalid
+	}
+
+	logger = logger.WithField("candidateID", verificationToken.CandidateID)
+
+	candidate, err := e.candidateRepo.FindByID(ctx, verificationToken.CandidateID)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if candidate == nil || candidate.Email.String != verificationToken.Email {
+		return ErrEmailVerificationTokenInvalid
+	}
+
+	marked, err := e.emailVerificationTokenRepo.MarkAsUsed(ctx, verificationToken)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if !marked {
+		return ErrEmailVerificationTokenInvalid
+	}
+
+	err = e.candidateRepo.Update(ctx, &model.Candidate{
+		ID:              candidate.ID,
+		Email:           candidate.Email,
+		Phone:           candidate.Phone,
+		EmailVerifiedAt: null.TimeFrom(time.Now()),
+	})
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// ChangeEmail changes the requester's email after verifying the password.
+// The candidate signed up with the oidc provider has no password, so it must set the password first with the reset password.
+// The new email is unverified until the candidate opens the link sent to it,
+// failing to send the link is only logged since the candidate can resend it.
+func (e *emailVerificationUsecase) ChangeEmail(ctx context.Context, requester *model.Candidate, input model.ChangeEmailInput) error {
+	input.Email = helper.FormatEmail(input.Email)
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         utils.DumpIncomingContext(ctx),
+		"candidateID": requester.ID,
+		"email":       input.Email,
+	})
+
+	if requester.ImpersonatorID != 0 {
+		return ErrPermissionDenied
+	}
+
+	if err := input.Validate(); err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	candidate, err := e.candidateRepo.FindByID(ctx, requester.ID)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if candidate == nil {
+		return ErrNotFound
+	}
+
+	cipherPass, err := e.candidateRepo.FindPasswordByID(ctx, candidate.ID)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if len(cipherPass) == 0 {
+		return ErrPasswordNotSet
+	}
+
+	match, err := e.passwordHasher.Verify(input.Password, string(cipherPass))
+	if err != nil {
+		logger.Error(err)
+	}
+	if !match {
+		return ErrCurrentPasswordNotMatch
+	}
+
+	if candidate.Email.String == input.Email {
+		return nil
+	}
+
+	existing, err := e.candidateRepo.FindUnscopedByEmail(ctx, input.Email)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if existing != nil {
+		return ErrDuplicateEmail
+	}
+
+	if err := e.candidateRepo.UpdateEmail(ctx, candidate, input.Email); err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	candidate.Email = null.StringFrom(input.Email)
+	candidate.EmailVerifiedAt = null.Time{}
+	if err := e.sendVerificationEmail(ctx, candidate, logger); err != nil {
+		logger.Error(err)
+	}
+
+	return nil
+}
+
+// sendVerificationEmail creates the verification token bound to the candidate's current email,
+// then sends it in the background, so the slow smtp server doesn't block the registration and the email change
+func (e *emailVerificationUsecase) sendVerificationEmail(ctx context.Context, candidate *model.Candidate, logger *logrus.Entry) error {
+	token, err := utils.GenerateRandomStringURLSafe(config.DefaultEmailVerificationTokenLength)
+	if err != nil {
+		return err
+	}
+
+	verificationToken := &model.EmailVerificationToken{
+		ID:          utils.GenerateID(),
+		CandidateID: candidate.ID,
+		Email:       candidate.Email.String,
+		TokenHash:   helper.HashToken(token),
+		ExpiredAt:   time.Now().Add(config.EmailVerificationTokenDuration()),
+	}
+	if err := e.emailVerificationTokenRepo.Create(ctx, verificationToken); err != nil {
+		return err
+	}
+
+	go e.sendEmail(candidate.Email.String, newEmailVerificationEmailBody(token), logger)
+
+	return nil
+}
+
+// sendEmail sends the verification email detached from the request, so it uses its own context bounded by the smtp timeout
+func (e *emailVerificationUsecase) sendEmail(to, body string, logger *logrus.Entry) {
+	ctx, cancel := context.WithTimeout(context.Background(), config.SMTPTimeout())
+	defer cancel()
+
+	if err := e.emailSender.SendEmail(ctx, to, "Verify your email", body); err != nil {
+		logger.Error(err)
+	}
+}
+
+func newEmailVerificationEmailBody(token string) string {
+	if config.EmailVerificationURL() == "" {
+		return fmt.Sprintf("Use this token to verify your email: %s\n\nThe token expires in %s.", token, config.EmailVerificationTokenDuration())
+	}
+
+	link := fmt.Sprintf(config.EmailVerificationURL(), token)
+	return fmt.Sprintf("Open this link to verify your email: %s\n\nThe link expires in %s.", link, config.EmailVerificationTokenDuration())
+}
+
+func newEmailVerificationCooldownCacheKey(candidateID int64) string {
+	return fmt.Sprintf("cache:email_verification_cooldown:candidate_id:%d", candidateID)
+}
@@ -1,14 +1,64 @@
 package usecase
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
 
 // errors ...
 var (
-	ErrNotFound                   = errors.New("not found")
-	ErrUnauthorized               = errors.New("unauthorized")
-	ErrAccessTokenExpired         = errors.New("access token expired")
-	ErrRefreshTokenExpired        = errors.New("refresh token expired")
-	ErrLoginByEmailPasswordLocked = errors.New("user is locked from logging in using email and password")
-	ErrPermissionDenied           = errors.New("permission denied")
-	ErrDuplicateCandidate         = errors.New("candidate already exist")
+	ErrNotFound                      = errors.New("not found")
+	ErrUnauthorized                  = errors.New("unauthorized")
+	ErrAccessTokenExpired            = errors.New("access token expired")
+	ErrRefreshTokenExpired           = errors.New("refresh token expired")
+	ErrRefreshTokenReused            = errors.New("refresh token reused")
+	ErrLoginByEmailPasswordLocked    = errors.New("user is locked from logging in using email and password")
+	ErrPermissionDenied              = errors.New("permission denied")
+	ErrDuplicateCandidate            = errors.New("candidate already exist")
+	ErrPasswordResetTokenInvalid     = errors.New("password reset token is invalid or expired")
+	ErrCurrentPasswordNotMatch       = errors.New("current password not match")
+	ErrPasswordNotSet                = errors.New("password is not set, reset the password to set one")
+	ErrOTPInvalid                    = errors.New("otp is invalid or expired")
+	ErrTooManyRequests               = errors.New("too many requests")
+	ErrEmailNotSet                   = errors.New("email is not set")
+	ErrEmailNotVerified              = errors.New("email is not verified")
+	ErrEmailAlreadyVerified          = errors.New("email is already verified")
+	ErrEmailVerificationTokenInvalid = errors.New("email verification token is invalid or expired")
+	ErrMFAChallengeInvalid           = errors.New("mfa challenge is invalid or expired")
+	ErrMFACodeInvalid                = errors.New("mfa code is invalid")
+	ErrMFAAlreadyEnabled             = errors.New("mfa is already enabled")
+	ErrMFANotEnabled                 = errors.New("mfa is not enabled")
+	ErrMFANotEnrolled                = errors.New("mfa is not enrolled")
+	ErrInvalidRole                   = errors.New("role is invalid")
+	ErrOIDCProviderNotFound          = errors.New("oidc provider not found")
+	ErrOIDCStateInvalid              = errors.New("oidc state is invalid or expired")
+	ErrOIDCAuthenticationFailed      = errors.New("oidc authentication failed")
+	ErrOIDCLinkRequired              = errors.New("email is registered, sign in to link the oidc identity")
+	ErrOIDCIdentityAlreadyLinked     = errors.New("oidc identity is linked to another candidate")
+	ErrAPIKeyInvalid                 = errors.New("api key is invalid")
+	ErrAPIKeyExpired                 = errors.New("api key expired")
+	ErrAPIKeyRevoked                 = errors.New("api key is revoked or expired")
+	ErrAPIKeyScopeInvalid            = errors.New("api key scope is invalid")
+	ErrAPIKeyExpiryInvalid           = errors.New("api key expiry must be in the future")
+	ErrDateOfBirthInvalid            = errors.New("date of birth must be in the past")
+	ErrCityInvalid                   = errors.New("city is not found or not in the province")
+	ErrProvinceInvalid               = errors.New("province is not found")
+	ErrStartDateInvalid              = errors.New("start date must not be in the future")
+	ErrEndDateRequired               = errors.New("end date is required unless until now")
+	ErrEndDateUntilNowConflict       = errors.New("end date must not be set when until now")
+	ErrEndDateBeforeStartDate        = errors.New("end date must not be before the start date")
+	ErrGPAScaleInvalid               = errors.New("gpa scale is not supported")
+	ErrGPAExceedsScale               = errors.New("gpa must not exceed the gpa scale")
+	ErrDuplicateEmail                = fmt.Errorf("%w: email already registered", ErrDuplicateCandidate)
+	ErrDuplicatePhone                = fmt.Errorf("%w: phone already registered", ErrDuplicateCandidate)
 )
+
+// PasswordPolicyError the password is rejected by the password policy, the reasons are reported on the password field
+type PasswordPolicyError struct {
+	Reasons []string
+}
+
+func (e *PasswordPolicyError) Error() string {
+	return fmt.Sprintf("password is rejected by the password policy: %s", strings.Join(e.Reasons, ", "))
+}
@@ -0,0 +1,182 @@
+package usecase
+
+import (
+	"context"
+	"github.com/irvankadhafi/talent-hub-service/internal/model"
+	"github.com/irvankadhafi/talent-hub-service/utils"
+	"github.com/sirupsen/logrus"
+)
+
+type experienceUsecase struct {
+	experienceRepo model.ExperienceRepository
+}
+
+// NewExperienceUsecase experienceUsecase constructor
+func NewExperienceUsecase(experienceRepo model.ExperienceRepository) model.ExperienceUsecase {
+	return &experienceUsecase{
+		experienceRepo: experienceRepo,
+	}
+}
+
+// FindAllByCandidate find the requester's own experiences
+func (e *experienceUsecase) FindAllByCandidate(ctx context.Context, requester *model.Candidate, pagination model.Pagination) ([]*model.Experience, int64, error) {
+	pagination.Normalize()
+
+	experiences, count, err := e.experienceRepo.FindAllByCandidateID(ctx, requester.ID, pagination)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":         utils.DumpIncomingContext(ctx),
+			"requesterID": requester.ID,
+		}).Error(err)
+		return nil, 0, err
+	}
+
+	return experiences, count, nil
+}
+
+// FindAllByCandidateIDForPartner find the other candidate's experiences, only allowed for the requester granted the candidate read
+func (e *experienceUsecase) FindAllByCandidateIDForPartner(ctx context.Context, requester *model.Candidate, candidateID int64, pagination model.Pagination) ([]*model.Experience, int64, error) {
+	if !requester.HasPermission(model.PermissionCandidateRead) {
+		return nil, 0, ErrPermissionDenied
+	}
+
+	pagination.Normalize()
+
+	experiences, count, err := e.experienceRepo.FindAllByCandidateID(ctx, candidateID, pagination)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":         utils.DumpIncomingContext(ctx),
+			"candidateID": candidateID,
+		}).Error(err)
+		return nil, 0, err
+	}
+
+	return experiences, count, nil
+}
+
+// FindByID find the requester's own experience, the experience of the other candidate is not found
+func (e *experienceUsecase) FindByID(ctx context.Context, requester *model.Candidate, id int64) (*model.Experience, error) {
+	experience, err := e.experienceRepo.FindByID(ctx, id)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":          utils.DumpIncomingContext(ctx),
+			"requesterID":  requester.ID,
+			"experienceID": id,
+		}).Error(err)
+		return nil, err
+	}
+
+	if experience == nil || experience.CandidateID != requester.ID {
+		return nil, ErrNotFound
+	}
+
+	return experience, nil
+}
+
+func (e *experienceUsecase) Create(ctx context.Context, requester *model.Candidate, input model.ExperienceInput) (*model.Experience, []*model.Experience, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         utils.DumpIncomingContext(ctx),
+		"requesterID": requester.ID,
+	})
+
+	experience := &model.Experience{
+		ID:          utils.GenerateID(),
+		CandidateID: requester.ID,
+	}
+	if err := setExperienceInput(experience, input); err != nil {
+		logger.Error(err)
+		return nil, nil, err
+	}
+
+	if err := e.experienceRepo.Create(ctx, experience); err != nil {
+		logger.Error(err)
+		return nil, nil, err
+	}
+
+	return e.findByIDWithOverlaps(ctx, experience.ID)
+}
+
+// Update replaces all the fields of the requester's own experience
+func (e *experienceUsecase) Update(ctx context.Context, requester *model.Candidate, id int64, input model.ExperienceInput) (*model.Experience, []*model.Experience, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":          utils.DumpIncomingContext(ctx),
+		"requesterID":  requester.ID,
+		"experienceID": id,
+	})
+
+	experience, err := e.FindByID(ctx, requester, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := setExperienceInput(experience, input); err != nil {
+		logger.Error(err)
+		return nil, nil, err
+	}
+
+	if err := e.experienceRepo.Update(ctx, experience); err != nil {
+		logger.Error(err)
+		return nil, nil, err
+	}
+
+	return e.findByIDWithOverlaps(ctx, experience.ID)
+}
+
+func (e *experienceUsecase) Delete(ctx context.Context, requester *model.Candidate, id int64) error {
+	experience, err := e.FindByID(ctx, requester, id)
+	if err != nil {
+		return err
+	}
+
+	if err := e.experienceRepo.Delete(ctx, experience); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":          utils.DumpIncomingContext(ctx),
+			"requesterID":  requester.ID,
+			"experienceID": id,
+		}).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// findByIDWithOverlaps find the saved experience with the overlapping experiences,
+// the saved experience is still returned when the overlaps can't be found
+func (e *experienceUsecase) findByIDWithOverlaps(ctx context.Context, id int64) (*model.Experience, []*model.Experience, error) {
+	experience, err := e.experienceRepo.FindByID(ctx, id)
+	if err != nil || experience == nil {
+		return experience, nil, err
+	}
+
+	overlaps, err := e.experienceRepo.FindAllOverlapping(ctx, experience)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":          utils.DumpIncomingContext(ctx),
+			"experienceID": id,
+		}).Error(err)
+	}
+
+	return experience, overlaps, nil
+}
+
+// setExperienceInput validate the input, then set the fields of the experience
+func setExperienceInput(experience *model.Experience, input model.ExperienceInput) error {
+	if err := input.ValidateAndFormat(); err != nil {
+		return err
+	}
+
+	startDate, endDate, err := parseDateRange(input.StartDate, input.EndDate, input.UntilNow)
+	if err != nil {
+		return err
+	}
+
+	experience.CompanyName = input.CompanyName
+	experience.CompanyAddress = input.CompanyAddress
+	experience.Position = input.Position
+	experience.JobDescription = input.JobDescription
+	experience.StartYear = startDate
+	experience.EndYear = endDate
+	experience.UntilNow = input.UntilNow
+
+	return nil
+}
@@ -0,0 +1,27 @@
+package usecase
+
+import (
+	"context"
+	"github.com/irvankadhafi/talent-hub-service/internal/model"
+	"github.com/irvankadhafi/talent-hub-service/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// resolveGeoLocation fallback to the coarse location of the ip address when the client doesn't send the coordinates,
+// the location stays unknown when the ip locator is not configured
+func (a *authUsecase) resolveGeoLocation(ctx context.Context, ipAddress string, location model.GeoLocation) model.GeoLocation {
+	if location.IsKnown() || a.ipLocator == nil {
+		return location
+	}
+
+	resolved, err := a.ipLocator.Locate(ipAddress)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":       utils.DumpIncomingContext(ctx),
+			"ipAddress": ipAddress,
+		}).Error(err)
+		return location
+	}
+
+	return resolved
+}
@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"context"
+	"github.com/irvankadhafi/talent-hub-service/internal/config"
+	"github.com/irvankadhafi/talent-hub-service/internal/model"
+	"github.com/irvankadhafi/talent-hub-service/utils"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/guregu/null.v4"
+	"time"
+)
+
+// Impersonate creates a time limited session on behalf of the candidate for the requester,
+// the session can't be refreshed and it's recorded in the impersonation logs
+func (a *authUsecase) Impersonate(ctx context.Context, requester *model.Candidate, candidateID int64, req model.ImpersonateRequest) (*model.Session, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":            utils.DumpIncomingContext(ctx),
+		"impersonatorID": requester.ID,
+		"candidateID":    candidateID,
+		"ipAddress":      req.IPAddress,
+	})
+
+	if err := req.Validate(); err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if requester.ImpersonatorID != 0 || requester.ID == candidateID ||
+		!requester.HasPermission(model.PermissionCandidateImpersonate) {
+		return nil, ErrPermissionDenied
+	}
+
+	candidate, err := a.candidateRepo.FindByID(ctx, candidateID)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if candidate == nil {
+		return nil, ErrNotFound
+	}
+
+	roles, err := findCandidateRoles(ctx, a.roleRepo, candidate.ID)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	// the other admins can't be impersonated, so the impersonation can't escalate the privilege
+	if model.HasPermission(roles, model.PermissionCandidateImpersonate) || model.HasPermission(roles, model.PermissionRoleManage) {
+		return nil, ErrPermissionDenied
+	}
+
+	expiredAt := time.Now().Add(config.ImpersonationDuration())
+	session := &model.Session{
+		ID:                    utils.GenerateID(),
+		CandidateID:           candidate.ID,
+		AccessTokenExpiredAt:  expiredAt,
+		RefreshTokenExpiredAt: expiredAt,
+		IPAddress:             req.IPAddress,
+		UserAgent:             req.UserAgent,
+		Roles:                 roles,
+		ImpersonatorID:        null.IntFrom(requester.ID),
+	}
+
+	session.AccessToken, err = GenerateAccessToken(a.sessionRepo, a.accessTokenSigner, session)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	session.RefreshToken, err = GenerateToken(a.sessionRepo, candidate.ID)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if err := a.sessionRepo.Create(ctx, session); err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	err = a.impersonationLogRepo.Create(ctx, &model.ImpersonationLog{
+		ID:             utils.GenerateID(),
+		ImpersonatorID: requester.ID,
+		CandidateID:    candidate.ID,
+		SessionID:      session.ID,
+		Reason:         req.Reason,
+		IPAddress:      req.IPAddress,
+		UserAgent:      req.UserAgent,
+		ExpiredAt:      expiredAt,
+	})
+	if err != nil {
+		// the impersonation must not be used without the audit record
+		logger.Error(err)
+		if err := a.sessionRepo.Delete(ctx, session); err != nil {
+			logger.Error(err)
+		}
+		return nil, err
+	}
+
+	// recorded on the candidate's activity, so the candidate is aware of the impersonation
+	a.recordAuthEvent(ctx, newSessionAuthEvent(model.AuthEventTypeImpersonate, session, model.ClientInfo{
+		UserAgent: req.UserAgent,
+		IPAddress: req.IPAddress,
+	}), nil)
+
+	logger.WithFields(logrus.Fields{
+		"securityEvent": "impersonation_started",
+		"sessionID":     session.ID,
+	}).Warn("impersonation session is created")
+
+	return session, nil
+}
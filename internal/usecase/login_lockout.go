@@ -0,0 +1,175 @@
+package usecase
+
+import (
+	"fmt"
+	"github.com/irvankadhafi/talent-hub-service/internal/config"
+	"github.com/irvankadhafi/talent-hub-service/pkg/cacher"
+	"github.com/sirupsen/logrus"
+	"strconv"
+	"time"
+)
+
+type loginAttemptSubject string
+
+const (
+	loginAttemptSubjectIdentifier loginAttemptSubject = "identifier"
+	loginAttemptSubjectIP         loginAttemptSubject = "ip"
+	loginAttemptSubjectCandidate  loginAttemptSubject = "candidate"
+)
+
+// isLoginLocked check whether the identifier or the ip address is locked from logging in.
+// Cache error will not lock the candidate out.
+func (a *authUsecase) isLoginLocked(identifier, ipAddress string) bool {
+	keys := []string{newLoginLockCacheKey(loginAttemptSubjectIdentifier, identifier)}
+	if ipAddress != "" {
+		keys = append(keys, newLoginLockCacheKey(loginAttemptSubjectIP, ipAddress))
+	}
+
+	return a.isAnyLoginLocked(keys)
+}
+
+// isCandidateLoginLocked check whether the candidate is locked by the failed second factor codes
+// or the ip address is locked from logging in
+func (a *authUsecase) isCandidateLoginLocked(candidateID int64, ipAddress string) bool {
+	keys := []string{newLoginLockCacheKey(loginAttemptSubjectCandidate, strconv.FormatInt(candidateID, 10))}
+	if ipAddress != "" {
+		keys = append(keys, newLoginLockCacheKey(loginAttemptSubjectIP, ipAddress))
+	}
+
+	return a.isAnyLoginLocked(keys)
+}
+
+func (a *authUsecase) isAnyLoginLocked(keys []string) bool {
+	for _, key := range keys {
+		reply, err := a.cacheManager.Get(key)
+		if err != nil {
+			logrus.WithField("key", key).Error(err)
+			continue
+		}
+
+		if reply != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// recordFailedLogin increase the failed login attempts of the identifier and the ip address,
+// then lock them when the attempts reach the max attempts
+func (a *authUsecase) recordFailedLogin(identifier, ipAddress string) {
+	a.recordFailedLoginAttempt(loginAttemptSubjectIdentifier, identifier, config.LoginMaxAttemptsPerIdentifier())
+	if ipAddress != "" {
+		a.recordFailedLoginAttempt(loginAttemptSubjectIP, ipAddress, config.LoginMaxAttemptsPerIP())
+	}
+}
+
+// recordFailedMFACode increase the failed second factor attempts of the candidate and the ip address,
+// so the second factor can't be brute-forced by requesting new challenges with the valid password
+func (a *authUsecase) recordFailedMFACode(candidateID int64, ipAddress string) {
+	a.recordFailedLoginAttempt(loginAttemptSubjectCandidate, strconv.FormatInt(candidateID, 10), config.LoginMaxAttemptsPerIdentifier())
+	if ipAddress != "" {
+		a.recordFailedLoginAttempt(loginAttemptSubjectIP, ipAddress, config.LoginMaxAttemptsPerIP())
+	}
+}
+
+// resetFailedMFACode reset the failed second factor attempts and lockout streak of the candidate
+func (a *authUsecase) resetFailedMFACode(candidateID int64) {
+	value := strconv.FormatInt(candidateID, 10)
+	err := a.cacheManager.DeleteByKeys([]string{
+		newLoginAttemptCacheKey(loginAttemptSubjectCandidate, value),
+		newLoginLockCountCacheKey(loginAttemptSubjectCandidate, value),
+	})
+	if err != nil {
+		logrus.WithField("candidateID", candidateID).Error(err)
+	}
+}
+
+// resetFailedLogin reset the failed login attempts and lockout streak of the identifier.
+// The ip address is not reset, so a valid account can't be used to keep trying other accounts.
+func (a *authUsecase) resetFailedLogin(identifier string) {
+	err := a.cacheManager.DeleteByKeys([]string{
+		newLoginAttemptCacheKey(loginAttemptSubjectIdentifier, identifier),
+		newLoginLockCountCacheKey(loginAttemptSubjectIdentifier, identifier),
+	})
+	if err != nil {
+		logrus.WithField("identifier", identifier).Error(err)
+	}
+}
+
+func (a *authUsecase) recordFailedLoginAttempt(subject loginAttemptSubject, value string, maxAttempts int64) {
+	logger := logrus.WithFields(logrus.Fields{
+		"subject": subject,
+		"value":   value,
+	})
+
+	attemptKey := newLoginAttemptCacheKey(subject, value)
+	attempts, err := a.increaseCounter(attemptKey, config.LoginAttemptWindow())
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+
+	if attempts < maxAttempts {
+		return
+	}
+
+	// the lockout streak is kept longer than the lockout itself, so consecutive lockouts get longer
+	lockCountKey := newLoginLockCountCacheKey(subject, value)
+	lockCount, err := a.increaseCounter(lockCountKey, 2*config.LoginLockoutMaxDuration())
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+
+	duration := loginLockoutDuration(lockCount, config.LoginLockoutBaseDuration(), config.LoginLockoutMaxDuration())
+	item := cacher.NewItemWithCustomTTL(newLoginLockCacheKey(subject, value), lockCount, duration)
+	if err := a.cacheManager.StoreWithoutBlocking(item); err != nil {
+		logger.Error(err)
+		return
+	}
+
+	if err := a.cacheManager.DeleteByKeys([]string{attemptKey}); err != nil {
+		logger.Error(err)
+	}
+
+	logger.WithFields(logrus.Fields{
+		"lockCount": lockCount,
+		"duration":  duration,
+	}).Warn("login is locked")
+}
+
+// increaseCounter increase the counter by one and return the counter value,
+// the ttl is set atomically along with the increment when the counter has no ttl
+func (a *authUsecase) increaseCounter(key string, ttl time.Duration) (int64, error) {
+	return a.cacheManager.IncreaseCachedValueByOneWithTTL(key, ttl)
+}
+
+// loginLockoutDuration return the lockout duration which doubled on each consecutive lockout
+func loginLockoutDuration(lockCount int64, base, max time.Duration) time.Duration {
+	duration := base
+	for i := int64(1); i < lockCount; i++ {
+		duration *= 2
+		if duration >= max {
+			return max
+		}
+	}
+
+	if duration > max {
+		return max
+	}
+
+	return duration
+}
+
+func newLoginAttemptCacheKey(subject loginAttemptSubject, value string) string {
+	return fmt.Sprintf("cache:login_attempt:%s:%s", subject, value)
+}
+
+func newLoginLockCountCacheKey(subject loginAttemptSubject, value string) string {
+	return fmt.Sprintf("cache:login_lock_count:%s:%s", subject, value)
+}
+
+func newLoginLockCacheKey(subject loginAttemptSubject, value string) string {
+	return fmt.Sprintf("cache:login_lock:%s:%s", subject, value)
+}
@@ -0,0 +1,246 @@
+package usecase
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/irvankadhafi/talent-hub-service/internal/config"
+	"github.com/irvankadhafi/talent-hub-service/internal/helper"
+	"github.com/irvankadhafi/talent-hub-service/internal/model"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+)
+
+func TestUsecase_recordFailedLogin(t *testing.T) {
+	maxPerIdentifier := int(config.LoginMaxAttemptsPerIdentifier())
+	maxPerIP := int(config.LoginMaxAttemptsPerIP())
+
+	tests := []struct {
+		name         string
+		failedLogins func(tu *testAuthUsecase)
+		identifier   string
+		ipAddress    string
+		wantLocked   bool
+		wantAttempts string
+	}{
+		{
+			name: "below the max attempts per identifier",
+			failedLogins: func(tu *testAuthUsecase) {
+				for i := 0; i < maxPerIdentifier-1; i++ {
+					tu.recordFailedLogin("john@example.com", "10.0.0.1")
+				}
+			},
+			identifier:   "john@example.com",
+			ipAddress:    "10.0.0.1",
+			wantLocked:   false,
+			wantAttempts: strconv.Itoa(maxPerIdentifier - 1),
+		},
+		{
+			name: "reach the max attempts per identifier",
+			failedLogins: func(tu *testAuthUsecase) {
+				for i := 0; i < maxPerIdentifier; i++ {
+					tu.recordFailedLogin("john@example.com", "10.0.0.1")
+				}
+			},
+			identifier: "john@example.com",
+			ipAddress:  "10.0.0.1",
+			wantLocked: true,
+		},
+		{
+			name: "the other identifier from the other ip is not locked",
+			failedLogins: func(tu *testAuthUsecase) {
+				for i := 0; i < maxPerIdentifier; i++ {
+					tu.recordFailedLogin("john@example.com", "10.0.0.1")
+				}
+			},
+			identifier: "jane@example.com",
+			ipAddress:  "10.0.0.2",
+			wantLocked: false,
+		},
+		{
+			name: "the locked identifier is locked from the other ip",
+			failedLogins: func(tu *testAuthUsecase) {
+				for i := 0; i < maxPerIdentifier; i++ {
+					tu.recordFailedLogin("john@example.com", "10.0.0.1")
+				}
+			},
+			identifier: "john@example.com",
+			ipAddress:  "10.0.0.2",
+			wantLocked: true,
+		},
+		{
+			name: "reach the max attempts per ip with the different identifiers",
+			failedLogins: func(tu *testAuthUsecase) {
+				for i := 0; i < maxPerIP; i++ {
+					tu.recordFailedLogin("candidate"+strconv.Itoa(i)+"@example.com", "10.0.0.1")
+				}
+			},
+			identifier: "jane@example.com",
+			ipAddress:  "10.0.0.1",
+			wantLocked: true,
+		},
+		{
+			name: "below the max attempts per ip with the different identifiers",
+			failedLogins: func(tu *testAuthUsecase) {
+				for i := 0; i < maxPerIP-1; i++ {
+					tu.recordFailedLogin("candidate"+strconv.Itoa(i)+"@example.com", "10.0.0.1")
+				}
+			},
+			identifier: "jane@example.com",
+			ipAddress:  "10.0.0.1",
+			wantLocked: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tu := newTestAuthUsecase(t)
+			tt.failedLogins(tu)
+
+			require.Equal(t, tt.wantLocked, tu.isLoginLocked(tt.identifier, tt.ipAddress))
+			if tt.wantAttempts != "" {
+				require.Equal(t, tt.wantAttempts, tu.cacheManager.value(newLoginAttemptCacheKey(loginAttemptSubjectIdentifier, tt.identifier)))
+			}
+		})
+	}
+}
+
+func TestUsecase_recordFailedLogin_exponentialLockout(t *testing.T) {
+	const identifier = "john@example.com"
+	base := config.LoginLockoutBaseDuration()
+	lockKey := newLoginLockCacheKey(loginAttemptSubjectIdentifier, identifier)
+
+	tu := newTestAuthUsecase(t)
+	lockout := func() {
+		for i := int64(0); i < config.LoginMaxAttemptsPerIdentifier(); i++ {
+			tu.recordFailedLogin(identifier, "")
+		}
+	}
+
+	for i, want := range []time.Duration{base, 2 * base, 4 * base, 8 * base} {
+		lockout()
+		require.True(t, tu.isLoginLocked(identifier, ""), "lockout %d", i+1)
+		require.Equal(t, want, tu.cacheManager.ttl(lockKey), "lockout %d", i+1)
+		require.False(t, tu.cacheManager.exists(newLoginAttemptCacheKey(loginAttemptSubjectIdentifier, identifier)))
+
+		// the lock is expired, the streak is kept
+		require.NoError(t, tu.cacheManager.DeleteByKeys([]string{lockKey}))
+	}
+
+	// the successful login resets the streak
+	tu.resetFailedLogin(identifier)
+	lockout()
+	require.Equal(t, base, tu.cacheManager.ttl(lockKey))
+}
+
+func TestUsecase_loginLockoutDuration(t *testing.T) {
+	base := time.Minute
+	max := 24 * time.Hour
+
+	tests := []struct {
+		lockCount int64
+		want      time.Duration
+	}{
+		{lockCount: 1, want: base},
+		{lockCount: 2, want: 2 * base},
+		{lockCount: 3, want: 4 * base},
+		{lockCount: 11, want: 1024 * base},
+		{lockCount: 12, want: max},
+		{lockCount: 100, want: max},
+	}
+
+	for _, tt := range tests {
+		t.Run(strconv.FormatInt(tt.lockCount, 10), func(t *testing.T) {
+			require.Equal(t, tt.want, loginLockoutDuration(tt.lockCount, base, max))
+		})
+	}
+
+	require.Equal(t, time.Hour, loginLockoutDuration(1, 2*time.Hour, time.Hour))
+}
+
+func TestUsecase_resetFailedLogin(t *testing.T) {
+	const identifier = "john@example.com"
+	const ipAddress = "10.0.0.1"
+
+	tu := newTestAuthUsecase(t)
+	tu.recordFailedLogin(identifier, ipAddress)
+	tu.resetFailedLogin(identifier)
+
+	require.False(t, tu.cacheManager.exists(newLoginAttemptCacheKey(loginAttemptSubjectIdentifier, identifier)))
+	// the ip attempts are kept, so the valid account can't be used to keep trying the other accounts
+	require.Equal(t, "1", tu.cacheManager.value(newLoginAttemptCacheKey(loginAttemptSubjectIP, ipAddress)))
+}
+
+func TestUsecase_LoginByIdentifierPassword_resetFailedLogin(t *testing.T) {
+	const identifier = "john@example.com"
+	const password = "secret123"
+	attemptKey := newLoginAttemptCacheKey(loginAttemptSubjectIdentifier, identifier)
+
+	tests := []struct {
+		name        string
+		enableTOTP  bool
+		wantSession bool
+	}{
+		{
+			name:        "reset on the login without the second factor",
+			enableTOTP:  false,
+			wantSession: true,
+		},
+		{
+			name:        "reset only after the second factor is completed",
+			enableTOTP:  true,
+			wantSession: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setTestTOTPEncryptionKey(t)
+
+			tu := newTestAuthUsecase(t)
+			candidate := &model.Candidate{
+				ID:              1,
+				Email:           null.StringFrom(identifier),
+				EmailVerifiedAt: null.TimeFrom(time.Now()),
+			}
+			var secret string
+			if tt.enableTOTP {
+				secret = enableTestTOTP(t, tu.candidateRepo, candidate)
+			}
+			tu.candidateRepo.add(t, tu.passwordHasher, candidate, password)
+
+			ctx := context.Background()
+			req := model.LoginRequest{Identifier: identifier, PlainPassword: "wrong-password"}
+			_, _, err := tu.LoginByIdentifierPassword(ctx, req)
+			require.Equal(t, ErrUnauthorized, err)
+			require.Equal(t, "1", tu.cacheManager.value(attemptKey))
+
+			req.PlainPassword = password
+			session, challenge, err := tu.LoginByIdentifierPassword(ctx, req)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantSession, session != nil)
+			if tt.wantSession {
+				require.False(t, tu.cacheManager.exists(attemptKey))
+				return
+			}
+
+			// the password alone doesn't reset the attempts
+			require.NotNil(t, challenge)
+			require.Equal(t, "1", tu.cacheManager.value(attemptKey))
+
+			code, err := helper.GenerateTOTPCode(secret, helper.TOTPCounter(time.Now()))
+			require.NoError(t, err)
+
+			session, err = tu.VerifyMFAChallenge(ctx, model.VerifyMFAChallengeRequest{
+				ChallengeToken: challenge.Token,
+				Code:           code,
+			})
+			require.NoError(t, err)
+			require.NotNil(t, session)
+			require.True(t, session.MFAAuthenticated)
+			require.False(t, tu.cacheManager.exists(attemptKey))
+		})
+	}
+}
@@ -0,0 +1,201 @@
+package usecase
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"github.com/irvankadhafi/talent-hub-service/internal/config"
+	"github.com/irvankadhafi/talent-hub-service/internal/helper"
+	"github.com/irvankadhafi/talent-hub-service/internal/model"
+	"github.com/irvankadhafi/talent-hub-service/pkg/cacher"
+	"github.com/irvankadhafi/talent-hub-service/utils"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/guregu/null.v4"
+	"time"
+)
+
+// RequestLoginOTP sends the login otp code to the phone.
+// Unregistered phone is not reported and the code is sent in the background,
+// so neither the response nor its latency tells whether the phone is registered.
+func (a *authUsecase) RequestLoginOTP(ctx context.Context, req model.RequestLoginOTPRequest) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   utils.DumpIncomingContext(ctx),
+		"phone": req.Phone,
+	})
+
+	if err := req.Validate(); err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if err := normalizePhone(&req.Phone); err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	// the cooldown is claimed atomically, so only one of the concurrent requests sends the code,
+	// and it applies to unregistered phone as well
+	cooldown := cacher.NewItemWithCustomTTL(newLoginOTPCooldownCacheKey(req.Phone), time.Now().Unix(), config.LoginOTPResendCooldown())
+	claimed, err := a.cacheManager.StoreIfNotExist(cooldown)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if !claimed {
+		return ErrTooManyRequests
+	}
+
+	candidate, err := a.candidateRepo.FindByPhone(ctx, req.Phone)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if candidate == nil {
+		logger.Warn("login otp requested for unregistered phone")
+		return nil
+	}
+
+	code, err := utils.GenerateRandomDigits(config.LoginOTPCodeLength())
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	err = a.cacheManager.StoreMultiWithoutBlocking([]cacher.Item{
+		cacher.NewItemWithCustomTTL(newLoginOTPCacheKey(req.Phone), hashLoginOTP(req.Phone, code), config.LoginOTPDuration()),
+		cacher.NewItemWithCustomTTL(newLoginOTPAttemptCacheKey(req.Phone), 0, config.LoginOTPDuration()),
+	})
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	message := fmt.Sprintf("Your Talent Hub login code is %s. It expires in %s. Never share this code with anyone.", code, config.LoginOTPDuration())
+	go a.sendSMS(req.Phone, message, config.LoginOTPDuration(), logger)
+
+	return nil
+}
+
+// LoginByOTP verifies the otp code then creates a new session, or a challenge when the candidate has enabled the TOTP.
+// The code is deleted once it's used or the max attempts is reached.
+func (a *authUsecase) LoginByOTP(ctx context.Context, req model.LoginByOTPRequest) (*model.Session, *model.MFAChallenge, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":       utils.DumpIncomingContext(ctx),
+		"phone":     req.Phone,
+		"ipAddress": req.IPAddress,
+	})
+
+	if err := req.Validate(); err != nil {
+		logger.Error(err)
+		return nil, nil, err
+	}
+
+	if err := normalizePhone(&req.Phone); err != nil {
+		logger.Error(err)
+		return nil, nil, err
+	}
+
+	loginReq := model.LoginRequest{
+		Identifier:     req.Phone,
+		IdentifierType: model.IdentifierTypePhone,
+		PhoneVerified:  true,
+		UserAgent:      req.UserAgent,
+		IPAddress:      req.IPAddress,
+		GeoLocation:    a.resolveGeoLocation(ctx, req.IPAddress, req.GeoLocation),
+	}
+	event := newAuthEvent(model.AuthEventTypeLoginOTP, 0, loginReq)
+
+	if a.isLoginLocked(req.Phone, req.IPAddress) {
+		logger.Warn(ErrLoginByEmailPasswordLocked)
+		a.recordAuthEvent(ctx, event, ErrLoginByEmailPasswordLocked)
+		return nil, nil, ErrLoginByEmailPasswordLocked
+	}
+
+	otpKey := newLoginOTPCacheKey(req.Phone)
+	attemptKey := newLoginOTPAttemptCacheKey(req.Phone)
+	reply, err := a.cacheManager.Get(otpKey)
+	if err != nil {
+		logger.Error(err)
+		return nil, nil, err
+	}
+
+	codeHash, _ := reply.([]byte)
+	if codeHash == nil {
+		return nil, nil, ErrOTPInvalid
+	}
+
+	attempts, err := a.increaseCounter(attemptKey, config.LoginOTPDuration())
+	if err != nil {
+		logger.Error(err)
+		return nil, nil, err
+	}
+
+	if attempts > config.LoginOTPMaxAttempts() {
+		if err := a.cacheManager.DeleteByKeys([]string{otpKey, attemptKey}); err != nil {
+			logger.Error(err)
+		}
+		a.recordAuthEvent(ctx, event, ErrOTPInvalid)
+		return nil, nil, ErrOTPInvalid
+	}
+
+	if subtle.ConstantTimeCompare(codeHash, []byte(hashLoginOTP(req.Phone, req.Code))) != 1 {
+		a.recordFailedLogin(req.Phone, req.IPAddress)
+		a.recordAuthEvent(ctx, event, ErrOTPInvalid)
+		return nil, nil, ErrOTPInvalid
+	}
+
+	if err := a.cacheManager.DeleteByKeys([]string{otpKey, attemptKey}); err != nil {
+		logger.Error(err)
+		return nil, nil, err
+	}
+
+	candidate, err := a.findCandidateByPhone(ctx, req.Phone)
+	if err != nil {
+		logger.Error(err)
+		return nil, nil, err
+	}
+
+	session, challenge, err := a.createSessionOrChallenge(ctx, candidate, loginReq)
+	if err != nil {
+		logger.Error(err)
+		return nil, nil, err
+	}
+
+	// the failed attempts are only reset once the second factor is completed
+	if session != nil {
+		a.resetFailedLogin(req.Phone)
+	}
+
+	event.CandidateID = null.IntFrom(candidate.ID)
+	a.recordLoginEvent(ctx, event, session, challenge)
+
+	return session, challenge, nil
+}
+
+// normalizePhone remove the leading zero and format the phone with the country code
+func normalizePhone(phone *string) error {
+	if err := helper.RemoveLeadingZeroPhoneNumber(phone); err != nil {
+		return err
+	}
+
+	return helper.FormatPhoneNumberWithCountryCode(phone, "ID")
+}
+
+// hashLoginOTP hash the code along with the phone, so the same code for different phones has different hash
+func hashLoginOTP(phone, code string) string {
+	return helper.HashToken(phone + ":" + code)
+}
+
+func newLoginOTPCacheKey(phone string) string {
+	return fmt.Sprintf("cache:login_otp:phone:%s", phone)
+}
+
+func newLoginOTPAttemptCacheKey(phone string) string {
+	return fmt.Sprintf("cache:login_otp_attempt:phone:%s", phone)
+}
+
+func newLoginOTPCooldownCacheKey(phone string) string {
+	return fmt.Sprintf("cache:login_otp_cooldown:phone:%s", phone)
+}
@@ -0,0 +1,155 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/irvankadhafi/talent-hub-service/internal/config"
+	"github.com/irvankadhafi/talent-hub-service/internal/model"
+	"github.com/irvankadhafi/talent-hub-service/pkg/cacher"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+)
+
+const testPhone = "+6281234567890"
+
+func TestUsecase_RequestLoginOTP(t *testing.T) {
+	tests := []struct {
+		name     string
+		phone    string
+		smsErr   error
+		cooldown bool
+		wantErr  error
+		wantSent bool
+	}{
+		{
+			name:     "send the code to the registered phone",
+			phone:    testPhone,
+			wantSent: true,
+		},
+		{
+			name:     "the failed sms is not reported",
+			phone:    testPhone,
+			smsErr:   errors.New("sms gateway is down"),
+			wantSent: true,
+		},
+		{
+			name:  "the unregistered phone is not reported",
+			phone: "+6289876543210",
+		},
+		{
+			name:     "the cooldown is not expired",
+			phone:    testPhone,
+			cooldown: true,
+			wantErr:  ErrTooManyRequests,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tu := newTestAuthUsecase(t)
+			tu.smsSender.err = tt.smsErr
+			tu.candidateRepo.candidates[1] = &model.Candidate{ID: 1, Phone: null.StringFrom(testPhone)}
+			if tt.cooldown {
+				require.NoError(t, tu.cacheManager.StoreWithoutBlocking(cacher.NewItemWithCustomTTL(newLoginOTPCooldownCacheKey(tt.phone), time.Now().Unix(), time.Minute)))
+			}
+
+			err := tu.RequestLoginOTP(context.Background(), model.RequestLoginOTPRequest{Phone: tt.phone})
+			require.Equal(t, tt.wantErr, err)
+			require.True(t, tu.cacheManager.exists(newLoginOTPCooldownCacheKey(tt.phone)))
+			require.Equal(t, tt.wantSent, tu.cacheManager.exists(newLoginOTPCacheKey(tt.phone)))
+
+			if !tt.wantSent {
+				return
+			}
+
+			select {
+			case sent := <-tu.smsSender.sent:
+				require.True(t, strings.HasPrefix(sent, tt.phone+": "))
+			case <-time.After(time.Second):
+				require.Fail(t, "the code is not sent")
+			}
+		})
+	}
+}
+
+func TestUsecase_LoginByOTP(t *testing.T) {
+	const code = "123456"
+	maxAttempts := config.LoginOTPMaxAttempts()
+
+	tests := []struct {
+		name             string
+		requested        bool
+		previousAttempts int64
+		code             string
+		wantErr          error
+		wantOTPKept      bool
+		wantFailedLogin  bool
+	}{
+		{
+			name:      "login with the valid code",
+			requested: true,
+			code:      code,
+		},
+		{
+			name:             "login with the valid code on the last attempt",
+			requested:        true,
+			previousAttempts: maxAttempts - 1,
+			code:             code,
+		},
+		{
+			name:             "the valid code is rejected after the max attempts",
+			requested:        true,
+			previousAttempts: maxAttempts,
+			code:             code,
+			wantErr:          ErrOTPInvalid,
+		},
+		{
+			name:            "the invalid code",
+			requested:       true,
+			code:            "654321",
+			wantErr:         ErrOTPInvalid,
+			wantOTPKept:     true,
+			wantFailedLogin: true,
+		},
+		{
+			name:    "the code is not requested",
+			code:    code,
+			wantErr: ErrOTPInvalid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tu := newTestAuthUsecase(t)
+			tu.candidateRepo.candidates[1] = &model.Candidate{ID: 1, Phone: null.StringFrom(testPhone)}
+			otpKey := newLoginOTPCacheKey(testPhone)
+			attemptKey := newLoginOTPAttemptCacheKey(testPhone)
+			if tt.requested {
+				require.NoError(t, tu.cacheManager.StoreMultiWithoutBlocking([]cacher.Item{
+					cacher.NewItemWithCustomTTL(otpKey, hashLoginOTP(testPhone, code), time.Minute),
+					cacher.NewItemWithCustomTTL(attemptKey, tt.previousAttempts, time.Minute),
+				}))
+			}
+
+			session, _, err := tu.LoginByOTP(context.Background(), model.LoginByOTPRequest{
+				Phone:     testPhone,
+				Code:      tt.code,
+				IPAddress: "10.0.0.1",
+			})
+			require.Equal(t, tt.wantErr, err)
+			require.Equal(t, tt.wantErr == nil, session != nil)
+			require.Equal(t, tt.wantOTPKept, tu.cacheManager.exists(otpKey))
+			if tt.wantOTPKept {
+				require.Equal(t, strconv.FormatInt(tt.previousAttempts+1, 10), tu.cacheManager.value(attemptKey))
+			} else {
+				require.False(t, tu.cacheManager.exists(attemptKey))
+			}
+			require.Equal(t, tt.wantFailedLogin, tu.cacheManager.exists(newLoginAttemptCacheKey(loginAttemptSubjectIdentifier, testPhone)))
+		})
+	}
+}
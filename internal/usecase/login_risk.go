@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"context"
+	"github.com/irvankadhafi/talent-hub-service/internal/config"
+	"github.com/irvankadhafi/talent-hub-service/internal/helper"
+	"github.com/irvankadhafi/talent-hub-service/internal/model"
+	"github.com/irvankadhafi/talent-hub-service/utils"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+// assessLoginRisk compares the login against the candidate's active sessions.
+// The first login is never flagged, since there is nothing to compare with.
+func (a *authUsecase) assessLoginRisk(ctx context.Context, candidate *model.Candidate, req model.LoginRequest) ([]model.LoginRiskFlag, error) {
+	if !config.LoginRiskEnabled() {
+		return nil, nil
+	}
+
+	sessions, err := a.sessionRepo.FindAllActiveByCandidateID(ctx, candidate.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// the impersonation session is created from the admin's device
+	var recentSessions []*model.Session
+	for _, session := range sessions {
+		if !session.IsImpersonated() {
+			recentSessions = append(recentSessions, session)
+		}
+	}
+
+	if len(recentSessions) == 0 {
+		return nil, nil
+	}
+
+	var flags []model.LoginRiskFlag
+	if isNewDevice(recentSessions, req.UserAgent) {
+		flags = append(flags, model.LoginRiskFlagNewDevice)
+	}
+
+	if isImpossibleTravel(recentSessions, req.GeoLocation, time.Now()) {
+		flags = append(flags, model.LoginRiskFlagImpossibleTravel)
+	}
+
+	return flags, nil
+}
+
+// notifyLoginAlert notifies the candidate about the suspicious login,
+// failing to notify is only logged so it never blocks the login
+func (a *authUsecase) notifyLoginAlert(ctx context.Context, candidate *model.Candidate, req model.LoginRequest, flags []model.LoginRiskFlag) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":           utils.DumpIncomingContext(ctx),
+		"securityEvent": "suspicious_login",
+		"candidateID":   candidate.ID,
+		"ipAddress":     req.IPAddress,
+		"riskFlags":     flags,
+	})
+	logger.Warn("suspicious login is detected")
+
+	if !config.LoginRiskNotify() {
+		return
+	}
+
+	err := a.loginAlertNotifier.NotifyLoginAlert(ctx, candidate, model.LoginAlert{
+		RiskFlags: flags,
+		IPAddress: req.IPAddress,
+		UserAgent: req.UserAgent,
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		logger.Error(err)
+	}
+}
+
+// isNewDevice check none of the sessions is created from the same device
+func isNewDevice(sessions []*model.Session, userAgent string) bool {
+	fingerprint := helper.DeviceFingerprint(userAgent)
+	for _, session := range sessions {
+		if helper.DeviceFingerprint(session.UserAgent) == fingerprint {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isImpossibleTravel check the travel from the last located session is faster than the max travel speed.
+// The sessions must be ordered by the last activity.
+func isImpossibleTravel(sessions []*model.Session, location model.GeoLocation, now time.Time) bool {
+	if !location.IsKnown() {
+		return false
+	}
+
+	for _, session := range sessions {
+		if !session.Latitude.Valid || !session.Longitude.Valid {
+			continue
+		}
+
+		distance := helper.HaversineDistance(session.Latitude.Float64, session.Longitude.Float64, location.Latitude.Float64, location.Longitude.Float64)
+		if distance < config.LoginRiskMinTravelDistance() {
+			return false
+		}
+
+		elapsed := now.Sub(session.UpdatedAt).Hours()
+		if elapsed <= 0 {
+			return true
+		}
+
+		return distance/elapsed > config.LoginRiskMaxTravelSpeed()
+	}
+
+	return false
+}
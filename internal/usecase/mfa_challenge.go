@@ -0,0 +1,237 @@
+package usecase
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"github.com/irvankadhafi/talent-hub-service/internal/config"
+	"github.com/irvankadhafi/talent-hub-service/internal/helper"
+	"github.com/irvankadhafi/talent-hub-service/internal/model"
+	"github.com/irvankadhafi/talent-hub-service/pkg/cacher"
+	"github.com/irvankadhafi/talent-hub-service/utils"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+// mfaChallengeState the pending challenge stored in the cache
+type mfaChallengeState struct {
+	CandidateID int64                    `json:"candidate_id"`
+	Identifier  string                   `json:"identifier,omitempty"` // the failed attempts of the identifier are reset on completion
+	Method      model.MFAChallengeMethod `json:"method"`
+	CodeHash    string                   `json:"code_hash,omitempty"` // only set on the sms step-up
+	RiskFlags   []model.LoginRiskFlag    `json:"risk_flags,omitempty"`
+}
+
+// createSessionOrChallenge creates the session straight away when the candidate has not enabled the TOTP,
+// otherwise returns a challenge to be completed with VerifyMFAChallenge.
+// The suspicious login of the candidate without TOTP is challenged with the code sent to the phone when the step-up is required,
+// unless the login itself is verified by the otp sent to the same phone.
+func (a *authUsecase) createSessionOrChallenge(ctx context.Context, candidate *model.Candidate, req model.LoginRequest) (*model.Session, *model.MFAChallenge, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         utils.DumpIncomingContext(ctx),
+		"candidateID": candidate.ID,
+	})
+
+	riskFlags, err := a.assessLoginRisk(ctx, candidate, req)
+	if err != nil {
+		logger.Error(err)
+		return nil, nil, err
+	}
+
+	if len(riskFlags) > 0 {
+		a.notifyLoginAlert(ctx, candidate, req, riskFlags)
+	}
+
+	state := mfaChallengeState{
+		CandidateID: candidate.ID,
+		Identifier:  req.Identifier,
+		Method:      model.MFAChallengeMethodTOTP,
+		RiskFlags:   riskFlags,
+	}
+
+	var stepUpCode string
+	switch {
+	case candidate.IsTOTPEnabled():
+	case len(riskFlags) > 0 && config.LoginRiskRequireStepUp() && candidate.Phone.Valid && !req.PhoneVerified:
+		stepUpCode, err = utils.GenerateRandomDigits(config.LoginOTPCodeLength())
+		if err != nil {
+			logger.Error(err)
+			return nil, nil, err
+		}
+
+		state.Method = model.MFAChallengeMethodSMS
+		state.CodeHash = helper.HashToken(stepUpCode)
+	default:
+		if len(riskFlags) > 0 && config.LoginRiskRequireStepUp() {
+			logger.Warn("step-up is not available, the candidate has neither TOTP nor phone other than the login factor")
+		}
+
+		session, err := a.createSession(ctx, candidate, req, false, riskFlags)
+		return session, nil, err
+	}
+
+	if a.isCandidateLoginLocked(candidate.ID, req.IPAddress) {
+		logger.Warn(ErrLoginByEmailPasswordLocked)
+		return nil, nil, ErrLoginByEmailPasswordLocked
+	}
+
+	token, err := utils.GenerateRandomStringURLSafe(config.DefaultMFAChallengeTokenLength)
+	if err != nil {
+		logger.Error(err)
+		return nil, nil, err
+	}
+
+	tokenHash := helper.HashToken(token)
+	err = a.cacheManager.StoreMultiWithoutBlocking([]cacher.Item{
+		cacher.NewItemWithCustomTTL(newMFAChallengeCacheKey(tokenHash), utils.Dump(state), config.MFAChallengeDuration()),
+		cacher.NewItemWithCustomTTL(newMFAChallengeAttemptCacheKey(tokenHash), 0, config.MFAChallengeDuration()),
+	})
+	if err != nil {
+		logger.Error(err)
+		return nil, nil, err
+	}
+
+	if state.Method == model.MFAChallengeMethodSMS {
+		message := fmt.Sprintf("Your Talent Hub verification code is %s. It expires in %s. Never share this code with anyone.", stepUpCode, config.MFAChallengeDuration())
+		go a.sendSMS(candidate.Phone.String, message, config.MFAChallengeDuration(), logger)
+	}
+
+	return nil, &model.MFAChallenge{
+		Token:     token,
+		Method:    state.Method,
+		RiskFlags: riskFlags,
+		ExpiredAt: time.Now().Add(config.MFAChallengeDuration()),
+	}, nil
+}
+
+// sendSMS sends the code message in the background, so the slow sms gateway doesn't block the request.
+// The code is useless once it expires, so the sending is bounded by the code's lifetime.
+func (a *authUsecase) sendSMS(phone, message string, timeout time.Duration, logger *logrus.Entry) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := a.smsSender.SendSMS(ctx, phone, message); err != nil {
+		logger.Error(err)
+	}
+}
+
+// VerifyMFAChallenge completes the challenge with either the TOTP code, a recovery code or the step-up code, then creates a new session.
+// The challenge is deleted once it's completed or the max attempts is reached.
+func (a *authUsecase) VerifyMFAChallenge(ctx context.Context, req model.VerifyMFAChallengeRequest) (*model.Session, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":       utils.DumpIncomingContext(ctx),
+		"ipAddress": req.IPAddress,
+	})
+
+	if err := req.Validate(); err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	tokenHash := helper.HashToken(req.ChallengeToken)
+	challengeKey := newMFAChallengeCacheKey(tokenHash)
+	attemptKey := newMFAChallengeAttemptCacheKey(tokenHash)
+	reply, err := a.cacheManager.Get(challengeKey)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	bt, _ := reply.([]byte)
+	if bt == nil {
+		return nil, ErrMFAChallengeInvalid
+	}
+
+	state := mfaChallengeState{}
+	if err := json.Unmarshal(bt, &state); err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	candidateID := state.CandidateID
+	logger = logger.WithField("candidateID", candidateID)
+
+	loginReq := model.LoginRequest{
+		UserAgent:   req.UserAgent,
+		IPAddress:   req.IPAddress,
+		GeoLocation: a.resolveGeoLocation(ctx, req.IPAddress, req.GeoLocation),
+	}
+	event := newAuthEvent(model.AuthEventTypeMFAVerify, candidateID, loginReq)
+
+	if a.isCandidateLoginLocked(candidateID, req.IPAddress) {
+		logger.Warn(ErrLoginByEmailPasswordLocked)
+		a.recordAuthEvent(ctx, event, ErrLoginByEmailPasswordLocked)
+		return nil, ErrLoginByEmailPasswordLocked
+	}
+
+	attempts, err := a.increaseCounter(attemptKey, config.MFAChallengeDuration())
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if attempts > config.MFAMaxAttempts() {
+		if err := a.cacheManager.DeleteByKeys([]string{challengeKey, attemptKey}); err != nil {
+			logger.Error(err)
+		}
+		a.recordAuthEvent(ctx, event, ErrMFAChallengeInvalid)
+		return nil, ErrMFAChallengeInvalid
+	}
+
+	candidate, err := a.candidateRepo.FindByID(ctx, candidateID)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if candidate == nil || (state.Method == model.MFAChallengeMethodTOTP && !candidate.IsTOTPEnabled()) {
+		return nil, ErrMFAChallengeInvalid
+	}
+
+	var ok bool
+	switch state.Method {
+	case model.MFAChallengeMethodSMS:
+		ok = subtle.ConstantTimeCompare([]byte(state.CodeHash), []byte(helper.HashToken(req.Code))) == 1
+	default:
+		ok, err = verifySecondFactor(ctx, a.cacheManager, a.candidateRepo, a.mfaRecoveryCodeRepo, candidate, req.Code)
+		if err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+	}
+
+	if !ok {
+		a.recordFailedMFACode(candidateID, req.IPAddress)
+		a.recordAuthEvent(ctx, event, ErrMFACodeInvalid)
+		return nil, ErrMFACodeInvalid
+	}
+
+	if err := a.cacheManager.DeleteByKeys([]string{challengeKey, attemptKey}); err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	// only the TOTP is the enrolled second factor, the step-up code only proves the possession of the phone
+	session, err := a.createSession(ctx, candidate, loginReq, state.Method == model.MFAChallengeMethodTOTP, state.RiskFlags)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	a.resetFailedMFACode(candidateID)
+	if state.Identifier != "" {
+		a.resetFailedLogin(state.Identifier)
+	}
+	a.recordLoginEvent(ctx, event, session, nil)
+
+	return session, nil
+}
+
+func newMFAChallengeCacheKey(tokenHash string) string {
+	return fmt.Sprintf("cache:mfa_challenge:token_hash:%s", tokenHash)
+}
+
+func newMFAChallengeAttemptCacheKey(tokenHash string) string {
+	return fmt.Sprintf("cache:mfa_challenge_attempt:token_hash:%s", tokenHash)
+}
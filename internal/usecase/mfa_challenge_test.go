@@ -0,0 +1,159 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/irvankadhafi/talent-hub-service/internal/config"
+	"github.com/irvankadhafi/talent-hub-service/internal/helper"
+	"github.com/irvankadhafi/talent-hub-service/internal/model"
+	"github.com/irvankadhafi/talent-hub-service/pkg/cacher"
+	"github.com/irvankadhafi/talent-hub-service/utils"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+)
+
+func TestUsecase_VerifyMFAChallenge(t *testing.T) {
+	const token = "challenge-token"
+	const identifier = "john@example.com"
+	const stepUpCode = "482913"
+	tokenHash := helper.HashToken(token)
+	challengeKey := newMFAChallengeCacheKey(tokenHash)
+	attemptKey := newMFAChallengeAttemptCacheKey(tokenHash)
+	candidateAttemptKey := newLoginAttemptCacheKey(loginAttemptSubjectCandidate, "1")
+
+	tests := []struct {
+		name   string
+		method model.MFAChallengeMethod
+		// code return the code to send, from the candidate's TOTP secret
+		code func(t *testing.T, tu *testAuthUsecase, secret string) string
+		// setup is called before the challenge is verified
+		setup                func(t *testing.T, tu *testAuthUsecase)
+		wantErr              error
+		wantMFAAuthenticated bool
+		wantChallengeKept    bool
+		wantFailedMFACode    bool
+	}{
+		{
+			name:                 "complete with the TOTP code",
+			method:               model.MFAChallengeMethodTOTP,
+			code:                 currentTOTPCode,
+			wantMFAAuthenticated: true,
+		},
+		{
+			name:   "the TOTP code is already used",
+			method: model.MFAChallengeMethodTOTP,
+			code: func(t *testing.T, tu *testAuthUsecase, secret string) string {
+				code := currentTOTPCode(t, tu, secret)
+				ok, err := verifyTOTPCode(tu.cacheManager, 1, secret, code)
+				require.NoError(t, err)
+				require.True(t, ok)
+				return code
+			},
+			wantErr:           ErrMFACodeInvalid,
+			wantChallengeKept: true,
+			wantFailedMFACode: true,
+		},
+		{
+			name:   "the invalid TOTP code",
+			method: model.MFAChallengeMethodTOTP,
+			code: func(t *testing.T, tu *testAuthUsecase, secret string) string {
+				return otherTOTPCode(currentTOTPCode(t, tu, secret))
+			},
+			wantErr:           ErrMFACodeInvalid,
+			wantChallengeKept: true,
+			wantFailedMFACode: true,
+		},
+		{
+			name:   "complete with the step-up code",
+			method: model.MFAChallengeMethodSMS,
+			code: func(_ *testing.T, _ *testAuthUsecase, _ string) string {
+				return stepUpCode
+			},
+			wantMFAAuthenticated: false,
+		},
+		{
+			name:   "the valid code is rejected after the max attempts",
+			method: model.MFAChallengeMethodTOTP,
+			code:   currentTOTPCode,
+			setup: func(t *testing.T, tu *testAuthUsecase) {
+				require.NoError(t, tu.cacheManager.StoreWithoutBlocking(cacher.NewItemWithCustomTTL(attemptKey, config.MFAMaxAttempts(), time.Minute)))
+			},
+			wantErr: ErrMFAChallengeInvalid,
+		},
+		{
+			name:   "the candidate is locked by the failed codes of the other challenges",
+			method: model.MFAChallengeMethodTOTP,
+			code:   currentTOTPCode,
+			setup: func(t *testing.T, tu *testAuthUsecase) {
+				for i := int64(0); i < config.LoginMaxAttemptsPerIdentifier(); i++ {
+					tu.recordFailedMFACode(1, "")
+				}
+			},
+			wantErr:           ErrLoginByEmailPasswordLocked,
+			wantChallengeKept: true,
+		},
+		{
+			name:   "the challenge doesn't exist",
+			method: model.MFAChallengeMethodTOTP,
+			code:   currentTOTPCode,
+			setup: func(t *testing.T, tu *testAuthUsecase) {
+				require.NoError(t, tu.cacheManager.DeleteByKeys([]string{challengeKey, attemptKey}))
+			},
+			wantErr: ErrMFAChallengeInvalid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setTestTOTPEncryptionKey(t)
+
+			tu := newTestAuthUsecase(t)
+			candidate := &model.Candidate{ID: 1, Email: null.StringFrom(identifier)}
+			secret := enableTestTOTP(t, tu.candidateRepo, candidate)
+			tu.candidateRepo.candidates[candidate.ID] = candidate
+
+			state := mfaChallengeState{CandidateID: candidate.ID, Identifier: identifier, Method: tt.method}
+			if tt.method == model.MFAChallengeMethodSMS {
+				state.CodeHash = helper.HashToken(stepUpCode)
+			}
+			require.NoError(t, tu.cacheManager.StoreMultiWithoutBlocking([]cacher.Item{
+				cacher.NewItemWithCustomTTL(challengeKey, utils.Dump(state), time.Minute),
+				cacher.NewItemWithCustomTTL(attemptKey, 0, time.Minute),
+			}))
+			tu.recordFailedLogin(identifier, "")
+
+			code := tt.code(t, tu, secret)
+			if tt.setup != nil {
+				tt.setup(t, tu)
+			}
+
+			session, err := tu.VerifyMFAChallenge(context.Background(), model.VerifyMFAChallengeRequest{
+				ChallengeToken: token,
+				Code:           code,
+			})
+			require.Equal(t, tt.wantErr, err)
+			require.Equal(t, tt.wantChallengeKept, tu.cacheManager.exists(challengeKey))
+			require.Equal(t, tt.wantFailedMFACode, tu.cacheManager.value(candidateAttemptKey) == "1")
+
+			if tt.wantErr != nil {
+				require.Nil(t, session)
+				// the failed attempts of the identifier are kept until the second factor is completed
+				require.True(t, tu.cacheManager.exists(newLoginAttemptCacheKey(loginAttemptSubjectIdentifier, identifier)))
+				return
+			}
+
+			require.NotNil(t, session)
+			require.Equal(t, tt.wantMFAAuthenticated, session.MFAAuthenticated)
+			require.False(t, tu.cacheManager.exists(attemptKey))
+			require.False(t, tu.cacheManager.exists(newLoginAttemptCacheKey(loginAttemptSubjectIdentifier, identifier)))
+		})
+	}
+}
+
+func currentTOTPCode(t *testing.T, _ *testAuthUsecase, secret string) string {
+	code, err := helper.GenerateTOTPCode(secret, helper.TOTPCounter(time.Now()))
+	require.NoError(t, err)
+	return code
+}
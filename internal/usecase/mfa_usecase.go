@@ -0,0 +1,299 @@
+package usecase
+
+import (
+	"context"
+	"encoding/base32"
+	"fmt"
+	"github.com/irvankadhafi/talent-hub-service/internal/config"
+	"github.com/irvankadhafi/talent-hub-service/internal/helper"
+	"github.com/irvankadhafi/talent-hub-service/internal/model"
+	"github.com/irvankadhafi/talent-hub-service/pkg/cacher"
+	"github.com/irvankadhafi/talent-hub-service/utils"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/guregu/null.v4"
+	"strings"
+	"time"
+)
+
+const recoveryCodeByteSize = 6
+
+var recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+type mfaUsecase struct {
+	candidateRepo       model.CandidateRepository
+	mfaRecoveryCodeRepo model.MFARecoveryCodeRepository
+	cacheManager        cacher.CacheManager
+}
+
+// NewMFAUsecase mfaUsecase constructor
+func NewMFAUsecase(
+	candidateRepo model.CandidateRepository,
+	mfaRecoveryCodeRepo model.MFARecoveryCodeRepository,
+	cacheManager cacher.CacheManager,
+) model.MFAUsecase {
+	return &mfaUsecase{
+		candidateRepo:       candidateRepo,
+		mfaRecoveryCodeRepo: mfaRecoveryCodeRepo,
+		cacheManager:        cacheManager,
+	}
+}
+
+// EnrollTOTP generates a pending TOTP secret for the requester,
+// it's not enabled until confirmed with a valid code
+func (m *mfaUsecase) EnrollTOTP(ctx context.Context, requester *model.Candidate) (*model.TOTPEnrollment, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         utils.DumpIncomingContext(ctx),
+		"candidateID": requester.ID,
+	})
+
+	candidate, err := m.findCandidate(ctx, requester.ID)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if candidate.IsTOTPEnabled() {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	secret, err := helper.GenerateTOTPSecret()
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	encryptedSecret, err := helper.EncryptSecret(config.MFATOTPEncryptionKey(), secret)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	err = m.candidateRepo.UpdateTOTP(ctx, &model.Candidate{
+		ID:         candidate.ID,
+		Email:      candidate.Email,
+		Phone:      candidate.Phone,
+		TOTPSecret: null.StringFrom(encryptedSecret),
+		UpdatedAt:  time.Now(),
+	})
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	accountName := candidate.Email.String
+	if accountName == "" {
+		accountName = candidate.Phone.String
+	}
+
+	return &model.TOTPEnrollment{
+		Secret: secret,
+		URI:    helper.NewTOTPURI(config.MFAIssuer(), accountName, secret),
+	}, nil
+}
+
+// ConfirmTOTP enables the pending TOTP secret once the code is valid, then returns the new recovery codes
+func (m *mfaUsecase) ConfirmTOTP(ctx context.Context, requester *model.Candidate, code string) ([]string, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         utils.DumpIncomingContext(ctx),
+		"candidateID": requester.ID,
+	})
+
+	candidate, err := m.findCandidate(ctx, requester.ID)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if candidate.IsTOTPEnabled() {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	encryptedSecret, err := m.candidateRepo.FindTOTPSecretByID(ctx, candidate.ID)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if !encryptedSecret.Valid {
+		return nil, ErrMFANotEnrolled
+	}
+
+	secret, err := helper.DecryptSecret(config.MFATOTPEncryptionKey(), encryptedSecret.String)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	ok, err := verifyTOTPCode(m.cacheManager, candidate.ID, secret, code)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if !ok {
+		return nil, ErrMFACodeInvalid
+	}
+
+	err = m.candidateRepo.UpdateTOTP(ctx, &model.Candidate{
+		ID:            candidate.ID,
+		Email:         candidate.Email,
+		Phone:         candidate.Phone,
+		TOTPSecret:    encryptedSecret,
+		TOTPEnabledAt: null.TimeFrom(time.Now()),
+		UpdatedAt:     time.Now(),
+	})
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return generateRecoveryCodes(ctx, m.mfaRecoveryCodeRepo, candidate.ID)
+}
+
+// DisableTOTP disables the TOTP after verifying the TOTP code or a recovery code
+func (m *mfaUsecase) DisableTOTP(ctx context.Context, requester *model.Candidate, code string) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         utils.DumpIncomingContext(ctx),
+		"candidateID": requester.ID,
+	})
+
+	candidate, err := m.findCandidate(ctx, requester.ID)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if !candidate.IsTOTPEnabled() {
+		return ErrMFANotEnabled
+	}
+
+	ok, err := verifySecondFactor(ctx, m.cacheManager, m.candidateRepo, m.mfaRecoveryCodeRepo, candidate, code)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if !ok {
+		return ErrMFACodeInvalid
+	}
+
+	err = m.candidateRepo.UpdateTOTP(ctx, &model.Candidate{
+		ID:        candidate.ID,
+		Email:     candidate.Email,
+		Phone:     candidate.Phone,
+		UpdatedAt: time.Now(),
+	})
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if err := m.mfaRecoveryCodeRepo.DeleteAllByCandidateID(ctx, candidate.ID); err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// RegenerateRecoveryCodes replaces the requester's recovery codes with the new ones
+func (m *mfaUsecase) RegenerateRecoveryCodes(ctx context.Context, requester *model.Candidate) ([]string, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         utils.DumpIncomingContext(ctx),
+		"candidateID": requester.ID,
+	})
+
+	candidate, err := m.findCandidate(ctx, requester.ID)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if !candidate.IsTOTPEnabled() {
+		return nil, ErrMFANotEnabled
+	}
+
+	return generateRecoveryCodes(ctx, m.mfaRecoveryCodeRepo, candidate.ID)
+}
+
+func (m *mfaUsecase) findCandidate(ctx context.Context, id int64) (*model.Candidate, error) {
+	candidate, err := m.candidateRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if candidate == nil {
+		return nil, ErrNotFound
+	}
+
+	return candidate, nil
+}
+
+// verifySecondFactor verifies either the candidate's TOTP code or an unused recovery code,
+// the recovery code is marked as used once it's verified
+func verifySecondFactor(ctx context.Context, cacheManager cacher.CacheManager, candidateRepo model.CandidateRepository, mfaRecoveryCodeRepo model.MFARecoveryCodeRepository, candidate *model.Candidate, code string) (bool, error) {
+	if len(code) == helper.TOTPDigits {
+		secret, err := findTOTPSecret(ctx, candidateRepo, candidate.ID)
+		if err != nil || secret == "" {
+			return false, err
+		}
+
+		return verifyTOTPCode(cacheManager, candidate.ID, secret, code)
+	}
+
+	return mfaRecoveryCodeRepo.MarkAsUsed(ctx, candidate.ID, helper.HashToken(normalizeRecoveryCode(code)))
+}
+
+// verifyTOTPCode verifies the candidate's TOTP code, the code can't be reused within its validity window.
+// The used counter is claimed atomically, so only one of the concurrent requests with the same code passes.
+func verifyTOTPCode(cacheManager cacher.CacheManager, candidateID int64, secret, code string) (bool, error) {
+	skew := config.MFATOTPSkew()
+	counter, ok := helper.ValidateTOTPCode(secret, code, time.Now(), skew)
+	if !ok {
+		return false, nil
+	}
+
+	usedKey := fmt.Sprintf("cache:totp_used:candidate_id:%d:counter:%d", candidateID, counter)
+	ttl := time.Duration(2*skew+1) * helper.TOTPPeriod * time.Second
+
+	return cacheManager.StoreIfNotExist(cacher.NewItemWithCustomTTL(usedKey, counter, ttl))
+}
+
+// findTOTPSecret find and decrypt the candidate's TOTP secret, empty when the candidate has not enrolled
+func findTOTPSecret(ctx context.Context, candidateRepo model.CandidateRepository, candidateID int64) (string, error) {
+	encryptedSecret, err := candidateRepo.FindTOTPSecretByID(ctx, candidateID)
+	if err != nil || !encryptedSecret.Valid {
+		return "", err
+	}
+
+	return helper.DecryptSecret(config.MFATOTPEncryptionKey(), encryptedSecret.String)
+}
+
+// generateRecoveryCodes generates and stores the hashed recovery codes, the plain codes are only returned once
+func generateRecoveryCodes(ctx context.Context, mfaRecoveryCodeRepo model.MFARecoveryCodeRepository, candidateID int64) ([]string, error) {
+	codes := make([]string, 0, config.MFARecoveryCodeCount())
+	codeHashes := make([]string, 0, config.MFARecoveryCodeCount())
+	for i := 0; i < config.MFARecoveryCodeCount(); i++ {
+		bt, err := utils.GenerateRandomBytes(recoveryCodeByteSize)
+		if err != nil {
+			return nil, err
+		}
+
+		code := strings.ToLower(recoveryCodeEncoding.EncodeToString(bt))
+		code = code[:5] + "-" + code[5:]
+		codes = append(codes, code)
+		codeHashes = append(codeHashes, helper.HashToken(normalizeRecoveryCode(code)))
+	}
+
+	if err := mfaRecoveryCodeRepo.ReplaceAllByCandidateID(ctx, candidateID, codeHashes); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// normalizeRecoveryCode so the code can be typed without the separator and in any case
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.ReplaceAll(code, "-", "")
+}
@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/irvankadhafi/talent-hub-service/internal/config"
+	"github.com/irvankadhafi/talent-hub-service/internal/helper"
+	"github.com/stretchr/testify/require"
+)
+
+// otherTOTPCode return the code differs from the given code on each digit
+func otherTOTPCode(code string) string {
+	bt := []byte(code)
+	for i, c := range bt {
+		bt[i] = '0' + (c-'0'+1)%10
+	}
+	return string(bt)
+}
+
+func TestUsecase_verifyTOTPCode(t *testing.T) {
+	secret, err := helper.GenerateTOTPSecret()
+	require.NoError(t, err)
+
+	counter := helper.TOTPCounter(time.Now())
+	code := func(counter uint64) string {
+		c, err := helper.GenerateTOTPCode(secret, counter)
+		require.NoError(t, err)
+		return c
+	}
+
+	tests := []struct {
+		name string
+		// usedCodes are verified before the code
+		usedCodes []string
+		code      string
+		want      bool
+	}{
+		{
+			name: "the current code",
+			code: code(counter),
+			want: true,
+		},
+		{
+			name: "the previous code within the skew",
+			code: code(counter - config.MFATOTPSkew()),
+			want: true,
+		},
+		{
+			name: "the code outside the skew",
+			code: code(counter - config.MFATOTPSkew() - 1),
+			want: false,
+		},
+		{
+			name: "the invalid code",
+			code: otherTOTPCode(code(counter)),
+			want: false,
+		},
+		{
+			name:      "the replayed code",
+			usedCodes: []string{code(counter)},
+			code:      code(counter),
+			want:      false,
+		},
+		{
+			name:      "the other code of the same candidate is not affected",
+			usedCodes: []string{code(counter - 1)},
+			code:      code(counter),
+			want:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cacheManager := newStubCacheManager()
+			for _, used := range tt.usedCodes {
+				ok, err := verifyTOTPCode(cacheManager, 1, secret, used)
+				require.NoError(t, err)
+				require.True(t, ok)
+			}
+
+			ok, err := verifyTOTPCode(cacheManager, 1, secret, tt.code)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, ok)
+		})
+	}
+
+	// the code used by the other candidate with the same secret is not a replay
+	cacheManager := newStubCacheManager()
+	for _, candidateID := range []int64{1, 2} {
+		ok, err := verifyTOTPCode(cacheManager, candidateID, secret, code(counter))
+		require.NoError(t, err)
+		require.True(t, ok)
+	}
+}
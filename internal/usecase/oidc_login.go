@@ -0,0 +1,320 @@
+package usecase
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/irvankadhafi/talent-hub-service/internal/config"
+	"github.com/irvankadhafi/talent-hub-service/internal/helper"
+	"github.com/irvankadhafi/talent-hub-service/internal/model"
+	"github.com/irvankadhafi/talent-hub-service/pkg/cacher"
+	"github.com/irvankadhafi/talent-hub-service/utils"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/guregu/null.v4"
+	"strings"
+	"time"
+)
+
+// oidcRandomLength the length in bytes of the random state, nonce and code verifier
+const oidcRandomLength = 32
+
+// oidcLoginState the pending authorization stored in the cache, keyed by the state hash.
+// The link candidate is only set when the authorization links the identity to the signed in candidate.
+type oidcLoginState struct {
+	Provider        string `json:"provider"`
+	Nonce           string `json:"nonce"`
+	CodeVerifier    string `json:"code_verifier"`
+	LinkCandidateID int64  `json:"link_candidate_id,omitempty"`
+}
+
+// StartOIDCLogin creates the authorization url of the provider along with the state to be returned on the redirect.
+// The nonce and the PKCE code verifier are kept on the cache until the authorization is completed with LoginByOIDC.
+func (a *authUsecase) StartOIDCLogin(ctx context.Context, providerName string) (*model.OIDCAuthorization, error) {
+	return a.startOIDCAuthorization(ctx, providerName, 0)
+}
+
+// StartOIDCLink creates the authorization url to link the provider's identity to the requester, completed with LinkOIDCIdentity
+func (a *authUsecase) StartOIDCLink(ctx context.Context, requester *model.Candidate, providerName string) (*model.OIDCAuthorization, error) {
+	return a.startOIDCAuthorization(ctx, providerName, requester.ID)
+}
+
+func (a *authUsecase) startOIDCAuthorization(ctx context.Context, providerName string, linkCandidateID int64) (*model.OIDCAuthorization, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":             utils.DumpIncomingContext(ctx),
+		"provider":        providerName,
+		"linkCandidateID": linkCandidateID,
+	})
+
+	provider, ok := a.oidcProviders[providerName]
+	if !ok {
+		return nil, ErrOIDCProviderNotFound
+	}
+
+	randoms := make([]string, 3)
+	for i := range randoms {
+		bt, err := utils.GenerateRandomBytes(oidcRandomLength)
+		if err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+		randoms[i] = base64.RawURLEncoding.EncodeToString(bt)
+	}
+
+	state := randoms[0]
+	loginState := oidcLoginState{
+		Provider:        provider.Name(),
+		Nonce:           randoms[1],
+		CodeVerifier:    randoms[2],
+		LinkCandidateID: linkCandidateID,
+	}
+
+	err := a.cacheManager.StoreWithoutBlocking(cacher.NewItemWithCustomTTL(newOIDCStateCacheKey(helper.HashToken(state)), utils.Dump(loginState), config.OIDCStateDuration()))
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return &model.OIDCAuthorization{
+		Provider:         provider.Name(),
+		AuthorizationURL: provider.AuthorizationURL(state, loginState.Nonce, loginState.CodeVerifier),
+		State:            state,
+		ExpiredAt:        time.Now().Add(config.OIDCStateDuration()),
+	}, nil
+}
+
+// LoginByOIDC completes the authorization with the code and state from the provider's redirect.
+// The candidate is found by the linked identity, otherwise the identity is linked to the candidate with the same email
+// verified by both the provider and the candidate, otherwise a new candidate is registered. The state is single use.
+func (a *authUsecase) LoginByOIDC(ctx context.Context, req model.OIDCLoginRequest) (*model.Session, *model.MFAChallenge, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":       utils.DumpIncomingContext(ctx),
+		"provider":  req.Provider,
+		"ipAddress": req.IPAddress,
+	})
+
+	if err := req.Validate(); err != nil {
+		logger.Error(err)
+		return nil, nil, err
+	}
+
+	provider, ok := a.oidcProviders[req.Provider]
+	if !ok {
+		return nil, nil, ErrOIDCProviderNotFound
+	}
+
+	loginReq := model.LoginRequest{
+		UserAgent:   req.UserAgent,
+		IPAddress:   req.IPAddress,
+		GeoLocation: a.resolveGeoLocation(ctx, req.IPAddress, req.GeoLocation),
+	}
+	event := newAuthEvent(model.AuthEventTypeLoginOIDC, 0, loginReq)
+
+	claims, err := a.completeOIDCAuthorization(ctx, provider, req.Code, req.State, 0)
+	switch err {
+	case nil:
+	case ErrOIDCStateInvalid, ErrOIDCAuthenticationFailed:
+		a.recordAuthEvent(ctx, event, err)
+		return nil, nil, err
+	default:
+		logger.Error(err)
+		return nil, nil, err
+	}
+
+	event.Identifier = fmt.Sprintf("%s:%s", provider.Name(), claims.Subject)
+	candidate, err := a.findOrCreateCandidateByOIDC(ctx, provider.Name(), claims)
+	switch err {
+	case nil:
+	case ErrDuplicateEmail, ErrOIDCLinkRequired:
+		a.recordAuthEvent(ctx, event, err)
+		return nil, nil, err
+	default:
+		logger.Error(err)
+		return nil, nil, err
+	}
+
+	event.CandidateID = null.IntFrom(candidate.ID)
+	session, challenge, err := a.createSessionOrChallenge(ctx, candidate, loginReq)
+	if err != nil {
+		logger.Error(err)
+		return nil, nil, err
+	}
+
+	a.recordLoginEvent(ctx, event, session, challenge)
+
+	return session, challenge, nil
+}
+
+// LinkOIDCIdentity completes the authorization started by StartOIDCLink, then links the provider's identity to the requester.
+// The identity linked to the other candidate can't be linked.
+func (a *authUsecase) LinkOIDCIdentity(ctx context.Context, requester *model.Candidate, req model.OIDCLinkRequest) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         utils.DumpIncomingContext(ctx),
+		"provider":    req.Provider,
+		"candidateID": requester.ID,
+	})
+
+	if err := req.Validate(); err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	provider, ok := a.oidcProviders[req.Provider]
+	if !ok {
+		return ErrOIDCProviderNotFound
+	}
+
+	claims, err := a.completeOIDCAuthorization(ctx, provider, req.Code, req.State, requester.ID)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	identity, err := a.candidateIdentityRepo.FindByProviderAndSubject(ctx, provider.Name(), claims.Subject)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	switch {
+	case identity == nil:
+	case identity.CandidateID == requester.ID:
+		return nil
+	default:
+		return ErrOIDCIdentityAlreadyLinked
+	}
+
+	email := helper.FormatEmail(claims.Email)
+	err = a.candidateIdentityRepo.Create(ctx, &model.CandidateIdentity{
+		ID:          utils.GenerateID(),
+		CandidateID: requester.ID,
+		Provider:    provider.Name(),
+		Subject:     claims.Subject,
+		Email:       null.NewString(email, email != ""),
+	})
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// completeOIDCAuthorization consume the single use state atomically, then authenticate the code with the provider.
+// The state of the link authorization is only valid for the same candidate, and the state of the login is not valid for the link.
+func (a *authUsecase) completeOIDCAuthorization(ctx context.Context, provider model.OIDCProvider, code, state string, linkCandidateID int64) (*model.OIDCClaims, error) {
+	reply, err := a.cacheManager.GetAndDelete(newOIDCStateCacheKey(helper.HashToken(state)))
+	if err != nil {
+		return nil, err
+	}
+
+	bt, _ := reply.([]byte)
+	if bt == nil {
+		return nil, ErrOIDCStateInvalid
+	}
+
+	loginState := oidcLoginState{}
+	if err := json.Unmarshal(bt, &loginState); err != nil {
+		return nil, err
+	}
+
+	if loginState.Provider != provider.Name() || loginState.LinkCandidateID != linkCandidateID {
+		return nil, ErrOIDCStateInvalid
+	}
+
+	claims, err := provider.Authenticate(ctx, code, loginState.CodeVerifier, loginState.Nonce)
+	if err != nil {
+		logrus.WithField("provider", provider.Name()).Error(err)
+		return nil, ErrOIDCAuthenticationFailed
+	}
+
+	return claims, nil
+}
+
+// findOrCreateCandidateByOIDC find the candidate linked to the identity.
+// The unlinked identity is only linked to the existing candidate when both the provider and the candidate have verified the email,
+// so the account registered by someone else with the unverified email can't keep the access.
+// Otherwise the candidate must sign in and link the identity explicitly.
+func (a *authUsecase) findOrCreateCandidateByOIDC(ctx context.Context, provider string, claims *model.OIDCClaims) (*model.Candidate, error) {
+	identity, err := a.candidateIdentityRepo.FindByProviderAndSubject(ctx, provider, claims.Subject)
+	if err != nil {
+		return nil, err
+	}
+
+	if identity != nil {
+		return a.findCandidateByID(ctx, identity.CandidateID)
+	}
+
+	email := helper.FormatEmail(claims.Email)
+	candidate, err := a.candidateRepo.FindUnscopedByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case candidate == nil:
+	case !claims.EmailVerified:
+		return nil, ErrDuplicateEmail
+	case !candidate.EmailVerifiedAt.Valid:
+		return nil, ErrOIDCLinkRequired
+	}
+
+	if candidate == nil {
+		candidate = &model.Candidate{
+			ID:       utils.GenerateID(),
+			FullName: oidcFullName(claims),
+			Email:    null.NewString(email, email != ""),
+		}
+		if claims.EmailVerified {
+			candidate.EmailVerifiedAt = null.TimeFrom(time.Now())
+		}
+
+		// the candidate signed up with the provider has no password, the password can be set with the reset password
+		if err := a.candidateRepo.Create(ctx, candidate); err != nil {
+			return nil, err
+		}
+	}
+
+	err = a.candidateIdentityRepo.Create(ctx, &model.CandidateIdentity{
+		ID:          utils.GenerateID(),
+		CandidateID: candidate.ID,
+		Provider:    provider,
+		Subject:     claims.Subject,
+		Email:       null.NewString(email, email != ""),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return a.findCandidateByID(ctx, candidate.ID)
+}
+
+func (a *authUsecase) findCandidateByID(ctx context.Context, id int64) (*model.Candidate, error) {
+	candidate, err := a.candidateRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if candidate == nil {
+		return nil, ErrNotFound
+	}
+
+	return candidate, nil
+}
+
+// oidcFullName the full name from the claims, the email's local part is used when the provider doesn't share the name
+func oidcFullName(claims *model.OIDCClaims) string {
+	if name := strings.TrimSpace(claims.Name); name != "" {
+		return name
+	}
+
+	if i := strings.Index(claims.Email, "@"); i > 0 {
+		return claims.Email[:i]
+	}
+
+	return claims.Subject
+}
+
+func newOIDCStateCacheKey(stateHash string) string {
+	return fmt.Sprintf("cache:oidc_state:state_hash:%s", stateHash)
+}
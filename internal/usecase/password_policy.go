@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"github.com/irvankadhafi/talent-hub-service/internal/config"
+	"github.com/irvankadhafi/talent-hub-service/internal/helper"
+	"github.com/irvankadhafi/talent-hub-service/internal/model"
+	"github.com/sirupsen/logrus"
+)
+
+// checkPasswordPolicy check the new password against the configured password policy and the breached passwords.
+// The candidate's full name, email and phone are disallowed to be contained in the password.
+func checkPasswordPolicy(breachedPasswordChecker model.BreachedPasswordChecker, password string, candidate *model.Candidate) error {
+	policy := helper.PasswordPolicy{
+		MinLength:            config.PasswordPolicyMinLength(),
+		MaxLength:            config.PasswordPolicyMaxLength(),
+		RequireUppercase:     config.PasswordPolicyRequireUppercase(),
+		RequireLowercase:     config.PasswordPolicyRequireLowercase(),
+		RequireDigit:         config.PasswordPolicyRequireDigit(),
+		RequireSymbol:        config.PasswordPolicyRequireSymbol(),
+		DisallowPersonalInfo: config.PasswordPolicyDisallowPersonalInfo(),
+		MinStrengthScore:     config.PasswordPolicyMinStrengthScore(),
+	}
+
+	reasons := policy.Check(password, candidate.FullName, candidate.Email.String, candidate.Phone.String)
+
+	if breachedPasswordChecker != nil {
+		breached, err := breachedPasswordChecker.IsBreached(password)
+		switch {
+		case err != nil:
+			// the password is not rejected when the breached passwords can't be checked
+			logrus.WithField("candidateID", candidate.ID).Error(err)
+		case breached:
+			reasons = append(reasons, helper.PasswordViolationBreached)
+		}
+	}
+
+	if len(reasons) > 0 {
+		return &PasswordPolicyError{Reasons: reasons}
+	}
+
+	return nil
+}
@@ -0,0 +1,270 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"github.com/irvankadhafi/talent-hub-service/internal/config"
+	"github.com/irvankadhafi/talent-hub-service/internal/helper"
+	"github.com/irvankadhafi/talent-hub-service/internal/model"
+	"github.com/irvankadhafi/talent-hub-service/pkg/cacher"
+	"github.com/irvankadhafi/talent-hub-service/utils"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+type passwordUsecase struct {
+	candidateRepo          model.CandidateRepository
+	sessionRepo            model.SessionRepository
+	passwordResetTokenRepo model.PasswordResetTokenRepository
+	emailSender            model.EmailSender
+	passwordHasher         model.PasswordHasher
+	cacheManager           cacher.CacheManager
+
+	breachedPasswordChecker model.BreachedPasswordChecker
+}
+
+// NewPasswordUsecase passwordUsecase constructor
+func NewPasswordUsecase(
+	candidateRepo model.CandidateRepository,
+	sessionRepo model.SessionRepository,
+	passwordResetTokenRepo model.PasswordResetTokenRepository,
+	emailSender model.EmailSender,
+	passwordHasher model.PasswordHasher,
+	cacheManager cacher.CacheManager,
+	breachedPasswordChecker model.BreachedPasswordChecker,
+) model.PasswordUsecase {
+	return &passwordUsecase{
+		candidateRepo:          candidateRepo,
+		sessionRepo:            sessionRepo,
+		passwordResetTokenRepo: passwordResetTokenRepo,
+		emailSender:            emailSender,
+		passwordHasher:         passwordHasher,
+		cacheManager:           cacheManager,
+
+		breachedPasswordChecker: breachedPasswordChecker,
+	}
+}
+
+// ForgotPassword sends the password reset token to the candidate's email in the background.
+// Unregistered email is not reported and the sending error is only logged,
+// so the response is the same for every email and the registered emails can't be enumerated.
+// The resend cooldown is claimed before the candidate lookup, so it applies to the unregistered email as well.
+func (p *passwordUsecase) ForgotPassword(ctx context.Context, input model.ForgotPasswordInput) error {
+	input.Email = helper.FormatEmail(input.Email)
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   utils.DumpIncomingContext(ctx),
+		"email": input.Email,
+	})
+
+	if err := input.Validate(); err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	cooldown := cacher.NewItemWithCustomTTL(newPasswordResetCooldownCacheKey(input.Email), time.Now().Unix(), config.PasswordResetResendCooldown())
+	claimed, err := p.cacheManager.StoreIfNotExist(cooldown)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if !claimed {
+		return ErrTooManyRequests
+	}
+
+	candidate, err := p.candidateRepo.FindByEmail(ctx, input.Email)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if candidate == nil {
+		logger.Warn("forgot password requested for unregistered email")
+		return nil
+	}
+
+	go p.sendPasswordResetEmail(candidate, logger)
+
+	return nil
+}
+
+// sendPasswordResetEmail creates the password reset token, then sends it to the candidate's email.
+// It runs detached from the request, so it uses its own context bounded by the smtp timeout.
+func (p *passwordUsecase) sendPasswordResetEmail(candidate *model.Candidate, logger *logrus.Entry) {
+	ctx, cancel := context.WithTimeout(context.Background(), config.SMTPTimeout())
+	defer cancel()
+
+	token, err := utils.GenerateRandomStringURLSafe(config.DefaultPasswordResetTokenLength)
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+
+	resetToken := &model.PasswordResetToken{
+		ID:          utils.GenerateID(),
+		CandidateID: candidate.ID,
+		TokenHash:   helper.HashToken(token),
+		ExpiredAt:   time.Now().Add(config.PasswordResetTokenDuration()),
+	}
+	if err := p.passwordResetTokenRepo.Create(ctx, resetToken); err != nil {
+		logger.Error(err)
+		return
+	}
+
+	if err := p.emailSender.SendEmail(ctx, candidate.Email.String, "Reset your password", newPasswordResetEmailBody(token)); err != nil {
+		logger.Error(err)
+	}
+}
+
+// ResetPassword resets the candidate's password using the single use token,
+// then revokes all the candidate's sessions
+func (p *passwordUsecase) ResetPassword(ctx context.Context, input model.ResetPasswordInput) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx": utils.DumpIncomingContext(ctx),
+	})
+
+	if err := input.Validate(); err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	resetToken, err := p.passwordResetTokenRepo.FindByToken(ctx, input.Token)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if resetToken == nil || !resetToken.IsUsable() {
+		return ErrPasswordResetTokenInvalid
+	}
+
+	logger = logger.WithField("candidateID", resetToken.CandidateID)
+
+	candidate, err := p.findCandidateAndCheckPassword(ctx, resetToken.CandidateID, input.Password)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	// mark the token first, so concurrent requests can't use the same token twice
+	marked, err := p.passwordResetTokenRepo.MarkAsUsed(ctx, resetToken)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if !marked {
+		return ErrPasswordResetTokenInvalid
+	}
+
+	if err := updateCandidatePassword(ctx, p.candidateRepo, p.passwordHasher, candidate.ID, input.Password); err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if err := p.sessionRepo.DeleteAllByCandidateID(ctx, resetToken.CandidateID); err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// ChangePassword changes the requester's password after verifying the current password,
+// optionally revokes all the requester's other sessions
+func (p *passwordUsecase) ChangePassword(ctx context.Context, requester *model.Candidate, input model.ChangePasswordInput) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         utils.DumpIncomingContext(ctx),
+		"candidateID": requester.ID,
+	})
+
+	if requester.ImpersonatorID != 0 {
+		return ErrPermissionDenied
+	}
+
+	if err := input.Validate(); err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	cipherPass, err := p.candidateRepo.FindPasswordByID(ctx, requester.ID)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if cipherPass == nil {
+		return ErrNotFound
+	}
+
+	match, err := p.passwordHasher.Verify(input.CurrentPassword, string(cipherPass))
+	if err != nil {
+		logger.Error(err)
+	}
+	if !match {
+		return ErrCurrentPasswordNotMatch
+	}
+
+	candidate, err := p.findCandidateAndCheckPassword(ctx, requester.ID, input.Password)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if err := updateCandidatePassword(ctx, p.candidateRepo, p.passwordHasher, candidate.ID, input.Password); err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if !input.RevokeOtherSessions {
+		return nil
+	}
+
+	if err := p.sessionRepo.DeleteAllByCandidateID(ctx, requester.ID, requester.SessionID); err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// findCandidateAndCheckPassword find the candidate, then check the new password against the password policy
+func (p *passwordUsecase) findCandidateAndCheckPassword(ctx context.Context, candidateID int64, plainPassword string) (*model.Candidate, error) {
+	candidate, err := p.candidateRepo.FindByID(ctx, candidateID)
+	if err != nil {
+		return nil, err
+	}
+
+	if candidate == nil {
+		return nil, ErrNotFound
+	}
+
+	if err := checkPasswordPolicy(p.breachedPasswordChecker, plainPassword, candidate); err != nil {
+		return nil, err
+	}
+
+	return candidate, nil
+}
+
+// updateCandidatePassword hash and update the candidate's password, the cached password is refreshed by the repository
+func updateCandidatePassword(ctx context.Context, candidateRepo model.CandidateRepository, passwordHasher model.PasswordHasher, candidateID int64, plainPassword string) error {
+	cipherPwd, err := passwordHasher.Hash(plainPassword)
+	if err != nil {
+		return err
+	}
+
+	return candidateRepo.UpdatePassword(ctx, candidateID, cipherPwd)
+}
+
+func newPasswordResetCooldownCacheKey(email string) string {
+	return fmt.Sprintf("cache:password_reset_cooldown:email:%s", email)
+}
+
+func newPasswordResetEmailBody(token string) string {
+	if config.PasswordResetURL() == "" {
+		return fmt.Sprintf("Use this token to reset your password: %s\n\nThe token expires in %s.", token, config.PasswordResetTokenDuration())
+	}
+
+	link := fmt.Sprintf(config.PasswordResetURL(), token)
+	return fmt.Sprintf("Open this link to reset your password: %s\n\nThe link expires in %s.", link, config.PasswordResetTokenDuration())
+}
@@ -0,0 +1,133 @@
+package usecase
+
+import (
+	"context"
+	"github.com/irvankadhafi/talent-hub-service/internal/model"
+	"github.com/irvankadhafi/talent-hub-service/utils"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+type roleUsecase struct {
+	candidateRepo model.CandidateRepository
+	sessionRepo   model.SessionRepository
+	roleRepo      model.RoleRepository
+}
+
+// NewRoleUsecase roleUsecase constructor
+func NewRoleUsecase(
+	candidateRepo model.CandidateRepository,
+	sessionRepo model.SessionRepository,
+	roleRepo model.RoleRepository,
+) model.RoleUsecase {
+	return &roleUsecase{
+		candidateRepo: candidateRepo,
+		sessionRepo:   sessionRepo,
+		roleRepo:      roleRepo,
+	}
+}
+
+// FindAllByCandidateID find the roles of the candidate, including the implicit candidate role
+func (r *roleUsecase) FindAllByCandidateID(ctx context.Context, requester *model.Candidate, candidateID int64) ([]model.Role, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         utils.DumpIncomingContext(ctx),
+		"requesterID": requester.ID,
+		"candidateID": candidateID,
+	})
+
+	if err := r.checkCandidate(ctx, requester, candidateID); err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	roles, err := findCandidateRoles(ctx, r.roleRepo, candidateID)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return roles, nil
+}
+
+// Assign assign the role to the candidate, the role takes effect on the candidate's next login or token refresh
+func (r *roleUsecase) Assign(ctx context.Context, requester *model.Candidate, candidateID int64, role model.Role) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         utils.DumpIncomingContext(ctx),
+		"requesterID": requester.ID,
+		"candidateID": candidateID,
+		"role":        role,
+	})
+
+	if !role.IsValid() || role == model.RoleCandidate {
+		return ErrInvalidRole
+	}
+
+	if err := r.checkCandidate(ctx, requester, candidateID); err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	err := r.roleRepo.Create(ctx, &model.CandidateRole{
+		CandidateID: candidateID,
+		Role:        role,
+		CreatedAt:   time.Now(),
+	})
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// Revoke revoke the role from the candidate, then delete all the candidate's sessions,
+// so the opaque access tokens and the refresh tokens are rejected immediately.
+// The signed access token is verified offline without the session, so on the jwt token mode
+// the revoked permissions are still usable until the token expires, at most config.JWTAccessTokenDuration.
+func (r *roleUsecase) Revoke(ctx context.Context, requester *model.Candidate, candidateID int64, role model.Role) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         utils.DumpIncomingContext(ctx),
+		"requesterID": requester.ID,
+		"candidateID": candidateID,
+		"role":        role,
+	})
+
+	if !role.IsValid() || role == model.RoleCandidate {
+		return ErrInvalidRole
+	}
+
+	if err := r.checkCandidate(ctx, requester, candidateID); err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if err := r.roleRepo.Delete(ctx, candidateID, role); err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if err := r.sessionRepo.DeleteAllByCandidateID(ctx, candidateID); err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// checkCandidate check the requester is allowed to manage the roles and the candidate exists
+func (r *roleUsecase) checkCandidate(ctx context.Context, requester *model.Candidate, candidateID int64) error {
+	if !requester.HasPermission(model.PermissionRoleManage) {
+		return ErrPermissionDenied
+	}
+
+	candidate, err := r.candidateRepo.FindByID(ctx, candidateID)
+	if err != nil {
+		return err
+	}
+
+	if candidate == nil {
+		return ErrNotFound
+	}
+
+	return nil
+}
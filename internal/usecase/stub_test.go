@@ -0,0 +1,424 @@
+package usecase
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/irvankadhafi/talent-hub-service/internal/config"
+	"github.com/irvankadhafi/talent-hub-service/internal/helper"
+	"github.com/irvankadhafi/talent-hub-service/internal/model"
+	"github.com/irvankadhafi/talent-hub-service/pkg/cacher"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+	"gopkg.in/guregu/null.v4"
+)
+
+// the stubs embed the interface, so calling a method not stubbed panics and fails the test
+
+// stubCacheManager an in-memory cache manager storing the values as bytes, like the redis reply
+type stubCacheManager struct {
+	cacher.CacheManager
+
+	mu    sync.Mutex
+	items map[string]stubCacheItem
+}
+
+type stubCacheItem struct {
+	value []byte
+	ttl   time.Duration
+}
+
+func newStubCacheManager() *stubCacheManager {
+	return &stubCacheManager{items: map[string]stubCacheItem{}}
+}
+
+func (s *stubCacheManager) Get(key string) (any, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	item, ok := s.items[key]
+	if !ok {
+		return nil, nil
+	}
+
+	return item.value, nil
+}
+
+func (s *stubCacheManager) StoreWithoutBlocking(item cacher.Item) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.store(item)
+	return nil
+}
+
+func (s *stubCacheManager) StoreIfNotExist(item cacher.Item) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.items[item.GetKey()]; ok {
+		return false, nil
+	}
+
+	s.store(item)
+	return true, nil
+}
+
+func (s *stubCacheManager) StoreMultiWithoutBlocking(items []cacher.Item) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, item := range items {
+		s.store(item)
+	}
+	return nil
+}
+
+func (s *stubCacheManager) DeleteByKeys(keys []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, key := range keys {
+		delete(s.items, key)
+	}
+	return nil
+}
+
+func (s *stubCacheManager) IncreaseCachedValueByOneWithTTL(key string, ttl time.Duration) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	item, ok := s.items[key]
+	if !ok {
+		item = stubCacheItem{value: []byte("0"), ttl: ttl}
+	}
+
+	value, err := strconv.ParseInt(string(item.value), 10, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	value++
+	item.value = []byte(strconv.FormatInt(value, 10))
+	s.items[key] = item
+
+	return value, nil
+}
+
+// value return the cached value, empty when the key doesn't exist
+func (s *stubCacheManager) value(key string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return string(s.items[key].value)
+}
+
+// ttl return the ttl of the key, zero when the key doesn't exist
+func (s *stubCacheManager) ttl(key string) time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.items[key].ttl
+}
+
+func (s *stubCacheManager) exists(key string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, ok := s.items[key]
+	return ok
+}
+
+func (s *stubCacheManager) store(item cacher.Item) {
+	var value []byte
+	switch v := item.GetValue().(type) {
+	case []byte:
+		value = v
+	case string:
+		value = []byte(v)
+	default:
+		value = []byte(fmt.Sprint(v))
+	}
+
+	s.items[item.GetKey()] = stubCacheItem{
+		value: value,
+		ttl:   time.Duration(item.GetTTLInt64()) * time.Second,
+	}
+}
+
+type stubCandidateRepository struct {
+	model.CandidateRepository
+
+	candidates  map[int64]*model.Candidate
+	passwords   map[int64]string
+	totpSecrets map[int64]string
+}
+
+func newStubCandidateRepository() *stubCandidateRepository {
+	return &stubCandidateRepository{
+		candidates:  map[int64]*model.Candidate{},
+		passwords:   map[int64]string{},
+		totpSecrets: map[int64]string{},
+	}
+}
+
+// add the candidate with the password hashed by the hasher
+func (s *stubCandidateRepository) add(t *testing.T, hasher model.PasswordHasher, candidate *model.Candidate, plainPassword string) {
+	t.Helper()
+
+	cipherPwd, err := hasher.Hash(plainPassword)
+	require.NoError(t, err)
+
+	s.candidates[candidate.ID] = candidate
+	s.passwords[candidate.ID] = cipherPwd
+}
+
+func (s *stubCandidateRepository) FindByID(_ context.Context, id int64) (*model.Candidate, error) {
+	return s.copyOf(s.candidates[id]), nil
+}
+
+func (s *stubCandidateRepository) FindByEmail(_ context.Context, email string) (*model.Candidate, error) {
+	for _, candidate := range s.candidates {
+		if candidate.Email.String == email {
+			return s.copyOf(candidate), nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *stubCandidateRepository) FindByPhone(_ context.Context, phone string) (*model.Candidate, error) {
+	for _, candidate := range s.candidates {
+		if candidate.Phone.String == phone {
+			return s.copyOf(candidate), nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *stubCandidateRepository) FindPasswordByID(_ context.Context, id int64) ([]byte, error) {
+	return []byte(s.passwords[id]), nil
+}
+
+func (s *stubCandidateRepository) FindTOTPSecretByID(_ context.Context, id int64) (null.String, error) {
+	secret, ok := s.totpSecrets[id]
+	return null.NewString(secret, ok), nil
+}
+
+func (s *stubCandidateRepository) copyOf(candidate *model.Candidate) *model.Candidate {
+	if candidate == nil {
+		return nil
+	}
+
+	c := *candidate
+	return &c
+}
+
+type stubSessionRepository struct {
+	model.SessionRepository
+
+	mu         sync.Mutex
+	sessions   map[int64]*model.Session
+	superseded map[string]*model.SupersededRefreshToken
+
+	// afterFindByToken is called after the session is found, to rotate the token concurrently
+	afterFindByToken func()
+}
+
+func newStubSessionRepository() *stubSessionRepository {
+	return &stubSessionRepository{
+		sessions:   map[int64]*model.Session{},
+		superseded: map[string]*model.SupersededRefreshToken{},
+	}
+}
+
+func (s *stubSessionRepository) Create(_ context.Context, session *model.Session) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sessions[session.ID] = session
+	return nil
+}
+
+func (s *stubSessionRepository) FindByToken(_ context.Context, tokenType model.TokenType, token string) (*model.Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, session := range s.sessions {
+		if (tokenType == model.AccessToken && session.AccessToken == token) ||
+			(tokenType == model.RefreshToken && session.RefreshToken == token) {
+			sess := *session
+			if s.afterFindByToken != nil {
+				s.afterFindByToken()
+			}
+			return &sess, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *stubSessionRepository) FindByID(_ context.Context, id int64) (*model.Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, ok := s.sessions[id]
+	if !ok {
+		return nil, nil
+	}
+
+	sess := *session
+	return &sess, nil
+}
+
+func (s *stubSessionRepository) Delete(_ context.Context, session *model.Session) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.sessions, session.ID)
+	return nil
+}
+
+// RefreshToken rotate the token only when the old refresh token is still current, like the conditional update
+func (s *stubSessionRepository) RefreshToken(_ context.Context, oldSess, sess *model.Session) (*model.Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, ok := s.sessions[sess.ID]
+	if !ok || current.RefreshToken != oldSess.RefreshToken {
+		return nil, nil
+	}
+
+	s.sessions[sess.ID] = sess
+	tokenHash := helper.HashToken(oldSess.RefreshToken)
+	s.superseded[tokenHash] = &model.SupersededRefreshToken{
+		TokenHash:   tokenHash,
+		SessionID:   oldSess.ID,
+		CandidateID: oldSess.CandidateID,
+		ExpiredAt:   oldSess.RefreshTokenExpiredAt,
+	}
+
+	return sess, nil
+}
+
+func (s *stubSessionRepository) FindSupersededRefreshToken(_ context.Context, refreshToken string) (*model.SupersededRefreshToken, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.superseded[helper.HashToken(refreshToken)], nil
+}
+
+func (s *stubSessionRepository) CheckToken(_ context.Context, _ string) (bool, error) {
+	return false, nil
+}
+
+// FindAllActiveByCandidateID return no session, so the login is never flagged as risky
+func (s *stubSessionRepository) FindAllActiveByCandidateID(_ context.Context, _ int64) ([]*model.Session, error) {
+	return nil, nil
+}
+
+type stubRoleRepository struct {
+	model.RoleRepository
+}
+
+func (s *stubRoleRepository) FindAllByCandidateID(_ context.Context, _ int64) ([]model.Role, error) {
+	return nil, nil
+}
+
+type stubSessionCleaner struct{}
+
+func (s *stubSessionCleaner) EnqueueCleanup(_ int64) {}
+
+type stubAuthEventRecorder struct {
+	mu     sync.Mutex
+	events []*model.AuthEvent
+}
+
+func (s *stubAuthEventRecorder) EnqueueRecord(event *model.AuthEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.events = append(s.events, event)
+}
+
+// stubSMSSender pass the sent messages to the channel, since the sms is sent in the background
+type stubSMSSender struct {
+	err  error
+	sent chan string
+}
+
+func (s *stubSMSSender) SendSMS(_ context.Context, to, message string) error {
+	s.sent <- to + ": " + message
+	return s.err
+}
+
+// testAuthUsecase the auth usecase with the stubbed dependencies
+type testAuthUsecase struct {
+	*authUsecase
+
+	cacheManager      *stubCacheManager
+	candidateRepo     *stubCandidateRepository
+	sessionRepo       *stubSessionRepository
+	authEventRecorder *stubAuthEventRecorder
+	smsSender         *stubSMSSender
+	passwordHasher    model.PasswordHasher
+}
+
+func newTestAuthUsecase(t *testing.T) *testAuthUsecase {
+	t.Helper()
+
+	passwordHasher, err := helper.NewPasswordHasher(helper.PasswordHashAlgorithmBcrypt, helper.Argon2idParams{}, bcrypt.MinCost, 1)
+	require.NoError(t, err)
+
+	tu := &testAuthUsecase{
+		cacheManager:      newStubCacheManager(),
+		candidateRepo:     newStubCandidateRepository(),
+		sessionRepo:       newStubSessionRepository(),
+		authEventRecorder: &stubAuthEventRecorder{},
+		smsSender:         &stubSMSSender{sent: make(chan string, 1)},
+		passwordHasher:    passwordHasher,
+	}
+	tu.authUsecase = NewAuthUsecase(AuthUsecaseDeps{
+		CandidateRepo:     tu.candidateRepo,
+		SessionRepo:       tu.sessionRepo,
+		RoleRepo:          &stubRoleRepository{},
+		SessionCleaner:    &stubSessionCleaner{},
+		CacheManager:      tu.cacheManager,
+		SMSSender:         tu.smsSender,
+		AuthEventRecorder: tu.authEventRecorder,
+		PasswordHasher:    passwordHasher,
+	}).(*authUsecase)
+
+	return tu
+}
+
+// setTestTOTPEncryptionKey set the TOTP secret encryption key for the test
+func setTestTOTPEncryptionKey(t *testing.T) {
+	t.Helper()
+
+	viper.Set("mfa.totp_encryption_key", base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", 32))))
+	t.Cleanup(func() {
+		viper.Set("mfa.totp_encryption_key", "")
+	})
+}
+
+// enableTestTOTP enable the TOTP of the candidate with the new secret, then return the plain secret
+func enableTestTOTP(t *testing.T, candidateRepo *stubCandidateRepository, candidate *model.Candidate) string {
+	t.Helper()
+
+	secret, err := helper.GenerateTOTPSecret()
+	require.NoError(t, err)
+
+	encrypted, err := helper.EncryptSecret(config.MFATOTPEncryptionKey(), secret)
+	require.NoError(t, err)
+
+	candidate.TOTPEnabledAt = null.TimeFrom(time.Now())
+	candidateRepo.totpSecrets[candidate.ID] = encrypted
+
+	return secret
+}
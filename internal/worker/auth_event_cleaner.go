@@ -0,0 +1,85 @@
+package worker
+
+import (
+	"context"
+	"github.com/irvankadhafi/talent-hub-service/internal/config"
+	"github.com/irvankadhafi/talent-hub-service/internal/model"
+	"github.com/sirupsen/logrus"
+	"sync"
+	"time"
+)
+
+// AuthEventCleaner periodically deletes the auth events exceeding the retention in the background
+type AuthEventCleaner struct {
+	authEventRepo model.AuthEventRepository
+	interval      time.Duration
+	stopCh        chan struct{}
+	wg            sync.WaitGroup
+	stopOnce      sync.Once
+}
+
+// NewAuthEventCleaner AuthEventCleaner constructor
+func NewAuthEventCleaner(authEventRepo model.AuthEventRepository, interval time.Duration) *AuthEventCleaner {
+	return &AuthEventCleaner{
+		authEventRepo: authEventRepo,
+		interval:      interval,
+		stopCh:        make(chan struct{}),
+	}
+}
+
+// Start spawns the worker, the first cleanup runs immediately
+func (w *AuthEventCleaner) Start() {
+	w.wg.Add(1)
+	go w.work()
+}
+
+// Stop stops the worker and waits until the running cleanup is done
+func (w *AuthEventCleaner) Stop() {
+	w.stopOnce.Do(func() {
+		close(w.stopCh)
+		w.wg.Wait()
+	})
+}
+
+func (w *AuthEventCleaner) work() {
+	defer w.wg.Done()
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		w.cleanup()
+
+		select {
+		case <-w.stopCh:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// cleanup deletes the expired auth events batch by batch, so the table is not locked for long
+func (w *AuthEventCleaner) cleanup() {
+	createdBefore := time.Now().Add(-config.AuthEventRetention())
+	batchSize := config.AuthEventCleanupBatchSize()
+
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), config.AuthEventCleanupTimeout())
+		deleted, err := w.authEventRepo.DeleteCreatedBefore(ctx, createdBefore, batchSize)
+		cancel()
+		if err != nil {
+			logrus.WithField("createdBefore", createdBefore).Error(err)
+			return
+		}
+
+		if deleted < int64(batchSize) {
+			return
+		}
+
+		select {
+		case <-w.stopCh:
+			return
+		default:
+		}
+	}
+}
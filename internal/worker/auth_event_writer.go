@@ -0,0 +1,67 @@
+package worker
+
+import (
+	"context"
+	"github.com/irvankadhafi/talent-hub-service/internal/config"
+	"github.com/irvankadhafi/talent-hub-service/internal/model"
+	"github.com/irvankadhafi/talent-hub-service/utils"
+	"github.com/sirupsen/logrus"
+	"sync"
+)
+
+// AuthEventWriter writes the auth events to the database in the background
+type AuthEventWriter struct {
+	authEventRepo model.AuthEventRepository
+	workerCount   int
+	eventCh       chan *model.AuthEvent
+	wg            sync.WaitGroup
+	stopOnce      sync.Once
+}
+
+// NewAuthEventWriter AuthEventWriter constructor
+func NewAuthEventWriter(authEventRepo model.AuthEventRepository, workerCount, queueSize int) *AuthEventWriter {
+	return &AuthEventWriter{
+		authEventRepo: authEventRepo,
+		workerCount:   workerCount,
+		eventCh:       make(chan *model.AuthEvent, queueSize),
+	}
+}
+
+// Start spawns the workers
+func (w *AuthEventWriter) Start() {
+	for i := 0; i < w.workerCount; i++ {
+		w.wg.Add(1)
+		go w.work()
+	}
+}
+
+// Stop stops accepting new event and waits until the queued events are written
+func (w *AuthEventWriter) Stop() {
+	w.stopOnce.Do(func() {
+		close(w.eventCh)
+		w.wg.Wait()
+	})
+}
+
+// EnqueueRecord enqueue the auth event without blocking,
+// the event is only logged when the queue is full so the audit trail is kept on the log
+func (w *AuthEventWriter) EnqueueRecord(event *model.AuthEvent) {
+	select {
+	case w.eventCh <- event:
+	default:
+		logrus.WithField("authEvent", utils.Dump(event)).Warn("auth event writer queue is full, event dropped")
+	}
+}
+
+func (w *AuthEventWriter) work() {
+	defer w.wg.Done()
+
+	for event := range w.eventCh {
+		ctx, cancel := context.WithTimeout(context.Background(), config.AuthEventWriteTimeout())
+		err := w.authEventRepo.Create(ctx, event)
+		cancel()
+		if err != nil {
+			logrus.WithField("authEvent", utils.Dump(event)).Error(err)
+		}
+	}
+}
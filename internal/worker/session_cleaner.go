@@ -0,0 +1,66 @@
+package worker
+
+import (
+	"context"
+	"github.com/irvankadhafi/talent-hub-service/internal/config"
+	"github.com/irvankadhafi/talent-hub-service/internal/model"
+	"github.com/sirupsen/logrus"
+	"sync"
+)
+
+// SessionCleaner deletes the candidate's sessions exceeding the max active session in the background
+type SessionCleaner struct {
+	sessionRepo model.SessionRepository
+	workerCount int
+	jobCh       chan int64
+	wg          sync.WaitGroup
+	stopOnce    sync.Once
+}
+
+// NewSessionCleaner SessionCleaner constructor
+func NewSessionCleaner(sessionRepo model.SessionRepository, workerCount, queueSize int) *SessionCleaner {
+	return &SessionCleaner{
+		sessionRepo: sessionRepo,
+		workerCount: workerCount,
+		jobCh:       make(chan int64, queueSize),
+	}
+}
+
+// Start spawns the workers
+func (w *SessionCleaner) Start() {
+	for i := 0; i < w.workerCount; i++ {
+		w.wg.Add(1)
+		go w.work()
+	}
+}
+
+// Stop stops accepting new job and waits until the queued jobs are done
+func (w *SessionCleaner) Stop() {
+	w.stopOnce.Do(func() {
+		close(w.jobCh)
+		w.wg.Wait()
+	})
+}
+
+// EnqueueCleanup enqueue the cleanup of candidate's sessions without blocking,
+// the job is dropped when the queue is full since the next login will enqueue it again
+func (w *SessionCleaner) EnqueueCleanup(candidateID int64) {
+	select {
+	case w.jobCh <- candidateID:
+	default:
+		logrus.WithField("candidateID", candidateID).Warn("session cleaner queue is full, job dropped")
+	}
+}
+
+func (w *SessionCleaner) work() {
+	defer w.wg.Done()
+
+	for candidateID := range w.jobCh {
+		ctx, cancel := context.WithTimeout(context.Background(), config.SessionCleanupTimeout())
+		err := w.sessionRepo.DeleteByCandidateIDAndMaxRemainderSession(ctx, candidateID, config.MaxActiveSession())
+		cancel()
+		if err != nil {
+			logrus.WithField("candidateID", candidateID).Error(err)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package worker
+
+import (
+	"context"
+	"github.com/irvankadhafi/talent-hub-service/internal/config"
+	"github.com/irvankadhafi/talent-hub-service/internal/model"
+	"github.com/sirupsen/logrus"
+	"sync"
+	"time"
+)
+
+// SupersededRefreshTokenCleaner periodically deletes the expired superseded refresh tokens in the background,
+// a reused token is already rejected as expired so its hash is no longer needed
+type SupersededRefreshTokenCleaner struct {
+	sessionRepo model.SessionRepository
+	interval    time.Duration
+	stopCh      chan struct{}
+	wg          sync.WaitGroup
+	stopOnce    sync.Once
+}
+
+// NewSupersededRefreshTokenCleaner SupersededRefreshTokenCleaner constructor
+func NewSupersededRefreshTokenCleaner(sessionRepo model.SessionRepository, interval time.Duration) *SupersededRefreshTokenCleaner {
+	return &SupersededRefreshTokenCleaner{
+		sessionRepo: sessionRepo,
+		interval:    interval,
+		stopCh:      make(chan struct{}),
+	}
+}
+
+// Start spawns the worker, the first cleanup runs immediately
+func (w *SupersededRefreshTokenCleaner) Start() {
+	w.wg.Add(1)
+	go w.work()
+}
+
+// Stop stops the worker and waits until the running cleanup is done
+func (w *SupersededRefreshTokenCleaner) Stop() {
+	w.stopOnce.Do(func() {
+		close(w.stopCh)
+		w.wg.Wait()
+	})
+}
+
+func (w *SupersededRefreshTokenCleaner) work() {
+	defer w.wg.Done()
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		w.cleanup()
+
+		select {
+		case <-w.stopCh:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// cleanup deletes the expired superseded refresh tokens batch by batch, so the table is not locked for long
+func (w *SupersededRefreshTokenCleaner) cleanup() {
+	expiredBefore := time.Now()
+	batchSize := config.SupersededRefreshTokenCleanupBatchSize()
+
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), config.SessionCleanupTimeout())
+		deleted, err := w.sessionRepo.DeleteExpiredSupersededRefreshTokens(ctx, expiredBefore, batchSize)
+		cancel()
+		if err != nil {
+			logrus.WithField("expiredBefore", expiredBefore).Error(err)
+			return
+		}
+
+		if deleted < int64(batchSize) {
+			return
+		}
+
+		select {
+		case <-w.stopCh:
+			return
+		default:
+		}
+	}
+}
@@ -0,0 +1,4 @@
+// Package auth contains the generated AuthService protobuf and gRPC code
+package auth
+
+//go:generate protoc --proto_path=../.. --go_out=paths=source_relative:../.. --go-grpc_out=paths=source_relative:../.. pb/auth/auth.proto
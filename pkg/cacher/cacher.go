@@ -13,11 +13,31 @@ import (
 
 var nilValue = []byte("null")
 
+// getAndDeleteScript get then delete the key atomically, works on the redis without the GETDEL command
+var getAndDeleteScript = redigo.NewScript(1, `
+local value = redis.call("GET", KEYS[1])
+if value then
+	redis.call("DEL", KEYS[1])
+end
+return value
+`)
+
+// increaseWithTTLScript increase the counter then set the ttl when the counter has no ttl, atomically,
+// so the counter always expires even when it's increased concurrently
+var increaseWithTTLScript = redigo.NewScript(1, `
+local value = redis.call("INCR", KEYS[1])
+if redis.call("PTTL", KEYS[1]) < 0 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+end
+return value
+`)
+
 type (
 	GetterFn func() (any, error)
 
 	CacheManager interface {
 		Get(key string) (any, error)
+		GetAndDelete(key string) (any, error)
 		GetOrLock(key string) (any, *redsync.Mutex, error)
 		GetOrSet(key string, fn GetterFn, opts ...func(Item)) ([]byte, error)
 
@@ -28,6 +48,7 @@ type (
 
 		Store(*redsync.Mutex, Item) error
 		StoreWithoutBlocking(Item) error
+		StoreIfNotExist(Item) (bool, error)
 		StoreMultiWithoutBlocking([]Item) error
 		StoreMultiPersist([]Item) error
 		StoreNil(cacheKey string) error
@@ -39,6 +60,7 @@ type (
 		DeleteByKeys(keys []string) error
 
 		IncreaseCachedValueByOne(key string) error
+		IncreaseCachedValueByOneWithTTL(key string, ttl time.Duration) (int64, error)
 
 		CheckKeyExist(key string) (value bool, err error)
 
@@ -117,6 +139,23 @@ func (cache *cacheManager) Get(key string) (cachedItem any, err error) {
 	return nil, nil
 }
 
+// GetAndDelete is used to retrieve an item then delete it atomically, so the item can only be retrieved once.
+func (cache *cacheManager) GetAndDelete(key string) (cachedItem any, err error) {
+	if cache.disableCaching {
+		return
+	}
+
+	client := cache.connPool.Get()
+	defer utils.WrapCloser(client.Close)
+
+	cachedItem, err = getAndDeleteScript.Do(client, key)
+	if err == redigo.ErrNil {
+		return nil, nil
+	}
+
+	return
+}
+
 // GetOrLock is used to retrieve an item from the cache based on the key. If the item is not found,
 // it will acquire a lock and wait for the item to be available in the cache.
 func (cache *cacheManager) GetOrLock(key string) (cachedItem any, mutex *redsync.Mutex, err error) {
@@ -347,6 +386,27 @@ func (cache *cacheManager) StoreWithoutBlocking(item Item) error {
 	return err
 }
 
+// StoreIfNotExist is used to store the item only when the key doesn't exist, atomically.
+// It returns whether the item is stored, so only one of the concurrent callers claims the key.
+func (cache *cacheManager) StoreIfNotExist(item Item) (bool, error) {
+	if cache.disableCaching {
+		return true, nil
+	}
+
+	client := cache.connPool.Get()
+	defer utils.WrapCloser(client.Close)
+
+	_, err := redigo.String(client.Do("SET", item.GetKey(), item.GetValue(), "EX", cache.decideCacheTTL(item), "NX"))
+	switch err {
+	case nil:
+		return true, nil
+	case redigo.ErrNil:
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
 // StoreMultiWithoutBlocking is used to store multiple items in the cache without acquiring locks.
 func (cache *cacheManager) StoreMultiWithoutBlocking(items []Item) error {
 	if cache.disableCaching {
@@ -582,6 +642,19 @@ func (cache *cacheManager) IncreaseCachedValueByOne(key string) error {
 	return err
 }
 
+// IncreaseCachedValueByOneWithTTL is used to increase the counter by one and return the increased value.
+// The ttl is only set when the counter has no ttl, so the counter expires the ttl after its creation.
+func (cache *cacheManager) IncreaseCachedValueByOneWithTTL(key string, ttl time.Duration) (int64, error) {
+	if cache.disableCaching {
+		return 0, nil
+	}
+
+	client := cache.connPool.Get()
+	defer utils.WrapCloser(client.Close)
+
+	return redigo.Int64(increaseWithTTLScript.Do(client, key, ttl.Milliseconds()))
+}
+
 // SetDefaultTTL is used to set the default time-to-live (TTL) for cache items in the cache manager.
 func (cache *cacheManager) SetDefaultTTL(duration time.Duration) {
 	cache.defaultTTL = duration
@@ -3,6 +3,7 @@ package utils
 import (
 	cryptoRand "crypto/rand"
 	"encoding/base64"
+	"math/big"
 	mathRand "math/rand"
 	"strings"
 	"time"
@@ -61,6 +62,23 @@ func GenerateRandomStringURLSafe(n int) (string, error) {
 	return base64.URLEncoding.EncodeToString(b), err
 }
 
+// GenerateRandomDigits returns a securely generated random numeric string,
+// each digit is uniformly distributed.
+func GenerateRandomDigits(n int) (string, error) {
+	sb := strings.Builder{}
+	sb.Grow(n)
+	max := big.NewInt(10)
+	for i := 0; i < n; i++ {
+		digit, err := cryptoRand.Int(cryptoRand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteByte(byte('0' + digit.Int64()))
+	}
+
+	return sb.String(), nil
+}
+
 // GenerateRandomAlphanumeric Generate random alphanumeric character adapted from
 // https://stackoverflow.com/questions/22892120/how-to-generate-a-random-string-of-a-fixed-length-in-go
 func GenerateRandomAlphanumeric(n int) string {
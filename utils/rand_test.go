@@ -1,6 +1,19 @@
 package utils
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GenerateRandomDigits(t *testing.T) {
+	digits, err := GenerateRandomDigits(6)
+	assert.NoError(t, err)
+	assert.Len(t, digits, 6)
+	for _, d := range digits {
+		assert.True(t, d >= '0' && d <= '9')
+	}
+}
 
 func BenchmarkGenerateRandomString(b *testing.B) {
 	for i := 0; i < b.N; i++ {
@@ -19,3 +32,9 @@ func BenchmarkGenerateRandomStringURLSafe(b *testing.B) {
 		_, _ = GenerateRandomStringURLSafe(100)
 	}
 }
+
+func BenchmarkGenerateRandomDigits(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = GenerateRandomDigits(6)
+	}
+}